@@ -9,15 +9,17 @@ import (
 	"github.com/roblovelock/gobble/pkg/parser/bits"
 	"github.com/roblovelock/gobble/pkg/parser/bytes"
 	"github.com/roblovelock/gobble/pkg/parser/numeric"
+	"github.com/roblovelock/gobble/pkg/parser/state"
 	"github.com/roblovelock/gobble/pkg/parser/stream"
 	"image"
 	"image/color"
 )
 
+// pixelContext is the decoder state: the previous pixel and the array of recently seen pixels. It is created for each
+// image by state.With, so the parser can be reused.
 type pixelContext struct {
 	c color.NRGBA
 	p [64]color.NRGBA
-	i image.Image
 }
 
 func (p *pixelContext) setColor(c color.NRGBA) {
@@ -49,16 +51,13 @@ func imageParser() parser.Parser[parser.Reader, image.Image] {
 }
 
 func headerParser() parser.Parser[parser.Reader, image.Rectangle] {
-	return modifier.Map(
+	return modifier.Map4(
 		sequence.Preceded(
 			bytes.Tag([]byte("qoif")),
-			sequence.Terminated(
-				sequence.Tuple[parser.Reader, uint32](numeric.Uint32BE(), numeric.Uint32BE()),
-				bytes.Take(2),
-			),
+			sequence.Tuple4(numeric.Uint32BE(), numeric.Uint32BE(), numeric.UInt8(), numeric.UInt8()),
 		),
-		func(i []uint32) (image.Rectangle, error) {
-			return image.Rect(0, 0, int(i[0]), int(i[1])), nil
+		func(width, height uint32, _, _ uint8) (image.Rectangle, error) {
+			return image.Rect(0, 0, int(width), int(height)), nil
 		},
 	)
 }
@@ -70,116 +69,118 @@ func endParser() parser.Parser[parser.Reader, parser.Empty] {
 	)
 }
 
-func rgbParser(ctx *pixelContext) parser.Parser[parser.Reader, []color.Color] {
+func rgbParser() parser.Parser[parser.Reader, []color.Color] {
 	return sequence.Preceded(
 		bytes.Byte(0xFE),
-		modifier.Map(
+		state.Update(
 			bytes.Take(3),
-			func(b []byte) ([]color.Color, error) {
+			func(ctx *pixelContext, b []byte) (*pixelContext, []color.Color, error) {
 				ctx.setColor(color.NRGBA{R: b[0], G: b[1], B: b[2], A: ctx.c.A})
-				return []color.Color{ctx.c}, nil
+				return ctx, []color.Color{ctx.c}, nil
 			},
 		),
 	)
 }
 
-func rgbaParser(ctx *pixelContext) parser.Parser[parser.Reader, []color.Color] {
+func rgbaParser() parser.Parser[parser.Reader, []color.Color] {
 	return sequence.Preceded(
 		bytes.Byte(0xFF),
-		modifier.Map(
+		state.Update(
 			bytes.Take(4),
-			func(b []byte) ([]color.Color, error) {
+			func(ctx *pixelContext, b []byte) (*pixelContext, []color.Color, error) {
 				ctx.setColor(color.NRGBA{R: b[0], G: b[1], B: b[2], A: b[3]})
-				return []color.Color{ctx.c}, nil
+				return ctx, []color.Color{ctx.c}, nil
 			},
 		),
 	)
 }
 
-func indexParser(ctx *pixelContext) parser.Parser[parser.BitReader, []color.Color] {
-	return modifier.Map[parser.BitReader, uint8, []color.Color](
+func indexParser() parser.Parser[parser.BitReader, []color.Color] {
+	return state.Update[parser.BitReader, uint8, *pixelContext, []color.Color](
 		branch.Alt(
+			// index 0 followed by a zero byte is the end marker
 			sequence.Terminated(
 				bits.Tag[uint8](6, 0),
-				modifier.Cut(modifier.Not(bits.Tag[uint8](8, 0))),
+				modifier.Not(bits.Tag[uint8](8, 0)),
 			),
-			bits.Take[uint8](6),
+			modifier.Verify(bits.Take[uint8](6), func(i uint8) bool { return i != 0 }),
 		),
-		func(i uint8) ([]color.Color, error) {
+		func(ctx *pixelContext, i uint8) (*pixelContext, []color.Color, error) {
 			ctx.setColor(ctx.p[i])
-			return []color.Color{ctx.c}, nil
+			return ctx, []color.Color{ctx.c}, nil
 		},
 	)
 }
 
-func diffParser(ctx *pixelContext) parser.Parser[parser.BitReader, []color.Color] {
-	return modifier.Map(
+func diffParser() parser.Parser[parser.BitReader, []color.Color] {
+	return state.Update(
 		sequence.Tuple[parser.BitReader, uint8](
 			bits.Take[uint8](2), bits.Take[uint8](2), bits.Take[uint8](2),
 		),
-		func(i []uint8) ([]color.Color, error) {
+		func(ctx *pixelContext, i []uint8) (*pixelContext, []color.Color, error) {
 			ctx.setColor(color.NRGBA{
 				R: ctx.c.R + i[0] - 2, G: ctx.c.G + i[1] - 2, B: ctx.c.B + i[2] - 2, A: ctx.c.A,
 			})
-			return []color.Color{ctx.c}, nil
+			return ctx, []color.Color{ctx.c}, nil
 		},
 	)
 }
 
-func lumaParser(ctx *pixelContext) parser.Parser[parser.BitReader, []color.Color] {
-	return modifier.Map(
+func lumaParser() parser.Parser[parser.BitReader, []color.Color] {
+	return state.Update(
 		sequence.Tuple[parser.BitReader, uint8](
 			bits.Take[uint8](6), bits.Take[uint8](4), bits.Take[uint8](4),
 		),
-		func(i []uint8) ([]color.Color, error) {
+		func(ctx *pixelContext, i []uint8) (*pixelContext, []color.Color, error) {
 			dg := i[0] - 32
 			dr := i[1] - 8 + dg
 			db := i[2] - 8 + dg
 
 			ctx.setColor(color.NRGBA{R: ctx.c.R + dr, G: ctx.c.G + dg, B: ctx.c.B + db, A: ctx.c.A})
-			return []color.Color{ctx.c}, nil
+			return ctx, []color.Color{ctx.c}, nil
 		},
 	)
 }
 
-func runParser(ctx *pixelContext) parser.Parser[parser.BitReader, []color.Color] {
-	return modifier.Map(
+func runParser() parser.Parser[parser.BitReader, []color.Color] {
+	return state.Update(
 		bits.Take[uint8](6),
-		func(count uint8) ([]color.Color, error) {
+		func(ctx *pixelContext, count uint8) (*pixelContext, []color.Color, error) {
 			c := make([]color.Color, count+1)
 			for i := uint8(0); i <= count; i++ {
 				c[i] = ctx.c
 			}
-			return c, nil
+			return ctx, c, nil
 		},
 	)
 }
 
-func bitPixelParser(ctx *pixelContext) parser.Parser[parser.Reader, []color.Color] {
+func bitPixelParser() parser.Parser[parser.Reader, []color.Color] {
 	parsers := map[uint8]parser.Parser[parser.BitReader, []color.Color]{
-		0x00: indexParser(ctx),
-		0x01: diffParser(ctx),
-		0x02: lumaParser(ctx),
-		0x03: runParser(ctx),
+		0x00: indexParser(),
+		0x01: diffParser(),
+		0x02: lumaParser(),
+		0x03: runParser(),
 	}
 
 	return bits.Bits(branch.Case(bits.Take[uint8](2), parsers))
 }
 
 func pixelParser() parser.Parser[parser.Reader, []color.Color] {
-	ctx := pixelContext{
-		c: color.NRGBA{A: 255},
-	}
-
-	return multi.FoldMany0(
-		branch.Alt(
-			rgbParser(&ctx),
-			rgbaParser(&ctx),
-			bitPixelParser(&ctx),
+	return state.With(
+		multi.FoldMany0(
+			branch.Alt(
+				rgbParser(),
+				rgbaParser(),
+				bitPixelParser(),
+			),
+			make([]color.Color, 0),
+			func(img []color.Color, c []color.Color) []color.Color {
+				return append(img, c...)
+			},
 		),
-		make([]color.Color, 0),
-		func(img []color.Color, c []color.Color) []color.Color {
-			return append(img, c...)
+		func() *pixelContext {
+			return &pixelContext{c: color.NRGBA{A: 255}}
 		},
 	)
 }
@@ -87,13 +87,13 @@ func stringParser() parser.Parser[parser.Reader, string] {
 		bytes.Byte('"'),
 		multi.FoldMany0(
 			fragmentParser(),
-			strings.Builder{},
-			func(builder strings.Builder, v string) strings.Builder {
+			&strings.Builder{},
+			func(builder *strings.Builder, v string) *strings.Builder {
 				builder.WriteString(v)
 				return builder
 			}),
 		bytes.Byte('"'),
-	), func(builder strings.Builder) (string, error) {
+	), func(builder *strings.Builder) (string, error) {
 		return builder.String(), nil
 	})
 }
@@ -1,43 +1,107 @@
 package branch
 
 import (
+	"bytes"
 	"github.com/roblovelock/gobble/pkg/errors"
 	"github.com/roblovelock/gobble/pkg/parser"
+	"io"
 )
 
 type (
 	altParser[R parser.Reader, T any] struct {
-		parsers []parser.Parser[R, T]
+		parsers []parser.Child[R, T]
 	}
 )
 
 func (o *altParser[R, T]) Parse(in R) (T, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *altParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *altParser[R, T]) ParseSession(s *parser.Session, in R) (T, error) {
+	var furthest error
 	for _, p := range o.parsers {
-		if r, err := p.Parse(in); err == nil {
+		if r, err := p.ParseIn(s, in); err == nil {
 			return r, nil
 		} else if errors.IsFatal(err) {
 			var t T
 			return t, err
+		} else {
+			furthest = errors.Furthest(furthest, err)
 		}
 	}
 	var r T
-	return r, errors.ErrNotMatched
+	return r, parser.NewError(in, errors.NotMatched(furthest))
 }
 
-func (o *altParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
+func (o *altParser[R, T]) ParseBytesSession(s *parser.Session, in []byte) (T, []byte, error) {
+	var furthest error
 	for _, p := range o.parsers {
-		if r, out, err := p.ParseBytes(in); err == nil {
+		if r, out, err := p.ParseBytesIn(s, in); err == nil {
 			return r, out, nil
 		} else if errors.IsFatal(err) {
 			var t T
 			return t, in, err
+		} else {
+			furthest = errors.Furthest(furthest, err)
 		}
 	}
 	var r T
-	return r, in, errors.ErrNotMatched
+	return r, in, parser.NewBytesError(in, errors.NotMatched(furthest))
+}
+
+func (o *altParser[R, T]) Describe() parser.Grammar {
+	children := make([]any, len(o.parsers))
+	for i, p := range o.parsers {
+		children[i] = p
+	}
+	return parser.NewGrammar(parser.GrammarChoice, children...)
+}
+
+func (o *altParser[R, T]) Expected() []string {
+	var expected []string
+	for _, p := range o.parsers {
+		expected = append(expected, parser.ExpectedOf(p)...)
+	}
+	return expected
+}
+
+// Print prints the value with the first alternative which can print it, skipping output that an earlier alternative
+// would match instead when it's parsed. The check only sees the printed output, so an earlier alternative which needs
+// more input, such as a longer tag, may still match the output followed by the rest of the input.
+func (o *altParser[R, T]) Print(w io.Writer, v T) error {
+	var buf bytes.Buffer
+	err := error(errors.ErrNotMatched)
+	for i, p := range o.parsers {
+		buf.Reset()
+		if err = p.Print(&buf, v); err != nil {
+			continue
+		}
+		if o.shadowed(i, buf.Bytes()) {
+			err = errors.ErrNotMatched
+			continue
+		}
+		_, err = w.Write(buf.Bytes())
+		return err
+	}
+	return err
+}
+
+// shadowed reports whether an alternative before the i'th matches the output printed by it.
+func (o *altParser[R, T]) shadowed(i int, out []byte) bool {
+	for _, p := range o.parsers[:i] {
+		if _, _, err := p.ParseBytesIn(parser.NewBytesSession(out), out); err == nil {
+			return true
+		}
+	}
+	return false
 }
 
-// Alt Trys a list of parsers and returns the result of the first successful one.
+// Alt Trys a list of parsers and returns the result of the first successful one. If none of the parsers match, the
+// error reports the furthest position reached and what each of the parsers expected there.
 func Alt[R parser.Reader, T any](parsers ...parser.Parser[R, T]) parser.Parser[R, T] {
-	return &altParser[R, T]{parsers: parsers}
+	return &altParser[R, T]{parsers: parser.NewChildren(parsers)}
 }
@@ -72,7 +72,7 @@ func TestAlt(t *testing.T) {
 			assert.Equal(t, tt.wantMatch, s)
 			assert.ErrorIs(t, err, tt.wantErr)
 
-			if err != io.EOF {
+			if !errors.Is(err, io.EOF) {
 				remain, err := io.ReadAll(tt.args.input)
 				require.NoError(t, err)
 				assert.Equal(t, tt.wantRemain, string(remain))
@@ -80,3 +80,67 @@ func TestAlt(t *testing.T) {
 		})
 	}
 }
+
+func TestAlt_expected(t *testing.T) {
+	tests := []struct {
+		name            string
+		parser          parser.Parser[parser.Reader, interface{}]
+		input           string
+		wantErrMsg      string
+		wantBytesErrMsg string
+	}{
+		{
+			name:            "mismatch => all alternatives expected",
+			parser:          Alt(parser.Untyped(bytes.Byte('a')), parser.Untyped(bytes.Tag([]byte("true")))),
+			input:           "xxxx",
+			wantErrMsg:      `expected 'a' or "true" at offset 0`,
+			wantBytesErrMsg: `expected 'a' or "true" at 4 bytes before end of input`,
+		},
+		{
+			name:            "alternative ran out of input => end of input reported",
+			parser:          Alt(parser.Untyped(bytes.Byte('a')), parser.Untyped(bytes.Tag([]byte("true")))),
+			input:           "x",
+			wantErrMsg:      `unexpected end of input, expected 'a' or "true" at offset 0`,
+			wantBytesErrMsg: `unexpected end of input, expected 'a' or "true" at 1 bytes before end of input`,
+		},
+		{
+			name:            "empty input => all alternatives expected",
+			parser:          Alt(parser.Untyped(bytes.Byte('a')), parser.Untyped(runes.Rune('😀'))),
+			input:           "",
+			wantErrMsg:      `unexpected end of input, expected 'a' or '😀' at offset 0`,
+			wantBytesErrMsg: `unexpected end of input, expected 'a' or '😀' at end of input`,
+		},
+		{
+			name: "duplicate expectations => listed once",
+			parser: Alt(
+				parser.Untyped(bytes.Byte('a')),
+				parser.Untyped(bytes.Byte('a')),
+				parser.Untyped(bytes.Byte('b')),
+			),
+			input:           "x",
+			wantErrMsg:      `expected 'a' or 'b' at offset 0`,
+			wantBytesErrMsg: `expected 'a' or 'b' at 1 bytes before end of input`,
+		},
+		{
+			name: "nested alternatives => flattened",
+			parser: Alt(
+				parser.Untyped(bytes.Byte('a')),
+				Alt(parser.Untyped(bytes.Byte('b')), parser.Untyped(bytes.Byte('c'))),
+			),
+			input:           "x",
+			wantErrMsg:      `expected 'a', 'b' or 'c' at offset 0`,
+			wantBytesErrMsg: `expected 'a', 'b' or 'c' at 1 bytes before end of input`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.parser.Parse(strings.NewReader(tt.input))
+			assert.ErrorIs(t, err, errors.ErrNotMatched)
+			assert.EqualError(t, err, tt.wantErrMsg)
+
+			_, _, err = tt.parser.ParseBytes([]byte(tt.input))
+			assert.ErrorIs(t, err, errors.ErrNotMatched)
+			assert.EqualError(t, err, tt.wantBytesErrMsg)
+		})
+	}
+}
@@ -1,99 +1,208 @@
 package branch
 
 import (
-	"github.com/roblovelock/gobble/pkg/combinator"
 	"github.com/roblovelock/gobble/pkg/errors"
 	"github.com/roblovelock/gobble/pkg/parser"
 	"io"
+	"sort"
+	"sync"
 )
 
 type (
 	peekCaseParser[R parser.Reader, T any] struct {
-		parsers map[byte]parser.Parser[R, T]
+		parsers      map[byte]parser.Child[R, T]
+		expected     []string
+		expectedOnce sync.Once
 	}
 
 	caseParser[R parser.Reader, C comparable, T any] struct {
-		parser        parser.Parser[R, C]
-		parsers       map[C]parser.Parser[R, T]
-		defaultParser parser.Parser[R, T]
+		parser        parser.Child[R, C]
+		parsers       map[C]parser.Child[R, T]
+		defaultParser parser.Child[R, T]
+		expected      []string
+		expectedOnce  sync.Once
 	}
 )
 
 func (o *caseParser[R, C, T]) Parse(in R) (T, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *caseParser[R, C, T]) ParseBytes(in []byte) (T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *caseParser[R, C, T]) ParseSession(s *parser.Session, in R) (T, error) {
 	currentOffset, _ := in.Seek(0, io.SeekCurrent)
-	c, err := o.parser.Parse(in)
+	c, err := o.parser.ParseIn(s, in)
 	if err != nil {
 		var t T
 		return t, err
 	}
 	choice, ok := o.parsers[c]
 	if !ok {
+		if o.defaultParser.Parser == nil {
+			_, _ = in.Seek(currentOffset, io.SeekStart)
+			var t T
+			return t, parser.NewExpectedError(in, errors.ErrNotMatched, o.caseExpected())
+		}
 		choice = o.defaultParser
 	}
 
-	result, err := choice.Parse(in)
+	result, err := choice.ParseIn(s, in)
 	if err != nil {
 		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return result, parser.NewError(in, err)
 	}
 
-	return result, err
+	return result, nil
 }
 
-func (o *caseParser[R, C, T]) ParseBytes(in []byte) (T, []byte, error) {
-	c, out, err := o.parser.ParseBytes(in)
+func (o *caseParser[R, C, T]) ParseBytesSession(s *parser.Session, in []byte) (T, []byte, error) {
+	c, out, err := o.parser.ParseBytesIn(s, in)
 	if err != nil {
 		var t T
 		return t, in, err
 	}
 	choice, ok := o.parsers[c]
 	if !ok {
+		if o.defaultParser.Parser == nil {
+			var t T
+			return t, in, parser.NewExpectedBytesError(in, errors.ErrNotMatched, o.caseExpected())
+		}
 		choice = o.defaultParser
 	}
 
-	result, out, err := choice.ParseBytes(out)
+	result, out, err := choice.ParseBytesIn(s, out)
 	if err != nil {
-		return result, in, err
+		return result, in, parser.NewBytesError(in, err)
 	}
 
 	return result, out, nil
 }
 
+// Describe describes the cases in the order of their value, followed by the default.
+func (o *caseParser[R, C, T]) Describe() parser.Grammar {
+	keys := make([]C, 0, len(o.parsers))
+	for c := range o.parsers {
+		keys = append(keys, c)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return errors.Quote(keys[i]) < errors.Quote(keys[j])
+	})
+
+	cases := make([]any, 0, len(keys)+1)
+	for _, c := range keys {
+		cases = append(cases, o.parsers[c])
+	}
+	if o.defaultParser.Parser != nil {
+		cases = append(cases, o.defaultParser)
+	}
+	return parser.NewGrammar(parser.GrammarSequence, o.parser, parser.NewGrammar(parser.GrammarChoice, cases...))
+}
+
+func (o *caseParser[R, C, T]) Expected() []string {
+	return parser.ExpectedOf(o.parser)
+}
+
+// caseExpected describes the values of the initial parser which have a matching case.
+func (o *caseParser[R, C, T]) caseExpected() []string {
+	o.expectedOnce.Do(func() {
+		o.expected = make([]string, 0, len(o.parsers))
+		for c := range o.parsers {
+			o.expected = append(o.expected, errors.Quote(c))
+		}
+		sort.Strings(o.expected)
+	})
+	return o.expected
+}
+
 func (o *peekCaseParser[R, T]) Parse(in R) (T, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *peekCaseParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *peekCaseParser[R, T]) ParseSession(s *parser.Session, in R) (T, error) {
 	b, err := in.ReadByte()
 	if err != nil {
 		var t T
-		return t, err
+		return t, parser.NewExpectedError(in, err, o.Expected())
 	}
 	_, _ = in.Seek(-1, io.SeekCurrent)
 	p, ok := o.parsers[b]
 	if !ok {
 		var t T
-		return t, errors.ErrNotMatched
+		return t, parser.NewExpectedError(in, errors.ErrNotMatched, o.Expected())
 	}
 
-	return p.Parse(in)
+	return p.ParseIn(s, in)
 }
 
-func (o *peekCaseParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
+func (o *peekCaseParser[R, T]) ParseBytesSession(s *parser.Session, in []byte) (T, []byte, error) {
 	if len(in) == 0 {
 		var t T
-		return t, in, io.EOF
+		return t, in, parser.NewExpectedBytesError(in, io.EOF, o.Expected())
 	}
 	p, ok := o.parsers[in[0]]
 	if !ok {
 		var t T
-		return t, in, errors.ErrNotMatched
+		return t, in, parser.NewExpectedBytesError(in, errors.ErrNotMatched, o.Expected())
+	}
+
+	return p.ParseBytesIn(s, in)
+}
+
+// Describe describes the parsers in the case map, in order of the byte they are chosen by.
+func (o *peekCaseParser[R, T]) Describe() parser.Grammar {
+	keys := make([]int, 0, len(o.parsers))
+	for b := range o.parsers {
+		keys = append(keys, int(b))
+	}
+	sort.Ints(keys)
+
+	cases := make([]any, len(keys))
+	for i, k := range keys {
+		cases[i] = o.parsers[byte(k)]
 	}
+	return parser.NewGrammar(parser.GrammarChoice, cases...)
+}
 
-	return p.ParseBytes(in)
+// Expected describes the parsers in the case map, in order of the byte they are chosen by. Parsers which don't
+// implement parser.Expecter are described by their byte.
+func (o *peekCaseParser[R, T]) Expected() []string {
+	o.expectedOnce.Do(func() {
+		keys := make([]int, 0, len(o.parsers))
+		for b := range o.parsers {
+			keys = append(keys, int(b))
+		}
+		sort.Ints(keys)
+
+		seen := make(map[string]bool, len(keys))
+		for _, k := range keys {
+			expected := parser.ExpectedOf(o.parsers[byte(k)])
+			if len(expected) == 0 {
+				expected = []string{errors.Quote(byte(k))}
+			}
+			for _, e := range expected {
+				if !seen[e] {
+					seen[e] = true
+					o.expected = append(o.expected, e)
+				}
+			}
+		}
+	})
+	return o.expected
 }
 
 // Case will choose which parser should process the input stream, from the provided map of parsers, based on the result
-// of the initial parser.
+// of the initial parser. If there is no matching parser the error lists the values which have one.
 func Case[R parser.Reader, C comparable, T any](
 	p parser.Parser[R, C], parsers map[C]parser.Parser[R, T],
 ) parser.Parser[R, T] {
-	return CaseOrDefault(p, parsers, combinator.Fail[R, T](errors.ErrNotMatched))
+	return &caseParser[R, C, T]{parser: parser.NewChild(p), parsers: newChildren(parsers)}
 }
 
 // CaseOrDefault will choose which parser should process the input stream, from the provided map of parsers, based on
@@ -101,11 +210,21 @@ func Case[R parser.Reader, C comparable, T any](
 func CaseOrDefault[R parser.Reader, C comparable, T any](
 	p parser.Parser[R, C], parsers map[C]parser.Parser[R, T], d parser.Parser[R, T],
 ) parser.Parser[R, T] {
-	return &caseParser[R, C, T]{parser: p, parsers: parsers, defaultParser: d}
+	return &caseParser[R, C, T]{parser: parser.NewChild(p), parsers: newChildren(parsers), defaultParser: parser.NewChild(d)}
 }
 
 // PeekCase will look ahead one byte and choose which parser should process the input stream, from the provided map of
-// parsers, based on the next byte value.
+// parsers, based on the next byte value. If there is no matching parser the error lists what each of the parsers
+// expected.
 func PeekCase[R parser.Reader, T any](parsers map[byte]parser.Parser[R, T]) parser.Parser[R, T] {
-	return &peekCaseParser[R, T]{parsers: parsers}
+	return &peekCaseParser[R, T]{parsers: newChildren(parsers)}
+}
+
+// newChildren returns the parsers of the cases as the children of a combinator.
+func newChildren[R parser.Reader, C comparable, T any](parsers map[C]parser.Parser[R, T]) map[C]parser.Child[R, T] {
+	children := make(map[C]parser.Child[R, T], len(parsers))
+	for c, p := range parsers {
+		children[c] = parser.NewChild(p)
+	}
+	return children
 }
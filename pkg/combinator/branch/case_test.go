@@ -1,8 +1,10 @@
 package branch
 
 import (
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
 	"github.com/roblovelock/gobble/pkg/errors"
 	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
 	"github.com/roblovelock/gobble/pkg/parser/runes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -264,3 +266,34 @@ func TestPeekCase(t *testing.T) {
 		})
 	}
 }
+
+func TestPeekCase_expected(t *testing.T) {
+	p := PeekCase(map[byte]parser.Parser[parser.Reader, string]{
+		'"': modifier.Map(bytes.Tag([]byte(`""`)), func(b []byte) (string, error) { return string(b), nil }),
+		'0': runes.TakeWhile1(unicode.IsDigit),
+		't': modifier.Value(bytes.Tag([]byte("true")), "true"),
+	})
+
+	_, err := p.Parse(strings.NewReader("x"))
+	assert.ErrorIs(t, err, errors.ErrNotMatched)
+	assert.EqualError(t, err, `expected "\"\"", '0' or "true" at offset 0`)
+
+	_, err = p.Parse(strings.NewReader(""))
+	assert.ErrorIs(t, err, io.EOF)
+	assert.EqualError(t, err, `unexpected end of input, expected "\"\"", '0' or "true" at offset 0`)
+}
+
+func TestCase_expected(t *testing.T) {
+	p := Case(bytes.One(), map[byte]parser.Parser[parser.Reader, string]{
+		'b': runes.Take(1),
+		'a': runes.Take(1),
+	})
+
+	_, err := p.Parse(strings.NewReader("cd"))
+	assert.ErrorIs(t, err, errors.ErrNotMatched)
+	assert.EqualError(t, err, `expected 'a' or 'b' at offset 0`)
+
+	_, _, err = p.ParseBytes([]byte("cd"))
+	assert.ErrorIs(t, err, errors.ErrNotMatched)
+	assert.EqualError(t, err, `expected 'a' or 'b' at 2 bytes before end of input`)
+}
@@ -7,18 +7,26 @@ import (
 
 type (
 	ifParser[R parser.Reader, C, T any] struct {
-		condition parser.Parser[R, C]
-		success   parser.Parser[R, T]
-		err       parser.Parser[R, T]
+		condition parser.Child[R, C]
+		success   parser.Child[R, T]
+		err       parser.Child[R, T]
 	}
 )
 
-func (o *ifParser[R, C, T]) Parse(in R) (result T, err error) {
+func (o *ifParser[R, C, T]) Parse(in R) (T, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *ifParser[R, C, T]) ParseBytes(in []byte) (T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *ifParser[R, C, T]) ParseSession(s *parser.Session, in R) (result T, err error) {
 	currentOffset, _ := in.Seek(0, io.SeekCurrent)
-	if _, err = o.condition.Parse(in); err != nil {
-		result, err = o.err.Parse(in)
+	if _, err = o.condition.ParseIn(s, in); err != nil {
+		result, err = o.err.ParseIn(s, in)
 	} else {
-		result, err = o.success.Parse(in)
+		result, err = o.success.ParseIn(s, in)
 	}
 
 	if err != nil {
@@ -28,11 +36,11 @@ func (o *ifParser[R, C, T]) Parse(in R) (result T, err error) {
 	return
 }
 
-func (o *ifParser[R, C, T]) ParseBytes(in []byte) (result T, out []byte, err error) {
-	if _, out, err = o.condition.ParseBytes(in); err != nil {
-		result, out, err = o.err.ParseBytes(in)
+func (o *ifParser[R, C, T]) ParseBytesSession(s *parser.Session, in []byte) (result T, out []byte, err error) {
+	if _, out, err = o.condition.ParseBytesIn(s, in); err != nil {
+		result, out, err = o.err.ParseBytesIn(s, in)
 	} else {
-		result, out, err = o.success.ParseBytes(in)
+		result, out, err = o.success.ParseBytesIn(s, in)
 	}
 
 	if err != nil {
@@ -41,9 +49,16 @@ func (o *ifParser[R, C, T]) ParseBytes(in []byte) (result T, out []byte, err err
 	return
 }
 
+func (o *ifParser[R, C, T]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarChoice,
+		parser.NewGrammar(parser.GrammarSequence, parser.NewGrammar(parser.GrammarPeek, o.condition), o.success),
+		parser.NewGrammar(parser.GrammarSequence, parser.NewGrammar(parser.GrammarNot, o.condition), o.err),
+	)
+}
+
 // If runs the conditional parser and chooses whether to run the success or error parser based on the outcome.
 func If[R parser.Reader, C, T any](
 	condition parser.Parser[R, C], success parser.Parser[R, T], err parser.Parser[R, T],
 ) parser.Parser[R, T] {
-	return &ifParser[R, C, T]{condition: condition, success: success, err: err}
+	return &ifParser[R, C, T]{condition: parser.NewChild(condition), success: parser.NewChild(success), err: parser.NewChild(err)}
 }
@@ -0,0 +1,353 @@
+// Package expr provides combinators for parsing expressions from a table of operators
+package expr
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"io"
+)
+
+const (
+	prefixOperator fixity = iota
+	infixLeftOperator
+	infixRightOperator
+	postfixOperator
+)
+
+type (
+	fixity int
+
+	// Operator is an entry in the operator table of Precedence. Operators with a higher power bind more tightly.
+	Operator[R parser.Reader, T any] struct {
+		fixity  fixity
+		power   int
+		parser  parser.Child[R, interface{}]
+		prefix  func(op interface{}, v T) (T, error)
+		infix   func(l T, op interface{}, r T) (T, error)
+		postfix func(v T, op interface{}) (T, error)
+	}
+
+	precedenceParser[R parser.Reader, T any] struct {
+		operand   parser.Child[R, T]
+		prefixes  []Operator[R, T]
+		infixes   []Operator[R, T]
+		postfixes []Operator[R, T]
+	}
+)
+
+func (o *precedenceParser[R, T]) Parse(in R) (T, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *precedenceParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *precedenceParser[R, T]) ParseSession(s *parser.Session, in R) (T, error) {
+	return o.parse(s, in, 0)
+}
+
+func (o *precedenceParser[R, T]) ParseBytesSession(s *parser.Session, in []byte) (T, []byte, error) {
+	return o.parseBytes(s, in, 0)
+}
+
+// Describe describes an expression as its prefix operators, operand and following operators, without their precedence.
+func (o *precedenceParser[R, T]) Describe() parser.Grammar {
+	operators := func(ops []Operator[R, T]) parser.Grammar {
+		choices := make([]any, len(ops))
+		for i, op := range ops {
+			choices[i] = op.parser
+		}
+		return parser.NewGrammar(parser.GrammarChoice, choices...)
+	}
+
+	operand := parser.NewGrammar(parser.GrammarSequence,
+		parser.NewRepeatGrammar(operators(o.prefixes), 0, -1), o.operand)
+	following := parser.NewGrammar(parser.GrammarChoice,
+		operators(o.postfixes), parser.NewGrammar(parser.GrammarSequence, operators(o.infixes), operand))
+	return parser.NewGrammar(parser.GrammarSequence, operand, parser.NewRepeatGrammar(following, 0, -1))
+}
+
+func (o *precedenceParser[R, T]) Expected() []string {
+	expected := parser.ExpectedOf(o.operand)
+	for _, op := range o.prefixes {
+		expected = append(expected, parser.ExpectedOf(op.parser)...)
+	}
+	return expected
+}
+
+// parse parses an expression containing operators which bind at least as tightly as minPower.
+func (o *precedenceParser[R, T]) parse(s *parser.Session, in R, minPower int) (T, error) {
+	currentOffset, _ := in.Seek(0, io.SeekCurrent)
+
+	lhs, err := o.parsePrefix(s, in)
+	if err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return lhs, err
+	}
+
+	for {
+		opOffset, _ := in.Seek(0, io.SeekCurrent)
+		op, v, err := o.match(s, in, o.postfixes, minPower)
+		if err != nil {
+			_, _ = in.Seek(currentOffset, io.SeekStart)
+			return lhs, err
+		}
+		if op != nil {
+			if lhs, err = op.postfix(lhs, v); err != nil {
+				_, _ = in.Seek(currentOffset, io.SeekStart)
+				return lhs, parser.NewError(in, err)
+			}
+			continue
+		}
+
+		op, v, err = o.match(s, in, o.infixes, minPower)
+		if err != nil {
+			_, _ = in.Seek(currentOffset, io.SeekStart)
+			return lhs, err
+		}
+		if op == nil {
+			return lhs, nil
+		}
+		rhs, err := o.parse(s, in, op.rightPower())
+		if err != nil {
+			if errors.IsFatal(err) {
+				_, _ = in.Seek(currentOffset, io.SeekStart)
+				return lhs, err
+			}
+			_, _ = in.Seek(opOffset, io.SeekStart)
+			return lhs, nil
+		}
+		if lhs, err = op.infix(lhs, v, rhs); err != nil {
+			_, _ = in.Seek(currentOffset, io.SeekStart)
+			return lhs, parser.NewError(in, err)
+		}
+	}
+}
+
+func (o *precedenceParser[R, T]) parsePrefix(s *parser.Session, in R) (T, error) {
+	op, v, err := o.match(s, in, o.prefixes, 0)
+	if err != nil {
+		var t T
+		return t, err
+	}
+	if op == nil {
+		return o.operand.ParseIn(s, in)
+	}
+
+	currentOffset, _ := in.Seek(0, io.SeekCurrent)
+	rhs, err := o.parse(s, in, op.rightPower())
+	if err != nil {
+		return rhs, err
+	}
+	r, err := op.prefix(v, rhs)
+	if err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return r, parser.NewError(in, err)
+	}
+	return r, nil
+}
+
+// match returns the first operator which matches the input and binds at least as tightly as minPower. The result is
+// nil if there isn't one, and the input is left unchanged. An operator which matches without consuming input is an
+// error, as it could be matched again forever.
+func (o *precedenceParser[R, T]) match(
+	s *parser.Session, in R, ops []Operator[R, T], minPower int,
+) (*Operator[R, T], interface{}, error) {
+	currentOffset, _ := in.Seek(0, io.SeekCurrent)
+	for i := range ops {
+		op := &ops[i]
+		if op.leftPower() < minPower {
+			continue
+		}
+		v, err := op.parser.ParseIn(s, in)
+		if err == nil {
+			if offset, _ := in.Seek(0, io.SeekCurrent); offset == currentOffset {
+				return nil, nil, parser.NewError(in, errors.NewLimitError(errors.ErrNoProgress))
+			}
+			return op, v, nil
+		}
+		if errors.IsFatal(err) {
+			return nil, nil, err
+		}
+	}
+	return nil, nil, nil
+}
+
+func (o *precedenceParser[R, T]) parseBytes(s *parser.Session, in []byte, minPower int) (T, []byte, error) {
+	lhs, out, err := o.parsePrefixBytes(s, in)
+	if err != nil {
+		return lhs, in, err
+	}
+
+	for {
+		op, v, next, err := o.matchBytes(s, out, o.postfixes, minPower)
+		if err != nil {
+			return lhs, in, err
+		}
+		if op != nil {
+			if lhs, err = op.postfix(lhs, v); err != nil {
+				return lhs, in, parser.NewBytesError(in, err)
+			}
+			out = next
+			continue
+		}
+
+		op, v, next, err = o.matchBytes(s, out, o.infixes, minPower)
+		if err != nil {
+			return lhs, in, err
+		}
+		if op == nil {
+			return lhs, out, nil
+		}
+		rhs, next, err := o.parseBytes(s, next, op.rightPower())
+		if err != nil {
+			if errors.IsFatal(err) {
+				return lhs, in, err
+			}
+			return lhs, out, nil
+		}
+		if lhs, err = op.infix(lhs, v, rhs); err != nil {
+			return lhs, in, parser.NewBytesError(in, err)
+		}
+		out = next
+	}
+}
+
+func (o *precedenceParser[R, T]) parsePrefixBytes(s *parser.Session, in []byte) (T, []byte, error) {
+	op, v, out, err := o.matchBytes(s, in, o.prefixes, 0)
+	if err != nil {
+		var t T
+		return t, in, err
+	}
+	if op == nil {
+		return o.operand.ParseBytesIn(s, in)
+	}
+
+	rhs, out, err := o.parseBytes(s, out, op.rightPower())
+	if err != nil {
+		return rhs, in, err
+	}
+	r, err := op.prefix(v, rhs)
+	if err != nil {
+		return r, in, parser.NewBytesError(in, err)
+	}
+	return r, out, nil
+}
+
+func (o *precedenceParser[R, T]) matchBytes(
+	s *parser.Session, in []byte, ops []Operator[R, T], minPower int,
+) (*Operator[R, T], interface{}, []byte, error) {
+	for i := range ops {
+		op := &ops[i]
+		if op.leftPower() < minPower {
+			continue
+		}
+		v, out, err := op.parser.ParseBytesIn(s, in)
+		if err == nil {
+			if len(out) == len(in) {
+				return nil, nil, in, parser.NewBytesError(in, errors.NewLimitError(errors.ErrNoProgress))
+			}
+			return op, v, out, nil
+		}
+		if errors.IsFatal(err) {
+			return nil, nil, in, err
+		}
+	}
+	return nil, nil, in, nil
+}
+
+// leftPower is the binding power of the operator to the expression on its left. Each power is doubled, leaving room
+// for associativity.
+func (op *Operator[R, T]) leftPower() int {
+	if op.fixity == prefixOperator {
+		return 0
+	}
+	return op.power * 2
+}
+
+// rightPower is the minimum binding power of the operators in the expression on the right of the operator. Left
+// associative operators stop the right hand side at operators of the same power.
+func (op *Operator[R, T]) rightPower() int {
+	if op.fixity == infixLeftOperator {
+		return op.power*2 + 1
+	}
+	return op.power * 2
+}
+
+// Prefix is an operator before its operand, such as negation. The operand contains the operators which bind more
+// tightly than power.
+func Prefix[R parser.Reader, O, T any](op parser.Parser[R, O], power int, fn func(O, T) (T, error)) Operator[R, T] {
+	return Operator[R, T]{
+		fixity: prefixOperator,
+		power:  power,
+		parser: parser.NewChild(parser.Untyped(op)),
+		prefix: func(op interface{}, v T) (T, error) {
+			return fn(op.(O), v)
+		},
+	}
+}
+
+// InfixLeft is a left associative binary operator, so "1-2-3" is parsed as "(1-2)-3".
+func InfixLeft[R parser.Reader, O, T any](
+	op parser.Parser[R, O], power int, fn func(T, O, T) (T, error),
+) Operator[R, T] {
+	return infix(infixLeftOperator, op, power, fn)
+}
+
+// InfixRight is a right associative binary operator, so "2^3^2" is parsed as "2^(3^2)".
+func InfixRight[R parser.Reader, O, T any](
+	op parser.Parser[R, O], power int, fn func(T, O, T) (T, error),
+) Operator[R, T] {
+	return infix(infixRightOperator, op, power, fn)
+}
+
+func infix[R parser.Reader, O, T any](
+	f fixity, op parser.Parser[R, O], power int, fn func(T, O, T) (T, error),
+) Operator[R, T] {
+	return Operator[R, T]{
+		fixity: f,
+		power:  power,
+		parser: parser.NewChild(parser.Untyped(op)),
+		infix: func(l T, op interface{}, r T) (T, error) {
+			return fn(l, op.(O), r)
+		},
+	}
+}
+
+// Postfix is an operator after its operand, such as a factorial.
+func Postfix[R parser.Reader, O, T any](op parser.Parser[R, O], power int, fn func(T, O) (T, error)) Operator[R, T] {
+	return Operator[R, T]{
+		fixity: postfixOperator,
+		power:  power,
+		parser: parser.NewChild(parser.Untyped(op)),
+		postfix: func(v T, op interface{}) (T, error) {
+			return fn(v, op.(O))
+		},
+	}
+}
+
+// Precedence parses expressions made of operands and the operators in the table, using precedence climbing (a Pratt
+// parser). Each operator has a binding power, and operators with a higher power bind more tightly. The fold functions
+// of the operators are applied as the expression is parsed, so the result can be an evaluated value or an AST.
+//
+// When several operators of the same kind match, the first in the table is used. If an infix operator isn't followed
+// by an operand, the expression ends before the operator.
+//   - If the input is empty, it will return io.EOF
+//   - If the input doesn't start with an operand or prefix operator, it will return errors.ErrNotMatched
+//   - If an operator matches without consuming input, it will return errors.ErrLimitExceeded wrapping
+//     errors.ErrNoProgress
+func Precedence[R parser.Reader, T any](operand parser.Parser[R, T], operators ...Operator[R, T]) parser.Parser[R, T] {
+	p := &precedenceParser[R, T]{operand: parser.NewChild(operand)}
+	for _, op := range operators {
+		switch op.fixity {
+		case prefixOperator:
+			p.prefixes = append(p.prefixes, op)
+		case postfixOperator:
+			p.postfixes = append(p.postfixes, op)
+		default:
+			p.infixes = append(p.infixes, op)
+		}
+	}
+	return p
+}
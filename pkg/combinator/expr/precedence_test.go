@@ -0,0 +1,192 @@
+package expr_test
+
+import (
+	"fmt"
+	"github.com/roblovelock/gobble/pkg/combinator/expr"
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/ascii"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+// tree builds an AST as a fully parenthesised string, which shows how the operators were grouped.
+func tree() parser.Parser[parser.Reader, string] {
+	operand := modifier.Map(ascii.Digit(), func(b byte) (string, error) { return string(b), nil })
+	binary := func(l string, op byte, r string) (string, error) {
+		return fmt.Sprintf("(%s%c%s)", l, op, r), nil
+	}
+	return expr.Precedence(
+		operand,
+		expr.InfixLeft(bytes.OneOf('+', '-'), 1, binary),
+		expr.InfixLeft(bytes.OneOf('*', '/'), 2, binary),
+		expr.InfixRight(bytes.Byte('^'), 4, binary),
+		expr.Prefix(bytes.Byte('-'), 3, func(op byte, v string) (string, error) {
+			return fmt.Sprintf("(-%s)", v), nil
+		}),
+		expr.Postfix(bytes.Byte('!'), 5, func(v string, op byte) (string, error) {
+			return fmt.Sprintf("(%s!)", v), nil
+		}),
+	)
+}
+
+func TestPrecedence(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantMatch  string
+		wantRemain string
+		wantErr    error
+	}{
+		{
+			name:    "empty input => EOF",
+			input:   "",
+			wantErr: io.EOF,
+		},
+		{
+			name:       "no operand => no match",
+			input:      "*1",
+			wantRemain: "*1",
+			wantErr:    errors.ErrNotMatched,
+		},
+		{
+			name:      "operand => operand",
+			input:     "7",
+			wantMatch: "7",
+		},
+		{
+			name:      "left associative => grouped left",
+			input:     "1-2-3",
+			wantMatch: "((1-2)-3)",
+		},
+		{
+			name:      "right associative => grouped right",
+			input:     "2^3^2",
+			wantMatch: "(2^(3^2))",
+		},
+		{
+			name:      "higher power => binds tighter",
+			input:     "1+2*3-4",
+			wantMatch: "((1+(2*3))-4)",
+		},
+		{
+			name:      "prefix => binds looser than higher power",
+			input:     "-2^2*3",
+			wantMatch: "((-(2^2))*3)",
+		},
+		{
+			name:      "postfix => binds tightest",
+			input:     "-3!^2",
+			wantMatch: "(-((3!)^2))",
+		},
+		{
+			name:       "trailing operator => expression before operator",
+			input:      "1+2*",
+			wantMatch:  "(1+2)",
+			wantRemain: "*",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tree()
+
+			input := strings.NewReader(tt.input)
+			s, err := p.Parse(input)
+
+			assert.Equal(t, tt.wantMatch, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			remain, err := io.ReadAll(input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRemain, string(remain))
+
+			s, out, err := p.ParseBytes([]byte(tt.input))
+
+			assert.Equal(t, tt.wantMatch, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantRemain, string(out))
+		})
+	}
+}
+
+func TestPrecedence_cut(t *testing.T) {
+	operand := modifier.Map(ascii.Digit1(), func(b []byte) (string, error) { return string(b), nil })
+	p := expr.Precedence(
+		operand,
+		expr.InfixLeft(sequence.Terminated(bytes.Byte('+'), modifier.Cut(modifier.Peek(ascii.Digit()))), 1,
+			func(l string, op byte, r string) (string, error) {
+				return l + "+" + r, nil
+			},
+		),
+	)
+
+	_, err := p.Parse(strings.NewReader("1+x"))
+
+	assert.ErrorIs(t, err, errors.ErrNotMatched)
+	assert.True(t, errors.IsFatal(err))
+}
+
+func TestPrecedence_emptyOperator(t *testing.T) {
+	operand := modifier.Map(ascii.Digit(), func(b byte) (string, error) { return string(b), nil })
+	empty := modifier.Optional(bytes.Byte('?'))
+	tests := []struct {
+		name     string
+		operator expr.Operator[parser.Reader, string]
+	}{
+		{
+			name: "postfix",
+			operator: expr.Postfix(empty, 1, func(v string, _ byte) (string, error) {
+				return v + "?", nil
+			}),
+		},
+		{
+			name: "prefix",
+			operator: expr.Prefix(empty, 1, func(_ byte, v string) (string, error) {
+				return "?" + v, nil
+			}),
+		},
+		{
+			name: "infix",
+			operator: expr.InfixLeft(empty, 1, func(l string, _ byte, r string) (string, error) {
+				return l + "?" + r, nil
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := expr.Precedence(operand, tt.operator)
+
+			input := strings.NewReader("12")
+			_, err := p.Parse(input)
+			assert.ErrorIs(t, err, errors.ErrNoProgress)
+			assert.True(t, errors.IsFatal(err))
+			assert.Equal(t, 2, input.Len())
+
+			_, out, err := p.ParseBytes([]byte("12"))
+			assert.ErrorIs(t, err, errors.ErrNoProgress)
+			assert.Equal(t, "12", string(out))
+		})
+	}
+}
+
+func ExamplePrecedence() {
+	p := expr.Precedence(
+		sequence.Delimited(ascii.SkipWhitespace0(), ascii.Int64(), ascii.SkipWhitespace0()),
+		expr.InfixLeft(bytes.Byte('+'), 1, func(l int64, _ byte, r int64) (int64, error) { return l + r, nil }),
+		expr.InfixLeft(bytes.Byte('-'), 1, func(l int64, _ byte, r int64) (int64, error) { return l - r, nil }),
+		expr.InfixLeft(bytes.Byte('*'), 2, func(l int64, _ byte, r int64) (int64, error) { return l * r, nil }),
+		expr.Prefix(bytes.Byte('-'), 3, func(_ byte, v int64) (int64, error) { return -v, nil }),
+	)
+
+	result, err := p.Parse(strings.NewReader("1 + 2 * 3 - -4"))
+	fmt.Printf("Result: %d, Error: %v", result, err)
+
+	// Output:
+	// Result: 11, Error: <nil>
+}
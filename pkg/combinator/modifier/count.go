@@ -7,27 +7,39 @@ type (
 		[]T | map[any]T
 	}
 	countParser[R parser.Reader, V any, T countParserConstraint[V]] struct {
-		parser parser.Parser[R, T]
+		parser parser.Child[R, T]
 	}
 )
 
 func (o *countParser[R, V, T]) Parse(in R) (int, error) {
-	v, err := o.parser.Parse(in)
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *countParser[R, V, T]) ParseBytes(in []byte) (int, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *countParser[R, V, T]) ParseSession(s *parser.Session, in R) (int, error) {
+	v, err := o.parser.ParseIn(s, in)
 	if err != nil {
 		return 0, err
 	}
 	return len(v), nil
 }
 
-func (o *countParser[R, V, T]) ParseBytes(in []byte) (int, []byte, error) {
-	v, out, err := o.parser.ParseBytes(in)
+func (o *countParser[R, V, T]) ParseBytesSession(s *parser.Session, in []byte) (int, []byte, error) {
+	v, out, err := o.parser.ParseBytesIn(s, in)
 	if err != nil {
 		return 0, in, err
 	}
 	return len(v), out, nil
 }
 
+func (o *countParser[R, V, T]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.parser)
+}
+
 // Count will return the length of the value returned from the parser
 func Count[R parser.Reader, V any, T countParserConstraint[V]](p parser.Parser[R, T]) parser.Parser[R, int] {
-	return &countParser[R, V, T]{parser: p}
+	return &countParser[R, V, T]{parser: parser.NewChild(p)}
 }
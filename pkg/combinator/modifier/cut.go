@@ -3,30 +3,51 @@ package modifier
 import (
 	"github.com/roblovelock/gobble/pkg/errors"
 	"github.com/roblovelock/gobble/pkg/parser"
+	"io"
 )
 
 type (
 	cutParser[R parser.Reader, T any] struct {
-		parser parser.Parser[R, T]
+		parser parser.Child[R, T]
 	}
 )
 
 func (o *cutParser[R, T]) Parse(in R) (T, error) {
-	r, err := o.parser.Parse(in)
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *cutParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *cutParser[R, T]) ParseSession(s *parser.Session, in R) (T, error) {
+	r, err := o.parser.ParseIn(s, in)
 	if err != nil {
 		err = errors.NewFatalError(err)
 	}
 	return r, err
 }
 
-func (o *cutParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
-	t, out, err := o.parser.ParseBytes(in)
+func (o *cutParser[R, T]) ParseBytesSession(s *parser.Session, in []byte) (T, []byte, error) {
+	t, out, err := o.parser.ParseBytesIn(s, in)
 	if err != nil {
 		return t, in, errors.NewFatalError(err)
 	}
 	return t, out, nil
 }
 
+func (o *cutParser[R, T]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.parser)
+}
+
+func (o *cutParser[R, T]) Expected() []string {
+	return parser.ExpectedOf(o.parser)
+}
+
+func (o *cutParser[R, T]) Print(w io.Writer, v T) error {
+	return o.parser.Print(w, v)
+}
+
 func Cut[R parser.Reader, T any](p parser.Parser[R, T]) parser.Parser[R, T] {
-	return &cutParser[R, T]{parser: p}
+	return &cutParser[R, T]{parser: parser.NewChild(p)}
 }
@@ -0,0 +1,61 @@
+package modifier
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"io"
+)
+
+type (
+	labelParser[R parser.Reader, T any] struct {
+		parser parser.Child[R, T]
+		rule   parser.Child[R, T]
+		label  string
+	}
+)
+
+func (o *labelParser[R, T]) Parse(in R) (T, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *labelParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *labelParser[R, T]) ParseSession(s *parser.Session, in R) (T, error) {
+	r, err := o.rule.ParseIn(s, in)
+	if err != nil {
+		return r, errors.WithContext(o.label, err)
+	}
+	return r, nil
+}
+
+func (o *labelParser[R, T]) ParseBytesSession(s *parser.Session, in []byte) (T, []byte, error) {
+	r, out, err := o.rule.ParseBytesIn(s, in)
+	if err != nil {
+		return r, in, errors.WithContext(o.label, err)
+	}
+	return r, out, nil
+}
+
+func (o *labelParser[R, T]) Describe() parser.Grammar {
+	return parser.DescribeOf(o.rule)
+}
+
+func (o *labelParser[R, T]) Expected() []string {
+	return parser.ExpectedOf(o.parser)
+}
+
+func (o *labelParser[R, T]) Print(w io.Writer, v T) error {
+	if err := o.parser.Print(w, v); err != nil {
+		return errors.WithContext(o.label, err)
+	}
+	return nil
+}
+
+// Label names the rule matched by the parser. If the parser fails its error is wrapped with the label, building a
+// stack of the rules which were being parsed, e.g. "in object > in field value: expected ','". The wrapped error keeps
+// its position and whether it is fatal. The label is also the name of the parser.Rule reported by traced parses.
+func Label[R parser.Reader, T any](p parser.Parser[R, T], label string) parser.Parser[R, T] {
+	return &labelParser[R, T]{parser: parser.NewChild(p), rule: parser.NewChild(parser.Rule(label, p)), label: label}
+}
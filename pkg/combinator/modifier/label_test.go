@@ -0,0 +1,114 @@
+package modifier_test
+
+import (
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLabel(t *testing.T) {
+	field := modifier.Label(
+		sequence.Preceded(bytes.Byte('a'), bytes.Byte(',')),
+		"field value",
+	)
+
+	tests := []struct {
+		name        string
+		parser      parser.Parser[parser.Reader, byte]
+		input       string
+		wantMatch   byte
+		wantRemain  string
+		wantErr     error
+		wantErrMsg  string
+		wantFatal   bool
+		wantContext []string
+	}{
+		{
+			name:        "empty input => EOF in context",
+			parser:      modifier.Label(field, "object"),
+			input:       "",
+			wantErr:     io.EOF,
+			wantErrMsg:  "in object > in field value: unexpected end of input, expected 'a' at offset 0",
+			wantContext: []string{"object", "field value"},
+		},
+		{
+			name:        "mismatch => no match in context",
+			parser:      modifier.Label(field, "object"),
+			input:       "ab",
+			wantRemain:  "ab",
+			wantErr:     errors.ErrNotMatched,
+			wantErrMsg:  "in object > in field value: expected ',' at offset 1",
+			wantContext: []string{"object", "field value"},
+		},
+		{
+			name:        "cut inside label => fatal in context",
+			parser:      modifier.Label(modifier.Cut(field), "object"),
+			input:       "ab",
+			wantRemain:  "ab",
+			wantErr:     errors.ErrNotMatched,
+			wantErrMsg:  "in object > in field value: expected ',' at offset 1",
+			wantFatal:   true,
+			wantContext: []string{"object", "field value"},
+		},
+		{
+			name:        "label inside cut => fatal in context",
+			parser:      modifier.Cut(modifier.Label(field, "object")),
+			input:       "ab",
+			wantRemain:  "ab",
+			wantErr:     errors.ErrNotMatched,
+			wantErrMsg:  "in object > in field value: expected ',' at offset 1",
+			wantFatal:   true,
+			wantContext: []string{"object", "field value"},
+		},
+		{
+			name:       "match => match",
+			parser:     modifier.Label(field, "object"),
+			input:      "a,b",
+			wantMatch:  ',',
+			wantRemain: "b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := strings.NewReader(tt.input)
+			s, err := tt.parser.Parse(input)
+
+			assert.Equal(t, tt.wantMatch, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantFatal, errors.IsFatal(err))
+			assert.Equal(t, tt.wantContext, errors.Context(err))
+			if tt.wantErrMsg != "" {
+				assert.EqualError(t, err, tt.wantErrMsg)
+			}
+
+			remain, err := io.ReadAll(input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRemain, string(remain))
+		})
+	}
+}
+
+func TestLabel_bytes(t *testing.T) {
+	p := modifier.Label(
+		modifier.Label(sequence.Preceded(bytes.Byte('a'), bytes.Byte(',')), "field value"),
+		"object",
+	)
+
+	s, out, err := p.ParseBytes([]byte("ab"))
+
+	assert.Equal(t, byte(0), s)
+	assert.Equal(t, []byte("ab"), out)
+	assert.ErrorIs(t, err, errors.ErrNotMatched)
+	assert.EqualError(t, err, "in object > in field value: expected ',' at 1 bytes before end of input")
+
+	e, ok := errors.AsParseError(err)
+	require.True(t, ok)
+	assert.Equal(t, int64(1), e.StartOffset(2))
+}
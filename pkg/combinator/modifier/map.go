@@ -1,20 +1,30 @@
 package modifier
 
 import (
+	"github.com/roblovelock/gobble/pkg/errors"
 	"github.com/roblovelock/gobble/pkg/parser"
 	"io"
 )
 
 type (
 	mapParser[R parser.Reader, T, V any] struct {
-		parser parser.Parser[R, T]
+		parser parser.Child[R, T]
 		fn     parser.MapFunc[T, V]
+		unmap  parser.MapFunc[V, T]
 	}
 )
 
 func (o *mapParser[R, T, V]) Parse(in R) (V, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *mapParser[R, T, V]) ParseBytes(in []byte) (V, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *mapParser[R, T, V]) ParseSession(s *parser.Session, in R) (V, error) {
 	currentOffset, _ := in.Seek(0, io.SeekCurrent)
-	t, err := o.parser.Parse(in)
+	t, err := o.parser.ParseIn(s, in)
 	if err != nil {
 		var v V
 		return v, err
@@ -22,24 +32,53 @@ func (o *mapParser[R, T, V]) Parse(in R) (V, error) {
 	r, err := o.fn(t)
 	if err != nil {
 		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return r, parser.NewError(in, err)
 	}
-	return r, err
+	return r, nil
 }
 
-func (o *mapParser[R, T, V]) ParseBytes(in []byte) (V, []byte, error) {
-	t, out, err := o.parser.ParseBytes(in)
+func (o *mapParser[R, T, V]) ParseBytesSession(s *parser.Session, in []byte) (V, []byte, error) {
+	t, out, err := o.parser.ParseBytesIn(s, in)
 	if err != nil {
 		var v V
 		return v, in, err
 	}
 	r, err := o.fn(t)
 	if err != nil {
-		return r, in, err
+		return r, in, parser.NewBytesError(in, err)
 	}
 	return r, out, err
 }
 
+func (o *mapParser[R, T, V]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.parser)
+}
+
+func (o *mapParser[R, T, V]) Expected() []string {
+	return parser.ExpectedOf(o.parser)
+}
+
+// Print converts the value back with the unmap function of Bimap. Parsers created by Map can't be printed.
+func (o *mapParser[R, T, V]) Print(w io.Writer, v V) error {
+	if o.unmap == nil {
+		return errors.ErrNotSupported
+	}
+	t, err := o.unmap(v)
+	if err != nil {
+		return err
+	}
+	return o.parser.Print(w, t)
+}
+
 // Map passes the output from the parser to the map function, before returning the mapped result.
 func Map[R parser.Reader, T, V any](p parser.Parser[R, T], mapFunc parser.MapFunc[T, V]) parser.Parser[R, V] {
-	return &mapParser[R, T, V]{parser: p, fn: mapFunc}
+	return &mapParser[R, T, V]{parser: parser.NewChild(p), fn: mapFunc}
+}
+
+// Bimap is a Map which can also be printed. The unmap function converts a value back into the output of the parser,
+// and should be the inverse of the map function.
+func Bimap[R parser.Reader, T, V any](
+	p parser.Parser[R, T], mapFunc parser.MapFunc[T, V], unmapFunc parser.MapFunc[V, T],
+) parser.Parser[R, V] {
+	return &mapParser[R, T, V]{parser: parser.NewChild(p), fn: mapFunc, unmap: unmapFunc}
 }
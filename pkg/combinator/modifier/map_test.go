@@ -86,7 +86,7 @@ func TestMap(t *testing.T) {
 
 			assert.Equal(t, tt.wantMatch, s)
 			if tt.wantErr != nil {
-				assert.EqualError(t, err, tt.wantErr.Error())
+				assert.ErrorContains(t, err, tt.wantErr.Error())
 			} else {
 				assert.NoError(t, err)
 			}
@@ -0,0 +1,59 @@
+package modifier
+
+import (
+	"github.com/roblovelock/gobble/pkg/parser"
+)
+
+// Map3 calls mapFunc with the values of the parser.Tuple3 produced by the parser, e.g. by sequence.Tuple3.
+func Map3[R parser.Reader, A, B, C, V any](
+	p parser.Parser[R, parser.Tuple3[A, B, C]], mapFunc func(A, B, C) (V, error),
+) parser.Parser[R, V] {
+	return Map(p, func(t parser.Tuple3[A, B, C]) (V, error) {
+		return mapFunc(t.First, t.Second, t.Third)
+	})
+}
+
+// Map4 calls mapFunc with the values of the parser.Tuple4 produced by the parser, e.g. by sequence.Tuple4.
+func Map4[R parser.Reader, A, B, C, D, V any](
+	p parser.Parser[R, parser.Tuple4[A, B, C, D]], mapFunc func(A, B, C, D) (V, error),
+) parser.Parser[R, V] {
+	return Map(p, func(t parser.Tuple4[A, B, C, D]) (V, error) {
+		return mapFunc(t.First, t.Second, t.Third, t.Fourth)
+	})
+}
+
+// Map5 calls mapFunc with the values of the parser.Tuple5 produced by the parser, e.g. by sequence.Tuple5.
+func Map5[R parser.Reader, A, B, C, D, E, V any](
+	p parser.Parser[R, parser.Tuple5[A, B, C, D, E]], mapFunc func(A, B, C, D, E) (V, error),
+) parser.Parser[R, V] {
+	return Map(p, func(t parser.Tuple5[A, B, C, D, E]) (V, error) {
+		return mapFunc(t.First, t.Second, t.Third, t.Fourth, t.Fifth)
+	})
+}
+
+// Map6 calls mapFunc with the values of the parser.Tuple6 produced by the parser, e.g. by sequence.Tuple6.
+func Map6[R parser.Reader, A, B, C, D, E, F, V any](
+	p parser.Parser[R, parser.Tuple6[A, B, C, D, E, F]], mapFunc func(A, B, C, D, E, F) (V, error),
+) parser.Parser[R, V] {
+	return Map(p, func(t parser.Tuple6[A, B, C, D, E, F]) (V, error) {
+		return mapFunc(t.First, t.Second, t.Third, t.Fourth, t.Fifth, t.Sixth)
+	})
+}
+
+// Map7 calls mapFunc with the values of the parser.Tuple7 produced by the parser, e.g. by sequence.Tuple7.
+func Map7[R parser.Reader, A, B, C, D, E, F, G, V any](
+	p parser.Parser[R, parser.Tuple7[A, B, C, D, E, F, G]], mapFunc func(A, B, C, D, E, F, G) (V, error),
+) parser.Parser[R, V] {
+	return Map(p, func(t parser.Tuple7[A, B, C, D, E, F, G]) (V, error) {
+		return mapFunc(t.First, t.Second, t.Third, t.Fourth, t.Fifth, t.Sixth, t.Seventh)
+	})
+}
+
+// Map8 calls mapFunc with the values of the parser.Tuple8 produced by the parser, e.g. by sequence.Tuple8.
+func Map8[R parser.Reader, A, B, C, D, E, F, G, H, V any](
+	p parser.Parser[R, parser.Tuple8[A, B, C, D, E, F, G, H]], mapFunc func(A, B, C, D, E, F, G, H) (V, error),
+) parser.Parser[R, V] {
+	return Map(p, func(t parser.Tuple8[A, B, C, D, E, F, G, H]) (V, error) {
+		return mapFunc(t.First, t.Second, t.Third, t.Fourth, t.Fifth, t.Sixth, t.Seventh, t.Eighth)
+	})
+}
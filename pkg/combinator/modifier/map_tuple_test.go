@@ -0,0 +1,34 @@
+package modifier_test
+
+import (
+	"fmt"
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/roblovelock/gobble/pkg/parser/numeric"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestMap4(t *testing.T) {
+	p := modifier.Map4(
+		sequence.Tuple4(bytes.Tag([]byte("hdr")), numeric.Uint16BE(), numeric.UInt8(), numeric.Bool()),
+		func(tag []byte, size uint16, version uint8, compressed bool) (string, error) {
+			if version != 1 {
+				return "", errors.ErrNotMatched
+			}
+			return fmt.Sprintf("%s:%d:%t", tag, size, compressed), nil
+		},
+	)
+
+	s, err := p.Parse(strings.NewReader("hdr\x01\x02\x01\x01"))
+	require.NoError(t, err)
+	assert.Equal(t, "hdr:258:true", s)
+
+	_, out, err := p.ParseBytes([]byte("hdr\x01\x02\x02\x01"))
+	assert.ErrorIs(t, err, errors.ErrNotMatched)
+	assert.Equal(t, []byte("hdr\x01\x02\x02\x01"), out)
+}
@@ -0,0 +1,82 @@
+package modifier
+
+import (
+	"github.com/roblovelock/gobble/pkg/parser"
+	"io"
+)
+
+type (
+	memoParser[R parser.Reader, T any] struct {
+		parser parser.Child[R, T]
+	}
+
+	// memoKey is the start position of a call.
+	memoKey struct {
+		parser any
+		scope  any
+		offset int64
+	}
+
+	// memoEntry is the outcome of a call. end is the offset after the call, or the length of the remaining input for
+	// ParseBytes.
+	memoEntry struct {
+		result any
+		end    int64
+		err    error
+	}
+)
+
+func (o *memoParser[R, T]) Parse(in R) (T, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *memoParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *memoParser[R, T]) ParseSession(s *parser.Session, in R) (T, error) {
+	start, _ := in.Seek(0, io.SeekCurrent)
+	key := memoKey{parser: o, scope: s.Scope(), offset: start}
+	if v, ok := s.Load(key); ok {
+		e := v.(memoEntry)
+		_, _ = in.Seek(e.end, io.SeekStart)
+		r, _ := e.result.(T)
+		return r, e.err
+	}
+
+	r, err := o.parser.ParseIn(s, in)
+	end, _ := in.Seek(0, io.SeekCurrent)
+	s.Store(key, memoEntry{result: r, end: end, err: err})
+	return r, err
+}
+
+func (o *memoParser[R, T]) ParseBytesSession(s *parser.Session, in []byte) (T, []byte, error) {
+	key := memoKey{parser: o, scope: s.Scope(), offset: s.Offset(in)}
+	if v, ok := s.Load(key); ok {
+		e := v.(memoEntry)
+		r, _ := e.result.(T)
+		return r, in[int64(len(in))-e.end:], e.err
+	}
+
+	r, out, err := o.parser.ParseBytesIn(s, in)
+	s.Store(key, memoEntry{result: r, end: int64(len(out)), err: err})
+	return r, out, err
+}
+
+func (o *memoParser[R, T]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.parser)
+}
+
+func (o *memoParser[R, T]) Expected() []string {
+	return parser.ExpectedOf(o.parser)
+}
+
+// Memo caches the result of the parser by its start position, so backtracking alternatives which try the same rule at
+// the same offset only parse it once (packrat parsing). The cache is held in the parser.Session of the parse, so it is
+// shared by the whole parse, and discarded when the parse returns.
+//
+// The cached value is shared by every call which hits the cache, so results which are modified by the caller, or
+// parsers that depend on anything other than the input, shouldn't be memoized.
+func Memo[R parser.Reader, T any](p parser.Parser[R, T]) parser.Parser[R, T] {
+	return &memoParser[R, T]{parser: parser.NewChild(p)}
+}
@@ -0,0 +1,159 @@
+package modifier_test
+
+import (
+	"github.com/roblovelock/gobble/pkg/combinator/branch"
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/combinator/multi"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/ascii"
+	"github.com/roblovelock/gobble/pkg/parser/bits"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type countingParser[T any] struct {
+	parser parser.Parser[parser.Reader, T]
+	calls  int
+}
+
+func (c *countingParser[T]) Parse(in parser.Reader) (T, error) {
+	c.calls++
+	return c.parser.Parse(in)
+}
+
+func (c *countingParser[T]) ParseBytes(in []byte) (T, []byte, error) {
+	c.calls++
+	return c.parser.ParseBytes(in)
+}
+
+func TestMemo(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantMatch  byte
+		wantRemain string
+		wantErr    error
+		wantCalls  int
+	}{
+		{
+			name:       "match => rule parsed once",
+			input:      "123b",
+			wantMatch:  'b',
+			wantRemain: "",
+			wantCalls:  1,
+		},
+		{
+			name:       "failure => cached",
+			input:      "x",
+			wantRemain: "x",
+			wantErr:    errors.ErrNotMatched,
+			wantCalls:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number := &countingParser[[]byte]{parser: ascii.Digit1()}
+			rule := modifier.Memo[parser.Reader, []byte](number)
+			p := branch.Alt(
+				sequence.Preceded(rule, bytes.Byte('a')),
+				sequence.Preceded(rule, bytes.Byte('b')),
+			)
+
+			input := strings.NewReader(tt.input)
+			s, err := p.Parse(input)
+
+			assert.Equal(t, tt.wantMatch, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantCalls, number.calls)
+
+			remain, err := io.ReadAll(input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRemain, string(remain))
+
+			number.calls = 0
+			s, out, err := p.ParseBytes([]byte(tt.input))
+
+			assert.Equal(t, tt.wantMatch, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantCalls, number.calls)
+			assert.Equal(t, tt.wantRemain, string(out))
+		})
+	}
+}
+
+func TestMemo_separateParses(t *testing.T) {
+	number := &countingParser[[]byte]{parser: ascii.Digit1()}
+	p := sequence.Terminated(modifier.Memo[parser.Reader, []byte](number), bytes.Byte(';'))
+
+	input := strings.NewReader("12;")
+	s, err := p.Parse(input)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("12"), s)
+
+	input.Reset("34;")
+	s, err = p.Parse(input)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("34"), s)
+
+	buf := []byte("56;")
+	s, _, err = p.ParseBytes(buf)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("56"), s)
+
+	copy(buf, "78;")
+	s, _, err = p.ParseBytes(buf)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("78"), s)
+
+	assert.Equal(t, 4, number.calls)
+}
+
+func TestMemo_concurrentSubSlices(t *testing.T) {
+	p := sequence.Terminated(modifier.Memo[parser.Reader, []byte](ascii.Digit1()), bytes.Byte(';'))
+	buf := []byte("1;22;333;")
+	inputs := [][]byte{buf[:2], buf[2:5], buf[5:]}
+
+	var wg sync.WaitGroup
+	results := make([][][]byte, len(inputs))
+	for i, in := range inputs {
+		wg.Add(1)
+		go func(i int, in []byte) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				s, _, err := p.ParseBytes(in)
+				if err != nil {
+					return
+				}
+				results[i] = append(results[i], s)
+			}
+		}(i, in)
+	}
+	wg.Wait()
+
+	for i, in := range inputs {
+		require.Len(t, results[i], 1000)
+		for _, s := range results[i] {
+			assert.Equal(t, in[:len(in)-1], s)
+		}
+	}
+}
+
+func TestMemo_bits(t *testing.T) {
+	p := multi.Many0(bits.Bits(modifier.Memo(bits.Take[uint8](8))))
+
+	s, out, err := p.ParseBytes([]byte{1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, []uint8{1, 2, 3}, s)
+	assert.Empty(t, out)
+
+	s, err = p.Parse(strings.NewReader("\x01\x02\x03"))
+	require.NoError(t, err)
+	assert.Equal(t, []uint8{1, 2, 3}, s)
+}
@@ -8,29 +8,41 @@ import (
 
 type (
 	notParser[R parser.Reader, T any] struct {
-		parser parser.Parser[R, T]
+		parser parser.Child[R, T]
 	}
 )
 
 func (o *notParser[R, T]) Parse(in R) (parser.Empty, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *notParser[R, T]) ParseBytes(in []byte) (parser.Empty, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *notParser[R, T]) ParseSession(s *parser.Session, in R) (parser.Empty, error) {
 	currentOffset, _ := in.Seek(0, io.SeekCurrent)
-	_, err := o.parser.Parse(in)
+	_, err := o.parser.ParseIn(s, in)
 	_, _ = in.Seek(currentOffset, io.SeekStart)
 	if err != nil {
 		return nil, nil
 	}
-	return nil, errors.ErrNotMatched
+	return nil, parser.NewError(in, errors.ErrNotMatched)
 }
 
-func (o *notParser[R, T]) ParseBytes(in []byte) (parser.Empty, []byte, error) {
-	_, _, err := o.parser.ParseBytes(in)
+func (o *notParser[R, T]) ParseBytesSession(s *parser.Session, in []byte) (parser.Empty, []byte, error) {
+	_, _, err := o.parser.ParseBytesIn(s, in)
 	if err != nil {
 		return nil, in, nil
 	}
-	return nil, in, errors.ErrNotMatched
+	return nil, in, parser.NewBytesError(in, errors.ErrNotMatched)
+}
+
+func (o *notParser[R, T]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarNot, o.parser)
 }
 
 // Not returns a result only if the parser returns an error. It doesn't consume any input
 func Not[R parser.Reader, T any](p parser.Parser[R, T]) parser.Parser[R, parser.Empty] {
-	return &notParser[R, T]{parser: p}
+	return &notParser[R, T]{parser: parser.NewChild(p)}
 }
@@ -6,21 +6,33 @@ import (
 
 type (
 	optionalParser[R parser.Reader, T any] struct {
-		parser parser.Parser[R, T]
+		parser parser.Child[R, T]
 	}
 )
 
 func (o *optionalParser[R, T]) Parse(in R) (T, error) {
-	v, _ := o.parser.Parse(in)
-	return v, nil
+	return o.ParseSession(parser.NewSession(), in)
 }
 
 func (o *optionalParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
-	v, out, _ := o.parser.ParseBytes(in)
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *optionalParser[R, T]) ParseSession(s *parser.Session, in R) (T, error) {
+	v, _ := o.parser.ParseIn(s, in)
+	return v, nil
+}
+
+func (o *optionalParser[R, T]) ParseBytesSession(s *parser.Session, in []byte) (T, []byte, error) {
+	v, out, _ := o.parser.ParseBytesIn(s, in)
 	return v, out, nil
 }
 
+func (o *optionalParser[R, T]) Describe() parser.Grammar {
+	return parser.NewRepeatGrammar(o.parser, 0, 1)
+}
+
 // Optional will call the parser and suppress any error returned
 func Optional[R parser.Reader, T any](p parser.Parser[R, T]) parser.Parser[R, T] {
-	return &optionalParser[R, T]{parser: p}
+	return &optionalParser[R, T]{parser: parser.NewChild(p)}
 }
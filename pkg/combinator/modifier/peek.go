@@ -7,23 +7,35 @@ import (
 
 type (
 	peekParser[R parser.Reader, T any] struct {
-		parser parser.Parser[R, T]
+		parser parser.Child[R, T]
 	}
 )
 
 func (o *peekParser[R, T]) Parse(in R) (T, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *peekParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *peekParser[R, T]) ParseSession(s *parser.Session, in R) (T, error) {
 	currentOffset, _ := in.Seek(0, io.SeekCurrent)
-	t, err := o.parser.Parse(in)
+	t, err := o.parser.ParseIn(s, in)
 	_, _ = in.Seek(currentOffset, io.SeekStart)
 	return t, err
 }
 
-func (o *peekParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
-	t, _, err := o.parser.ParseBytes(in)
+func (o *peekParser[R, T]) ParseBytesSession(s *parser.Session, in []byte) (T, []byte, error) {
+	t, _, err := o.parser.ParseBytesIn(s, in)
 	return t, in, err
 }
 
+func (o *peekParser[R, T]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarPeek, o.parser)
+}
+
 // Peek returns the result of the parser without consuming the input.
 func Peek[R parser.Reader, T any](p parser.Parser[R, T]) parser.Parser[R, T] {
-	return &peekParser[R, T]{parser: p}
+	return &peekParser[R, T]{parser: parser.NewChild(p)}
 }
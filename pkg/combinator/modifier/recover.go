@@ -0,0 +1,193 @@
+package modifier
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"io"
+)
+
+type (
+	recoverParser[R parser.Reader, T, S any] struct {
+		parser   parser.Child[R, T]
+		sync     parser.Child[R, S]
+		fallback T
+	}
+
+	diagnosticsParser[R parser.Reader, T any] struct {
+		parser parser.Child[R, T]
+	}
+
+	// diagnosticsKey is the session key of the errors recovered from during a parse.
+	diagnosticsKey struct{}
+
+	diagnostics struct {
+		errs []error
+	}
+)
+
+func (o *recoverParser[R, T, S]) Parse(in R) (T, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *recoverParser[R, T, S]) ParseBytes(in []byte) (T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *recoverParser[R, T, S]) ParseSession(s *parser.Session, in R) (T, error) {
+	startOffset, _ := in.Seek(0, io.SeekCurrent)
+	r, err := o.parser.ParseIn(s, in)
+	if err == nil || errors.IsFatal(err) {
+		return r, err
+	}
+
+	_, _ = in.Seek(startOffset, io.SeekStart)
+	for {
+		_, syncErr := o.sync.ParseIn(s, in)
+		if syncErr == nil {
+			break
+		}
+		if errors.IsFatal(syncErr) {
+			_, _ = in.Seek(startOffset, io.SeekStart)
+			return r, syncErr
+		}
+		if errors.Is(syncErr, io.EOF) || !skip(in) {
+			break
+		}
+	}
+
+	if offset, _ := in.Seek(0, io.SeekCurrent); offset == startOffset {
+		return r, err
+	}
+	addDiagnostic(s, err)
+	return o.fallback, nil
+}
+
+func (o *recoverParser[R, T, S]) ParseBytesSession(s *parser.Session, in []byte) (T, []byte, error) {
+	r, out, err := o.parser.ParseBytesIn(s, in)
+	if err == nil || errors.IsFatal(err) {
+		return r, out, err
+	}
+
+	out = in
+	for len(out) > 0 {
+		_, next, syncErr := o.sync.ParseBytesIn(s, out)
+		if syncErr == nil {
+			out = next
+			break
+		}
+		if errors.IsFatal(syncErr) {
+			return r, in, syncErr
+		}
+		if errors.Is(syncErr, io.EOF) {
+			break
+		}
+		out = out[1:]
+	}
+
+	if len(out) == len(in) {
+		return r, in, err
+	}
+	addDiagnostic(s, err)
+	return o.fallback, out, nil
+}
+
+func (o *recoverParser[R, T, S]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.parser)
+}
+
+func (o *recoverParser[R, T, S]) Expected() []string {
+	return parser.ExpectedOf(o.parser)
+}
+
+func (o *diagnosticsParser[R, T]) Parse(in R) (parser.Diagnosed[T], error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *diagnosticsParser[R, T]) ParseBytes(in []byte) (parser.Diagnosed[T], []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *diagnosticsParser[R, T]) ParseSession(s *parser.Session, in R) (parser.Diagnosed[T], error) {
+	d := enterDiagnostics(s)
+	start := len(d.errs)
+
+	r, err := o.parser.ParseIn(s, in)
+	return parser.Diagnosed[T]{Value: r, Errors: d.since(start)}, err
+}
+
+func (o *diagnosticsParser[R, T]) ParseBytesSession(s *parser.Session, in []byte) (parser.Diagnosed[T], []byte, error) {
+	d := enterDiagnostics(s)
+	start := len(d.errs)
+
+	r, out, err := o.parser.ParseBytesIn(s, in)
+	return parser.Diagnosed[T]{Value: r, Errors: d.since(start)}, out, err
+}
+
+func (o *diagnosticsParser[R, T]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.parser)
+}
+
+func (o *diagnosticsParser[R, T]) Expected() []string {
+	return parser.ExpectedOf(o.parser)
+}
+
+// enterDiagnostics returns the error sink of the parse, creating it if this is the outermost Diagnostics call.
+func enterDiagnostics(s *parser.Session) *diagnostics {
+	d, _ := s.LoadOrStore(diagnosticsKey{}, &diagnostics{})
+	return d.(*diagnostics)
+}
+
+// since returns a copy of the errors recorded after the first n, so an enclosing Diagnostics parser can't change them.
+func (d *diagnostics) since(n int) []error {
+	if len(d.errs) == n {
+		return nil
+	}
+	return append([]error(nil), d.errs[n:]...)
+}
+
+// skip skips a byte of the input, or a token of a token stream, returning false at the end of the input.
+func skip[R parser.Reader](in R) bool {
+	if _, err := in.ReadByte(); !errors.Is(err, errors.ErrNotSupported) {
+		return err == nil
+	}
+
+	// a token stream can seek past its end, so compare with the end instead
+	offset, _ := in.Seek(0, io.SeekCurrent)
+	end, err := in.Seek(0, io.SeekEnd)
+	if err != nil || offset >= end {
+		_, _ = in.Seek(offset, io.SeekStart)
+		return false
+	}
+	_, _ = in.Seek(offset+1, io.SeekStart)
+	return true
+}
+
+// addDiagnostic records err in the error sink of the parse, if there is one.
+func addDiagnostic(s *parser.Session, err error) {
+	if d, ok := s.Load(diagnosticsKey{}); ok {
+		d := d.(*diagnostics)
+		d.errs = append(d.errs, err)
+	}
+}
+
+// Recover calls the parser, and if it fails, skips the input until the sync parser matches and returns the fallback
+// value. The sync match is consumed, so the sync parser can be wrapped with Peek to stop in front of it, e.g. at the
+// separator of multi.Separated0. This lets repetitions such as multi.Many0 continue after a syntax error, producing a
+// partial result. Input is skipped one byte at a time, or one token for a token stream, until the sync parser matches
+// or the input ends.
+//
+// The error is recorded in the diagnostics of the parse, which are returned by the enclosing Diagnostics parser.
+// Recorded errors aren't removed if an enclosing alternative later backtracks.
+//   - If the parser succeeds, it will return its result.
+//   - If the parser or the sync parser returns a fatal error, it will return the error.
+//   - If no input was skipped, it will return the error from the parser, so repetitions end instead of looping.
+func Recover[R parser.Reader, T, S any](p parser.Parser[R, T], sync parser.Parser[R, S], fallback T) parser.Parser[R, T] {
+	return &recoverParser[R, T, S]{parser: parser.NewChild(p), sync: parser.NewChild(sync), fallback: fallback}
+}
+
+// Diagnostics collects the errors recovered from by every Recover parser called by the parser. It is normally used to
+// wrap the top level parser of a grammar. The errors are returned with the result even if the parser fails. Within an
+// enclosing Diagnostics parser, the errors are also collected by the enclosing parser.
+func Diagnostics[R parser.Reader, T any](p parser.Parser[R, T]) parser.Parser[R, parser.Diagnosed[T]] {
+	return &diagnosticsParser[R, T]{parser: parser.NewChild(p)}
+}
@@ -0,0 +1,189 @@
+package modifier_test
+
+import (
+	"github.com/roblovelock/gobble/pkg/combinator"
+	"github.com/roblovelock/gobble/pkg/combinator/branch"
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/combinator/multi"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/ascii"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/roblovelock/gobble/pkg/parser/stream"
+	"github.com/roblovelock/gobble/pkg/parser/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRecover(t *testing.T) {
+	statement := sequence.Terminated(sequence.Preceded(bytes.Byte('a'), ascii.Digit1()), bytes.Byte(';'))
+
+	tests := []struct {
+		name       string
+		parser     parser.Parser[parser.Reader, []byte]
+		input      string
+		wantMatch  []byte
+		wantRemain string
+		wantErr    error
+		wantFatal  bool
+		wantErrs   []int64
+	}{
+		{
+			name:      "match => result",
+			parser:    modifier.Recover(statement, bytes.Byte(';'), []byte("?")),
+			input:     "a1;",
+			wantMatch: []byte("1"),
+		},
+		{
+			name:       "no match => skip past sync",
+			parser:     modifier.Recover(statement, bytes.Byte(';'), []byte("?")),
+			input:      "ax;a2;",
+			wantMatch:  []byte("?"),
+			wantRemain: "a2;",
+			wantErrs:   []int64{1},
+		},
+		{
+			name:       "peek sync => skip to sync",
+			parser:     modifier.Recover(statement, modifier.Peek(bytes.Byte(';')), []byte("?")),
+			input:      "ax;a2;",
+			wantMatch:  []byte("?"),
+			wantRemain: ";a2;",
+			wantErrs:   []int64{1},
+		},
+		{
+			name:      "no sync => skip to end",
+			parser:    modifier.Recover(statement, bytes.Byte(';'), []byte("?")),
+			input:     "ax",
+			wantMatch: []byte("?"),
+			wantErrs:  []int64{1},
+		},
+		{
+			name:      "sync fails without EOF => skip to end",
+			parser:    modifier.Recover(statement, combinator.Fail[parser.Reader, byte](errors.ErrNotMatched), []byte("?")),
+			input:     "ax",
+			wantMatch: []byte("?"),
+			wantErrs:  []int64{1},
+		},
+		{
+			name:       "nothing skipped => error",
+			parser:     modifier.Recover(statement, modifier.Peek(bytes.Byte('x')), []byte("?")),
+			input:      "x",
+			wantRemain: "x",
+			wantErr:    errors.ErrNotMatched,
+		},
+		{
+			name:       "empty input => EOF",
+			parser:     modifier.Recover(statement, bytes.Byte(';'), []byte("?")),
+			input:      "",
+			wantRemain: "",
+			wantErr:    io.EOF,
+		},
+		{
+			name:       "fatal error => error",
+			parser:     modifier.Recover(modifier.Cut(statement), bytes.Byte(';'), []byte("?")),
+			input:      "ax;",
+			wantRemain: "ax;",
+			wantErr:    errors.ErrNotMatched,
+			wantFatal:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := modifier.Diagnostics(tt.parser)
+
+			input := strings.NewReader(tt.input)
+			s, err := p.Parse(input)
+
+			assertDiagnosed(t, tt.input, tt.wantMatch, tt.wantErrs, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantFatal, errors.IsFatal(err))
+
+			remain, err := io.ReadAll(input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRemain, string(remain))
+
+			s, out, err := p.ParseBytes([]byte(tt.input))
+
+			assertDiagnosed(t, tt.input, tt.wantMatch, tt.wantErrs, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantFatal, errors.IsFatal(err))
+			assert.Equal(t, tt.wantRemain, string(out))
+		})
+	}
+}
+
+func assertDiagnosed(
+	t *testing.T, input string, wantMatch []byte, wantErrs []int64, got parser.Diagnosed[[]byte],
+) {
+	t.Helper()
+	assert.Equal(t, wantMatch, got.Value)
+	require.Len(t, got.Errors, len(wantErrs))
+	for i, err := range got.Errors {
+		parseErr, ok := errors.AsParseError(err)
+		require.True(t, ok)
+		assert.Equal(t, wantErrs[i], parseErr.StartOffset(int64(len(input))))
+	}
+}
+
+func TestRecover_many(t *testing.T) {
+	statement := sequence.Terminated(sequence.Preceded(bytes.Byte('a'), ascii.Digit1()), bytes.Byte(';'))
+	p := modifier.Diagnostics(multi.Many0(modifier.Recover(statement, bytes.Byte(';'), []byte("?"))))
+
+	s, err := p.Parse(strings.NewReader("a1;ax;a3;b;"))
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("1"), []byte("?"), []byte("3"), []byte("?")}, s.Value)
+	require.Len(t, s.Errors, 2)
+	assert.EqualError(t, s.Errors[0], "expected digit at offset 4")
+	assert.EqualError(t, s.Errors[1], "expected 'a' at offset 9")
+}
+
+func TestRecover_separated(t *testing.T) {
+	item := modifier.Recover(
+		ascii.Digit1(),
+		modifier.Peek(branch.Alt(bytes.Byte(','), modifier.Value(stream.EOF(), byte(0)))),
+		[]byte("?"),
+	)
+	p := modifier.Diagnostics(multi.Separated0(item, bytes.Byte(',')))
+
+	s, out, err := p.ParseBytes([]byte("1,x,3,yy"))
+	require.NoError(t, err)
+	assert.Empty(t, out)
+	assert.Equal(t, [][]byte{[]byte("1"), []byte("?"), []byte("3"), []byte("?")}, s.Value)
+	require.Len(t, s.Errors, 2)
+	input := []byte("1,x,3,yy")
+	assert.Equal(t, "error: expected digit\n --> 1:3\n  |\n1 | 1,x,3,yy\n  |   ^\n", errors.Render(input, s.Errors[0]))
+	assert.Equal(t, "error: expected digit\n --> 1:7\n  |\n1 | 1,x,3,yy\n  |       ^\n", errors.Render(input, s.Errors[1]))
+}
+
+func TestRecover_tokens(t *testing.T) {
+	item := modifier.Recover(token.Satisfy(func(r rune) bool { return r != '?' }), token.Satisfy(func(r rune) bool {
+		return r == ';'
+	}), '!')
+	in := token.NewSliceReader([]rune("?x"))
+
+	r, err := item.Parse(in)
+	require.NoError(t, err)
+	assert.Equal(t, '!', r)
+	offset, _ := in.Seek(0, io.SeekCurrent)
+	assert.Equal(t, int64(2), offset)
+}
+
+func TestDiagnostics_nested(t *testing.T) {
+	statement := sequence.Terminated(sequence.Preceded(bytes.Byte('a'), ascii.Digit1()), bytes.Byte(';'))
+	inner := modifier.Diagnostics(multi.Many0(modifier.Recover(statement, bytes.Byte(';'), []byte("?"))))
+	p := modifier.Diagnostics(sequence.Pair(modifier.Recover(statement, bytes.Byte(';'), []byte("?")), inner))
+
+	s, err := p.Parse(strings.NewReader("ax;a2;b;"))
+	require.NoError(t, err)
+	assert.Len(t, s.Errors, 2)
+	require.Len(t, s.Value.Second.Errors, 1)
+	assert.EqualError(t, s.Value.Second.Errors[0], "expected 'a' at offset 6")
+
+	// the errors of the nested parser are a copy, so changing them doesn't change those of the enclosing parser
+	s.Value.Second.Errors[0] = nil
+	assert.Error(t, s.Errors[1])
+}
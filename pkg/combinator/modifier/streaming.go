@@ -0,0 +1,54 @@
+package modifier
+
+import (
+	"github.com/roblovelock/gobble/pkg/parser"
+)
+
+type (
+	streamingParser[R parser.Reader, T any] struct {
+		parser parser.Child[R, T]
+	}
+)
+
+func (o *streamingParser[R, T]) Parse(in R) (T, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *streamingParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *streamingParser[R, T]) ParseSession(s *parser.Session, in R) (T, error) {
+	defer s.SetStreaming(s.SetStreaming(true))
+	return o.parser.ParseIn(s, in)
+}
+
+func (o *streamingParser[R, T]) ParseBytesSession(s *parser.Session, in []byte) (T, []byte, error) {
+	defer s.SetStreaming(s.SetStreaming(true))
+	r, out, err := o.parser.ParseBytesIn(s, in)
+	if err != nil {
+		return r, in, err
+	}
+	return r, out, nil
+}
+
+func (o *streamingParser[R, T]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.parser)
+}
+
+func (o *streamingParser[R, T]) Expected() []string {
+	return parser.ExpectedOf(o.parser)
+}
+
+// Streaming switches the parser into streaming mode, where the input may be a partial buffer. If the parser runs out of
+// input it returns an errors.IncompleteError, reporting how many more bytes are needed when it is known, instead of
+// io.EOF. The caller can retry once more data has arrived.
+//
+// Parsers are in complete mode by default, where running out of input is a failure like any other. Streaming mode
+// applies to every parser called within p, and an IncompleteError is fatal, so an alternative, optional or repeated
+// parser which runs out of input stops the parse instead of trying the next alternative on a partial buffer. Parsers
+// which stop at the end of the input without failing, such as TakeWhile, succeed on a partial buffer, so a streaming
+// grammar should end them with a delimiter.
+func Streaming[R parser.Reader, T any](p parser.Parser[R, T]) parser.Parser[R, T] {
+	return &streamingParser[R, T]{parser: parser.NewChild(p)}
+}
@@ -0,0 +1,170 @@
+package modifier_test
+
+import (
+	stdbytes "bytes"
+	"github.com/roblovelock/gobble/pkg/combinator/branch"
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/roblovelock/gobble/pkg/parser/numeric"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestStreaming(t *testing.T) {
+	type args struct {
+		parser parser.Parser[parser.Reader, interface{}]
+		input  []byte
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantMatch  interface{}
+		wantRemain []byte
+		wantErr    error
+		wantNeeded int
+	}{
+		{
+			name: "partial tag => incomplete",
+			args: args{
+				parser: modifier.Streaming(parser.Untyped(bytes.Tag([]byte("abc")))),
+				input:  []byte("ab"),
+			},
+			wantRemain: []byte("ab"),
+			wantErr:    parser.ErrIncomplete,
+			wantNeeded: 1,
+		},
+		{
+			name: "partial number => incomplete",
+			args: args{
+				parser: modifier.Streaming(parser.Untyped(numeric.Uint32BE())),
+				input:  []byte{0, 1},
+			},
+			wantRemain: []byte{0, 1},
+			wantErr:    parser.ErrIncomplete,
+			wantNeeded: 2,
+		},
+		{
+			name: "mismatch => no match",
+			args: args{
+				parser: modifier.Streaming(parser.Untyped(bytes.Tag([]byte("abc")))),
+				input:  []byte("abd"),
+			},
+			wantRemain: []byte("abd"),
+			wantErr:    errors.ErrNotMatched,
+		},
+		{
+			name: "streaming alternative => incomplete before next alternative",
+			args: args{
+				parser: branch.Alt(
+					modifier.Streaming(parser.Untyped(bytes.Tag([]byte("abc")))),
+					parser.Untyped(bytes.Tag([]byte("ab"))),
+				),
+				input: []byte("ab"),
+			},
+			wantRemain: []byte("ab"),
+			wantErr:    parser.ErrIncomplete,
+			wantNeeded: 1,
+		},
+		{
+			name: "complete alternative => next alternative",
+			args: args{
+				parser: branch.Alt(
+					parser.Untyped(bytes.Tag([]byte("abc"))),
+					parser.Untyped(bytes.Tag([]byte("ab"))),
+				),
+				input: []byte("ab"),
+			},
+			wantMatch:  []byte("ab"),
+			wantRemain: []byte{},
+		},
+		{
+			name: "alternatives ran out of input => incomplete",
+			args: args{
+				parser: modifier.Streaming(branch.Alt(
+					parser.Untyped(bytes.Tag([]byte("x"))),
+					parser.Untyped(bytes.Tag([]byte("abc"))),
+				)),
+				input: []byte("ab"),
+			},
+			wantRemain: []byte("ab"),
+			wantErr:    parser.ErrIncomplete,
+			wantNeeded: 1,
+		},
+		{
+			name: "alternative ran out of input before shorter match => incomplete",
+			args: args{
+				parser: modifier.Streaming(branch.Alt(
+					parser.Untyped(bytes.Tag([]byte("abcd"))),
+					parser.Untyped(bytes.Tag([]byte("ab"))),
+				)),
+				input: []byte("abc"),
+			},
+			wantRemain: []byte("abc"),
+			wantErr:    parser.ErrIncomplete,
+			wantNeeded: 1,
+		},
+		{
+			name: "complete input => match",
+			args: args{
+				parser: modifier.Streaming(parser.Untyped(bytes.Tag([]byte("abc")))),
+				input:  []byte("abcd"),
+			},
+			wantMatch:  []byte("abc"),
+			wantRemain: []byte("d"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, remain, err := tt.args.parser.ParseBytes(tt.args.input)
+
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.wantMatch, s)
+			}
+			assert.Equal(t, tt.wantRemain, remain)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == parser.ErrIncomplete {
+				assert.ErrorIs(t, err, io.EOF)
+				assert.True(t, errors.IsFatal(err))
+
+				needed, ok := errors.Needed(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantNeeded, needed)
+			}
+
+			in := stdbytes.NewReader(tt.args.input)
+			_, err = tt.args.parser.Parse(in)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == parser.ErrIncomplete {
+				needed, ok := errors.Needed(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantNeeded, needed)
+				assert.Equal(t, len(tt.args.input), in.Len())
+			}
+		})
+	}
+}
+
+func TestStreaming_parse(t *testing.T) {
+	p := modifier.Streaming(bytes.Tag([]byte("abc")))
+
+	_, err := p.Parse(strings.NewReader("ab"))
+
+	assert.ErrorIs(t, err, parser.ErrIncomplete)
+	assert.EqualError(t, err, `incomplete input, 1 more bytes needed: unexpected end of input, expected "abc" at offset 0`)
+}
+
+func TestStreaming_parseAlt(t *testing.T) {
+	p := modifier.Streaming(branch.Alt(bytes.Tag([]byte("abcd")), bytes.Tag([]byte("ab"))))
+
+	in := strings.NewReader("abc")
+	_, err := p.Parse(in)
+
+	assert.ErrorIs(t, err, parser.ErrIncomplete)
+	assert.True(t, errors.IsFatal(err))
+	assert.Equal(t, 3, in.Len())
+}
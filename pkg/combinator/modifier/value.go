@@ -1,26 +1,37 @@
 package modifier
 
 import (
+	"github.com/roblovelock/gobble/pkg/errors"
 	"github.com/roblovelock/gobble/pkg/parser"
+	"io"
+	"reflect"
 )
 
 type (
 	valueParser[R parser.Reader, T, V any] struct {
-		parser parser.Parser[R, T]
+		parser parser.Child[R, T]
 		value  V
 	}
 )
 
 func (o *valueParser[R, T, V]) Parse(in R) (V, error) {
-	if _, err := o.parser.Parse(in); err != nil {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *valueParser[R, T, V]) ParseBytes(in []byte) (V, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *valueParser[R, T, V]) ParseSession(s *parser.Session, in R) (V, error) {
+	if _, err := o.parser.ParseIn(s, in); err != nil {
 		var v V
 		return v, err
 	}
 	return o.value, nil
 }
 
-func (o *valueParser[R, T, V]) ParseBytes(in []byte) (V, []byte, error) {
-	_, out, err := o.parser.ParseBytes(in)
+func (o *valueParser[R, T, V]) ParseBytesSession(s *parser.Session, in []byte) (V, []byte, error) {
+	_, out, err := o.parser.ParseBytesIn(s, in)
 	if err != nil {
 		var v V
 		return v, nil, err
@@ -28,7 +39,25 @@ func (o *valueParser[R, T, V]) ParseBytes(in []byte) (V, []byte, error) {
 	return o.value, out, nil
 }
 
+func (o *valueParser[R, T, V]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.parser)
+}
+
+func (o *valueParser[R, T, V]) Expected() []string {
+	return parser.ExpectedOf(o.parser)
+}
+
+// Print prints the parser with its zero value, so it should be a parser of a constant, such as a tag. The value must
+// be equal to the value returned by the parser.
+func (o *valueParser[R, T, V]) Print(w io.Writer, v V) error {
+	if !reflect.DeepEqual(v, o.value) {
+		return errors.ErrNotMatched
+	}
+	var t T
+	return o.parser.Print(w, t)
+}
+
 // Value returns the provided value if the parser succeeds.
 func Value[R parser.Reader, T, V any](p parser.Parser[R, T], value V) parser.Parser[R, V] {
-	return &valueParser[R, T, V]{parser: p, value: value}
+	return &valueParser[R, T, V]{parser: parser.NewChild(p), value: value}
 }
@@ -8,14 +8,22 @@ import (
 
 type (
 	verifyParser[R parser.Reader, T any] struct {
-		parser    parser.Parser[R, T]
+		parser    parser.Child[R, T]
 		predicate parser.Predicate[T]
 	}
 )
 
 func (o *verifyParser[R, T]) Parse(in R) (T, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *verifyParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *verifyParser[R, T]) ParseSession(s *parser.Session, in R) (T, error) {
 	currentOffset, _ := in.Seek(0, io.SeekCurrent)
-	r, err := o.parser.Parse(in)
+	r, err := o.parser.ParseIn(s, in)
 	if err != nil {
 		return r, err
 	}
@@ -23,26 +31,41 @@ func (o *verifyParser[R, T]) Parse(in R) (T, error) {
 	if !o.predicate(r) {
 		var r T
 		_, _ = in.Seek(currentOffset, io.SeekStart)
-		return r, errors.ErrNotMatched
+		return r, parser.NewError(in, errors.ErrNotMatched)
 	}
 
 	return r, nil
 }
 
-func (o *verifyParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
-	r, out, err := o.parser.ParseBytes(in)
+func (o *verifyParser[R, T]) ParseBytesSession(s *parser.Session, in []byte) (T, []byte, error) {
+	r, out, err := o.parser.ParseBytesIn(s, in)
 	if err != nil {
 		return r, in, err
 	}
 
 	if !o.predicate(r) {
 		var r T
-		return r, in, errors.ErrNotMatched
+		return r, in, parser.NewBytesError(in, errors.ErrNotMatched)
 	}
 
 	return r, out, nil
 }
 
+func (o *verifyParser[R, T]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.parser)
+}
+
+func (o *verifyParser[R, T]) Expected() []string {
+	return parser.ExpectedOf(o.parser)
+}
+
+func (o *verifyParser[R, T]) Print(w io.Writer, v T) error {
+	if !o.predicate(v) {
+		return errors.ErrNotMatched
+	}
+	return o.parser.Print(w, v)
+}
+
 func Verify[R parser.Reader, T any](p parser.Parser[R, T], predicate parser.Predicate[T]) parser.Parser[R, T] {
-	return &verifyParser[R, T]{parser: p, predicate: predicate}
+	return &verifyParser[R, T]{parser: parser.NewChild(p), predicate: predicate}
 }
@@ -6,33 +6,42 @@ import (
 
 type (
 	foldMany0Parser[R parser.Reader, T, A any] struct {
-		parser      parser.Parser[R, T]
+		parser      parser.Child[R, T]
 		accumulator A
 		fn          parser.Accumulator[T, A]
 	}
 )
 
 func (o *foldMany0Parser[R, T, A]) Parse(in R) (A, error) {
-	for r, err := o.parser.Parse(in); err == nil; r, err = o.parser.Parse(in) {
-		o.accumulator = o.fn(o.accumulator, r)
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *foldMany0Parser[R, T, A]) ParseBytes(in []byte) (A, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *foldMany0Parser[R, T, A]) ParseSession(s *parser.Session, in R) (A, error) {
+	err := repeat(s, in, o.parser, 0, func(r T) { o.accumulator = o.fn(o.accumulator, r) })
+	if err != nil {
+		var a A
+		return a, err
 	}
 	return o.accumulator, nil
 }
 
-func (o *foldMany0Parser[R, T, A]) ParseBytes(in []byte) (A, []byte, error) {
-	var r T
-	var err error
-	out := in
-	for {
-		r, out, err = o.parser.ParseBytes(out)
-		if err != nil {
-			break
-		}
-		o.accumulator = o.fn(o.accumulator, r)
+func (o *foldMany0Parser[R, T, A]) ParseBytesSession(s *parser.Session, in []byte) (A, []byte, error) {
+	out, err := repeatBytes(s, in, o.parser, 0, func(r T) { o.accumulator = o.fn(o.accumulator, r) })
+	if err != nil {
+		var a A
+		return a, in, err
 	}
 	return o.accumulator, out, nil
 }
 
+func (o *foldMany0Parser[R, T, A]) Describe() parser.Grammar {
+	return parser.NewRepeatGrammar(o.parser, 0, -1)
+}
+
 func FoldMany0[R parser.Reader, T, A any](p parser.Parser[R, T], acc A, f parser.Accumulator[T, A]) parser.Parser[R, A] {
-	return &foldMany0Parser[R, T, A]{parser: p, accumulator: acc, fn: f}
+	return &foldMany0Parser[R, T, A]{parser: parser.NewChild(p), accumulator: acc, fn: f}
 }
@@ -2,75 +2,121 @@ package multi
 
 import (
 	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/errors"
 	"github.com/roblovelock/gobble/pkg/parser"
 	"io"
 )
 
 type (
 	keyValueParser[R parser.Reader, F comparable, S1, S2, T any] struct {
-		key   parser.Parser[R, F]
-		s1    parser.Parser[R, S1]
-		value parser.Parser[R, T]
-		s2    parser.Parser[R, S2]
+		key   parser.Child[R, F]
+		s1    parser.Child[R, S1]
+		value parser.Child[R, T]
+		s2    parser.Child[R, S2]
 	}
 )
 
 func (o *keyValueParser[R, F, S1, S2, T]) Parse(in R) (map[F]T, error) {
-	currentOffset, _ := in.Seek(0, io.SeekCurrent)
-	result := make(map[F]T, 7)
-	for {
-		f, err := o.key.Parse(in)
-		if err != nil {
-			_, _ = in.Seek(currentOffset, io.SeekStart)
-			break
-		}
+	return o.ParseSession(parser.NewSession(), in)
+}
 
-		if _, err := o.s1.Parse(in); err != nil {
-			_, _ = in.Seek(currentOffset, io.SeekStart)
-			break
-		}
+func (o *keyValueParser[R, F, S1, S2, T]) ParseBytes(in []byte) (map[F]T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *keyValueParser[R, F, S1, S2, T]) ParseSession(s *parser.Session, in R) (map[F]T, error) {
+	startOffset, _ := in.Seek(0, io.SeekCurrent)
+	currentOffset := startOffset
+	rep := parser.NewRepetition(s, in)
 
-		s, err := o.value.Parse(in)
+	result := make(map[F]T, 7)
+	for {
+		f, v, err := o.parsePair(s, in)
 		if err != nil {
+			if errors.IsFatal(err) {
+				_, _ = in.Seek(startOffset, io.SeekStart)
+				return nil, err
+			}
 			_, _ = in.Seek(currentOffset, io.SeekStart)
 			break
 		}
-		result[f] = s
+		result[f] = v
 
 		currentOffset, _ = in.Seek(0, io.SeekCurrent)
-		if _, err := o.s2.Parse(in); err != nil {
+		if _, err := o.s2.ParseIn(s, in); err != nil {
+			if errors.IsFatal(err) {
+				_, _ = in.Seek(startOffset, io.SeekStart)
+				return nil, err
+			}
 			break
 		}
+		if err := rep.Next(in); err != nil {
+			err = parser.NewError(in, err)
+			_, _ = in.Seek(startOffset, io.SeekStart)
+			return nil, err
+		}
 	}
 
 	return result, nil
 }
 
-func (o *keyValueParser[R, F, S1, S2, T]) ParseBytes(in []byte) (map[F]T, []byte, error) {
+func (o *keyValueParser[R, F, S1, S2, T]) parsePair(s *parser.Session, in R) (f F, t T, err error) {
+	if f, err = o.key.ParseIn(s, in); err != nil {
+		return f, t, err
+	}
+	if _, err = o.s1.ParseIn(s, in); err != nil {
+		return f, t, err
+	}
+	t, err = o.value.ParseIn(s, in)
+	return f, t, err
+}
+
+func (o *keyValueParser[R, F, S1, S2, T]) ParseBytesSession(s *parser.Session, in []byte) (map[F]T, []byte, error) {
+	rep := parser.NewBytesRepetition(s, in)
+
 	result := make(map[F]T, 7)
+	out := in
 	for {
-		f, out, err := o.key.ParseBytes(in)
+		f, v, next, err := o.parsePairBytes(s, out)
 		if err != nil {
-			return result, in, nil
+			if errors.IsFatal(err) {
+				return nil, in, err
+			}
+			return result, out, nil
 		}
+		result[f] = v
 
-		_, out, err = o.s1.ParseBytes(out)
+		_, next, err = o.s2.ParseBytesIn(s, next)
 		if err != nil {
-			return result, in, nil
+			if errors.IsFatal(err) {
+				return nil, in, err
+			}
+			return result, next, nil
 		}
-
-		s, out, err := o.value.ParseBytes(out)
-		if err != nil {
-			return result, in, nil
+		if err := rep.NextBytes(next); err != nil {
+			return nil, in, parser.NewBytesError(next, err)
 		}
-		result[f] = s
+		out = next
+	}
+}
 
-		_, out, err = o.s2.ParseBytes(out)
-		if err != nil {
-			return result, out, nil
-		}
-		in = out
+func (o *keyValueParser[R, F, S1, S2, T]) Describe() parser.Grammar {
+	pair := parser.NewGrammar(parser.GrammarSequence, o.key, o.s1, o.value)
+	rest := parser.NewRepeatGrammar(parser.NewGrammar(parser.GrammarSequence, o.s2, pair), 0, -1)
+	return parser.NewRepeatGrammar(parser.NewGrammar(parser.GrammarSequence, pair, rest), 0, 1)
+}
+
+func (o *keyValueParser[R, F, S1, S2, T]) parsePairBytes(s *parser.Session, in []byte) (f F, t T, out []byte, err error) {
+	if f, out, err = o.key.ParseBytesIn(s, in); err != nil {
+		return f, t, in, err
+	}
+	if _, out, err = o.s1.ParseBytesIn(s, out); err != nil {
+		return f, t, in, err
+	}
+	if t, out, err = o.value.ParseBytesIn(s, out); err != nil {
+		return f, t, in, err
 	}
+	return f, t, out, nil
 }
 
 // KeyValue returns a map of key value pairs.
@@ -86,7 +132,7 @@ func KeyValue[R parser.Reader, F comparable, S1, S2, T any](
 	key parser.Parser[R, F], s1 parser.Parser[R, S1], value parser.Parser[R, T], s2 parser.Parser[R, S2],
 ) parser.Parser[R, map[F]T] {
 	return &keyValueParser[R, F, S1, S2, T]{
-		key: key, s1: s1, value: value, s2: s2,
+		key: parser.NewChild(key), s1: parser.NewChild(s1), value: parser.NewChild(value), s2: parser.NewChild(s2),
 	}
 }
 
@@ -1,143 +1,242 @@
 package multi
 
 import (
+	"github.com/roblovelock/gobble/pkg/errors"
 	"github.com/roblovelock/gobble/pkg/parser"
+	"io"
 )
 
 type (
 	many0Parser[R parser.Reader, T any] struct {
-		parser parser.Parser[R, T]
+		parser parser.Child[R, T]
 	}
 
 	many0CountParser[R parser.Reader, T any] struct {
-		parser parser.Parser[R, T]
+		parser parser.Child[R, T]
 	}
 
 	many1Parser[R parser.Reader, T any] struct {
-		parser parser.Parser[R, T]
+		parser parser.Child[R, T]
 	}
 
 	many1CountParser[R parser.Reader, T any] struct {
-		parser parser.Parser[R, T]
+		parser parser.Child[R, T]
 	}
 )
 
 func (o *many0Parser[R, T]) Parse(in R) ([]T, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *many0Parser[R, T]) ParseBytes(in []byte) ([]T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *many0Parser[R, T]) ParseSession(s *parser.Session, in R) ([]T, error) {
 	result := make([]T, 0)
-	for {
-		r, err := o.parser.Parse(in)
-		if err != nil {
-			break
-		}
-		result = append(result, r)
+	err := repeat(s, in, o.parser, 0, func(r T) { result = append(result, r) })
+	if err != nil {
+		return nil, err
 	}
-
 	return result, nil
 }
 
-func (o *many0Parser[R, T]) ParseBytes(in []byte) (result []T, out []byte, err error) {
-	var r T
-	out = in
-	for {
-		r, out, err = o.parser.ParseBytes(out)
-		if err != nil {
-			return result, out, nil
-		}
-		result = append(result, r)
+func (o *many0Parser[R, T]) ParseBytesSession(s *parser.Session, in []byte) ([]T, []byte, error) {
+	var result []T
+	out, err := repeatBytes(s, in, o.parser, 0, func(r T) { result = append(result, r) })
+	if err != nil {
+		return nil, in, err
 	}
+	return result, out, nil
+}
+
+func (o *many0Parser[R, T]) Describe() parser.Grammar {
+	return parser.NewRepeatGrammar(o.parser, 0, -1)
 }
 
 func (o *many0CountParser[R, T]) Parse(in R) (uint, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *many0CountParser[R, T]) ParseBytes(in []byte) (uint, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *many0CountParser[R, T]) ParseSession(s *parser.Session, in R) (uint, error) {
 	var count uint = 0
-	for {
-		if _, err := o.parser.Parse(in); err != nil {
-			break
-		}
-		count++
+	err := repeat(s, in, o.parser, 0, func(T) { count++ })
+	if err != nil {
+		return 0, err
 	}
-
 	return count, nil
 }
 
-func (o *many0CountParser[R, T]) ParseBytes(in []byte) (count uint, out []byte, err error) {
-	out = in
-	for {
-		_, out, err = o.parser.ParseBytes(out)
-		if err != nil {
-			return count, out, nil
-		}
-		count++
+func (o *many0CountParser[R, T]) ParseBytesSession(s *parser.Session, in []byte) (uint, []byte, error) {
+	var count uint = 0
+	out, err := repeatBytes(s, in, o.parser, 0, func(T) { count++ })
+	if err != nil {
+		return 0, in, err
 	}
+	return count, out, nil
+}
+
+func (o *many0CountParser[R, T]) Describe() parser.Grammar {
+	return parser.NewRepeatGrammar(o.parser, 0, -1)
 }
 
 func (o *many1Parser[R, T]) Parse(in R) ([]T, error) {
-	r, err := o.parser.Parse(in)
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *many1Parser[R, T]) ParseBytes(in []byte) ([]T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *many1Parser[R, T]) ParseSession(s *parser.Session, in R) ([]T, error) {
+	var result []T
+	err := repeat(s, in, o.parser, 1, func(r T) { result = append(result, r) })
 	if err != nil {
 		return nil, err
 	}
-
-	result := []T{r}
-	for r, err := o.parser.Parse(in); err == nil; r, err = o.parser.Parse(in) {
-		result = append(result, r)
-	}
-
 	return result, nil
 }
 
-func (o *many1Parser[R, T]) ParseBytes(in []byte) (result []T, out []byte, err error) {
-	var r T
-	out = in
-	for {
-		r, out, err = o.parser.ParseBytes(out)
-		if err != nil {
-			if len(result) == 0 {
-				return result, in, err
-			}
-			return result, out, nil
-		}
-		result = append(result, r)
+func (o *many1Parser[R, T]) ParseBytesSession(s *parser.Session, in []byte) ([]T, []byte, error) {
+	var result []T
+	out, err := repeatBytes(s, in, o.parser, 1, func(r T) { result = append(result, r) })
+	if err != nil {
+		return nil, in, err
 	}
+	return result, out, nil
+}
+
+func (o *many1Parser[R, T]) Describe() parser.Grammar {
+	return parser.NewRepeatGrammar(o.parser, 1, -1)
 }
 
 func (o *many1CountParser[R, T]) Parse(in R) (uint, error) {
-	if _, err := o.parser.Parse(in); err != nil {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *many1CountParser[R, T]) ParseBytes(in []byte) (uint, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *many1CountParser[R, T]) ParseSession(s *parser.Session, in R) (uint, error) {
+	var count uint = 0
+	err := repeat(s, in, o.parser, 1, func(T) { count++ })
+	if err != nil {
 		return 0, err
 	}
+	return count, nil
+}
 
-	var count uint = 1
-	for _, err := o.parser.Parse(in); err == nil; _, err = o.parser.Parse(in) {
-		count++
+func (o *many1CountParser[R, T]) ParseBytesSession(s *parser.Session, in []byte) (uint, []byte, error) {
+	var count uint = 0
+	out, err := repeatBytes(s, in, o.parser, 1, func(T) { count++ })
+	if err != nil {
+		return 0, in, err
 	}
+	return count, out, nil
+}
 
-	return count, nil
+func (o *many1CountParser[R, T]) Describe() parser.Grammar {
+	return parser.NewRepeatGrammar(o.parser, 1, -1)
 }
 
-func (o *many1CountParser[R, T]) ParseBytes(in []byte) (count uint, out []byte, err error) {
-	out = in
-	for {
-		_, out, err = o.parser.ParseBytes(out)
+// repeat applies the parser until it fails, passing each result to fn. The input is left after the last match. If the
+// parser fails with a fatal error, it fails before matching min times, or a repetition is rejected by
+// parser.Repetition, the input is rewound and the error is returned.
+func repeat[R parser.Reader, T any](s *parser.Session, in R, p parser.Child[R, T], min int, fn func(T)) error {
+	currentOffset, _ := in.Seek(0, io.SeekCurrent)
+	rep := parser.NewRepetition(s, in)
+
+	for n := 0; ; n++ {
+		r, err := p.ParseIn(s, in)
+		if err == nil {
+			err = rep.Next(in)
+			if err != nil {
+				err = parser.NewError(in, err)
+			}
+		} else if n >= min && !errors.IsFatal(err) {
+			return nil
+		}
 		if err != nil {
-			if count == 0 {
-				return count, in, err
+			_, _ = in.Seek(currentOffset, io.SeekStart)
+			return err
+		}
+		fn(r)
+	}
+}
+
+// repeatBytes is repeat for ParseBytes. It returns the remaining input after the last match.
+func repeatBytes[R parser.Reader, T any](
+	s *parser.Session, in []byte, p parser.Child[R, T], min int, fn func(T),
+) ([]byte, error) {
+	rep := parser.NewBytesRepetition(s, in)
+
+	out := in
+	for n := 0; ; n++ {
+		r, next, err := p.ParseBytesIn(s, out)
+		if err == nil {
+			err = rep.NextBytes(next)
+			if err != nil {
+				err = parser.NewBytesError(next, err)
 			}
-			return count, out, nil
+		} else if n >= min && !errors.IsFatal(err) {
+			return out, nil
+		}
+		if err != nil {
+			return in, err
+		}
+		fn(r)
+		out = next
+	}
+}
+
+func (o *many0Parser[R, T]) Print(w io.Writer, v []T) error {
+	return printAll(o.parser, w, v)
+}
+
+func (o *many1Parser[R, T]) Print(w io.Writer, v []T) error {
+	if len(v) == 0 {
+		return errors.ErrNotMatched
+	}
+	return printAll(o.parser, w, v)
+}
+
+func printAll[R parser.Reader, T any](p parser.Child[R, T], w io.Writer, v []T) error {
+	for _, t := range v {
+		if err := p.Print(w, t); err != nil {
+			return err
 		}
-		count++
 	}
+	return nil
 }
 
+// Many0 applies the parser until it fails, and returns the results.
+//   - If the parser fails with a fatal error, such as errors.ErrLimitExceeded, it will return the error
 func Many0[R parser.Reader, T any](p parser.Parser[R, T]) parser.Parser[R, []T] {
-	return &many0Parser[R, T]{parser: p}
+	return &many0Parser[R, T]{parser: parser.NewChild(p)}
 }
 
+// Many0Count applies the parser until it fails, and returns the number of times it matched.
+//   - If the parser fails with a fatal error, such as errors.ErrLimitExceeded, it will return the error
 func Many0Count[R parser.Reader, T any](p parser.Parser[R, T]) parser.Parser[R, uint] {
-	return &many0CountParser[R, T]{parser: p}
+	return &many0CountParser[R, T]{parser: parser.NewChild(p)}
 }
 
+// Many1 applies the parser until it fails, and returns the results. The first match counts towards
+// parser.Limits.MaxRepetitions like the rest.
+//   - If the parser doesn't match at least once, it will return the error of the parser
+//   - If the parser fails with a fatal error, such as errors.ErrLimitExceeded, it will return the error
 func Many1[R parser.Reader, T any](p parser.Parser[R, T]) parser.Parser[R, []T] {
-	return &many1Parser[R, T]{parser: p}
+	return &many1Parser[R, T]{parser: parser.NewChild(p)}
 }
 
+// Many1Count applies the parser until it fails, and returns the number of times it matched.
+//   - If the parser doesn't match at least once, it will return the error of the parser
+//   - If the parser fails with a fatal error, such as errors.ErrLimitExceeded, it will return the error
 func Many1Count[R parser.Reader, T any](p parser.Parser[R, T]) parser.Parser[R, uint] {
-	return &many1CountParser[R, T]{parser: p}
+	return &many1CountParser[R, T]{parser: parser.NewChild(p)}
 }
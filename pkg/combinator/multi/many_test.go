@@ -59,7 +59,7 @@ func TestMany0(t *testing.T) {
 			assert.Equal(t, tt.wantMatch, s)
 			assert.ErrorIs(t, err, tt.wantErr)
 
-			if err != io.EOF {
+			if !errors.Is(err, io.EOF) {
 				remain, err := io.ReadAll(tt.args.input)
 				require.NoError(t, err)
 				assert.Equal(t, tt.wantRemain, string(remain))
@@ -116,7 +116,7 @@ func TestMany1(t *testing.T) {
 			assert.Equal(t, tt.wantMatch, s)
 			assert.ErrorIs(t, err, tt.wantErr)
 
-			if err != io.EOF {
+			if !errors.Is(err, io.EOF) {
 				remain, err := io.ReadAll(tt.args.input)
 				require.NoError(t, err)
 				assert.Equal(t, tt.wantRemain, string(remain))
@@ -173,7 +173,7 @@ func TestMany0Count(t *testing.T) {
 			assert.Equal(t, tt.wantMatch, s)
 			assert.ErrorIs(t, err, tt.wantErr)
 
-			if err != io.EOF {
+			if !errors.Is(err, io.EOF) {
 				remain, err := io.ReadAll(tt.args.input)
 				require.NoError(t, err)
 				assert.Equal(t, tt.wantRemain, string(remain))
@@ -230,7 +230,7 @@ func TestMany1Count(t *testing.T) {
 			assert.Equal(t, tt.wantMatch, s)
 			assert.ErrorIs(t, err, tt.wantErr)
 
-			if err != io.EOF {
+			if !errors.Is(err, io.EOF) {
 				remain, err := io.ReadAll(tt.args.input)
 				require.NoError(t, err)
 				assert.Equal(t, tt.wantRemain, string(remain))
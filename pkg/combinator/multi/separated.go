@@ -2,49 +2,122 @@ package multi
 
 import (
 	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/errors"
 	"github.com/roblovelock/gobble/pkg/parser"
+	"io"
 )
 
 type (
 	separated0Parser[R parser.Reader, T any, S any] struct {
-		parser    parser.Parser[R, T]
-		separator parser.Parser[R, S]
+		parser    parser.Child[R, T]
+		separator parser.Child[R, S]
 	}
 )
 
 func (o *separated0Parser[R, T, S]) Parse(in R) ([]T, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *separated0Parser[R, T, S]) ParseBytes(in []byte) ([]T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *separated0Parser[R, T, S]) ParseSession(s *parser.Session, in R) ([]T, error) {
+	currentOffset, _ := in.Seek(0, io.SeekCurrent)
+	rep := parser.NewRepetition(s, in)
+
 	result := make([]T, 0)
-	for r, err := o.parser.Parse(in); err == nil; r, err = o.parser.Parse(in) {
+	for {
+		r, err := o.parser.ParseIn(s, in)
+		if err != nil {
+			if errors.IsFatal(err) {
+				_, _ = in.Seek(currentOffset, io.SeekStart)
+				return nil, err
+			}
+			break
+		}
+		if err := rep.Count(); err != nil {
+			err = parser.NewError(in, err)
+			_, _ = in.Seek(currentOffset, io.SeekStart)
+			return nil, err
+		}
 		result = append(result, r)
-		if _, err := o.separator.Parse(in); err != nil {
+
+		if _, err := o.separator.ParseIn(s, in); err != nil {
+			if errors.IsFatal(err) {
+				_, _ = in.Seek(currentOffset, io.SeekStart)
+				return nil, err
+			}
 			break
 		}
+		if err := rep.Progress(in); err != nil {
+			err = parser.NewError(in, err)
+			_, _ = in.Seek(currentOffset, io.SeekStart)
+			return nil, err
+		}
 	}
 
 	return result, nil
 }
 
-func (o *separated0Parser[R, T, S]) ParseBytes(in []byte) ([]T, []byte, error) {
+func (o *separated0Parser[R, T, S]) ParseBytesSession(s *parser.Session, in []byte) ([]T, []byte, error) {
+	rep := parser.NewBytesRepetition(s, in)
+
 	result := make([]T, 0)
+	out := in
 	for {
-		r, out, err := o.parser.ParseBytes(in)
+		r, next, err := o.parser.ParseBytesIn(s, out)
 		if err != nil {
-			return result, in, nil
+			if errors.IsFatal(err) {
+				return nil, in, err
+			}
+			return result, out, nil
+		}
+		if err := rep.Count(); err != nil {
+			return nil, in, parser.NewBytesError(next, err)
 		}
 		result = append(result, r)
 
-		_, out, err = o.separator.ParseBytes(out)
+		_, next, err = o.separator.ParseBytesIn(s, next)
 		if err != nil {
-			return result, out, nil
+			if errors.IsFatal(err) {
+				return nil, in, err
+			}
+			return result, next, nil
+		}
+		if err := rep.ProgressBytes(next); err != nil {
+			return nil, in, parser.NewBytesError(next, err)
+		}
+		out = next
+	}
+}
+
+func (o *separated0Parser[R, T, S]) Describe() parser.Grammar {
+	rest := parser.NewRepeatGrammar(parser.NewGrammar(parser.GrammarSequence, o.separator, o.parser), 0, -1)
+	return parser.NewRepeatGrammar(parser.NewGrammar(parser.GrammarSequence, o.parser, rest), 0, 1)
+}
+
+// Print prints the separator between the values with its zero value, so it should be a parser of a constant, such as
+// a tag.
+func (o *separated0Parser[R, T, S]) Print(w io.Writer, v []T) error {
+	var sep S
+	for i, t := range v {
+		if i > 0 {
+			if err := o.separator.Print(w, sep); err != nil {
+				return err
+			}
+		}
+		if err := o.parser.Print(w, t); err != nil {
+			return err
 		}
-		in = out
 	}
+	return nil
 }
 
 func Separated0[R parser.Reader, T any, S any](
 	p parser.Parser[R, T], separator parser.Parser[R, S],
 ) parser.Parser[R, []T] {
-	return &separated0Parser[R, T, S]{parser: p, separator: separator}
+	return &separated0Parser[R, T, S]{parser: parser.NewChild(p), separator: parser.NewChild(separator)}
 }
 
 func Separated0Count[R parser.Reader, T any, S any](
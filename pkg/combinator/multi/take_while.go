@@ -4,11 +4,12 @@ import (
 	"github.com/roblovelock/gobble/pkg/errors"
 	"github.com/roblovelock/gobble/pkg/parser"
 	"io"
+	"math"
 )
 
 type (
 	takeWhileMinMaxParser[R parser.Reader, T any] struct {
-		parser    parser.Parser[R, T]
+		parser    parser.Child[R, T]
 		min       int
 		max       int
 		predicate parser.Predicate[T]
@@ -16,18 +17,31 @@ type (
 )
 
 func (o *takeWhileMinMaxParser[R, T]) Parse(in R) ([]T, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *takeWhileMinMaxParser[R, T]) ParseBytes(in []byte) ([]T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *takeWhileMinMaxParser[R, T]) ParseSession(s *parser.Session, in R) ([]T, error) {
 	currentOffset, _ := in.Seek(0, io.SeekCurrent)
+	canceller := s.Canceller()
 	result := make([]T, 0)
 
 	for i := 0; i < o.max; i++ {
-		r, err := o.parser.Parse(in)
+		if err := canceller.Check(); err != nil {
+			_, _ = in.Seek(currentOffset, io.SeekStart)
+			return nil, parser.NewError(in, err)
+		}
+		r, err := o.parser.ParseIn(s, in)
 		if err == nil && !o.predicate(r) {
 			err = errors.ErrNotMatched
 		}
 		if err != nil {
 			if len(result) < o.min {
 				_, _ = in.Seek(currentOffset, io.SeekStart)
-				return nil, err
+				return nil, parser.NewError(in, err)
 			}
 			break
 		}
@@ -37,23 +51,27 @@ func (o *takeWhileMinMaxParser[R, T]) Parse(in R) ([]T, error) {
 	return result, nil
 }
 
-func (o *takeWhileMinMaxParser[R, T]) ParseBytes(in []byte) ([]T, []byte, error) {
+func (o *takeWhileMinMaxParser[R, T]) ParseBytesSession(s *parser.Session, in []byte) ([]T, []byte, error) {
 	var r T
 	var err error
 	out := in
 	result := make([]T, 0, o.min)
+	canceller := s.Canceller()
 	for i := 0; i < o.max; i++ {
-		r, out, err = o.parser.ParseBytes(out)
+		if err := canceller.Check(); err != nil {
+			return nil, in, parser.NewBytesError(in, err)
+		}
+		r, out, err = o.parser.ParseBytesIn(s, out)
 		if err != nil {
 			if len(result) < o.min {
-				return nil, in, err
+				return nil, in, parser.NewBytesError(in, err)
 			}
 			return result, in, nil
 		}
 
 		if !o.predicate(r) {
 			if len(result) < o.min {
-				return nil, in, errors.ErrNotMatched
+				return nil, in, parser.NewBytesError(in, errors.ErrNotMatched)
 			}
 			return result, in, nil
 		}
@@ -63,6 +81,14 @@ func (o *takeWhileMinMaxParser[R, T]) ParseBytes(in []byte) ([]T, []byte, error)
 	return result, out, nil
 }
 
+func (o *takeWhileMinMaxParser[R, T]) Describe() parser.Grammar {
+	max := o.max
+	if max == math.MaxInt {
+		max = -1
+	}
+	return parser.NewRepeatGrammar(o.parser, o.min, max)
+}
+
 func TakeWhileMinMax[R parser.Reader, T any](p parser.Parser[R, T], min, max int, predicate parser.Predicate[T]) parser.Parser[R, []T] {
-	return &takeWhileMinMaxParser[R, T]{parser: p, min: min, max: max, predicate: predicate}
+	return &takeWhileMinMaxParser[R, T]{parser: parser.NewChild(p), min: min, max: max, predicate: predicate}
 }
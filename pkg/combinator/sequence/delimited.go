@@ -7,52 +7,82 @@ import (
 
 type (
 	delimitedParser[R parser.Reader, F, S, T any] struct {
-		first  parser.Parser[R, F]
-		second parser.Parser[R, S]
-		third  parser.Parser[R, T]
+		first  parser.Child[R, F]
+		second parser.Child[R, S]
+		third  parser.Child[R, T]
 	}
 )
 
 func (o *delimitedParser[R, F, S, T]) Parse(in R) (S, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *delimitedParser[R, F, S, T]) ParseBytes(in []byte) (S, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *delimitedParser[R, F, S, T]) ParseSession(s *parser.Session, in R) (S, error) {
 	currentOffset, _ := in.Seek(0, io.SeekCurrent)
-	if _, err := o.first.Parse(in); err != nil {
+	if _, err := o.first.ParseIn(s, in); err != nil {
 		var r S
 		return r, err
 	}
 
-	s, err := o.second.Parse(in)
+	v, err := o.second.ParseIn(s, in)
 	if err != nil {
 		_, _ = in.Seek(currentOffset, io.SeekStart)
-		return s, err
+		return v, err
 	}
 
-	if _, err := o.third.Parse(in); err != nil {
+	if _, err := o.third.ParseIn(s, in); err != nil {
 		_, _ = in.Seek(currentOffset, io.SeekStart)
 		var r S
 		return r, err
 	}
 
-	return s, nil
+	return v, nil
 }
 
-func (o *delimitedParser[R, F, S, T]) ParseBytes(in []byte) (S, []byte, error) {
-	_, out, err := o.first.ParseBytes(in)
+func (o *delimitedParser[R, F, S, T]) ParseBytesSession(s *parser.Session, in []byte) (S, []byte, error) {
+	_, out, err := o.first.ParseBytesIn(s, in)
 	if err != nil {
 		var r S
 		return r, in, err
 	}
 
-	s, out, err := o.second.ParseBytes(out)
+	v, out, err := o.second.ParseBytesIn(s, out)
 	if err != nil {
-		return s, in, err
+		return v, in, err
 	}
-	_, out, err = o.third.ParseBytes(out)
+	_, out, err = o.third.ParseBytesIn(s, out)
 	if err != nil {
 		var r S
 		return r, in, err
 	}
 
-	return s, out, nil
+	return v, out, nil
+}
+
+func (o *delimitedParser[R, F, S, T]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.first, o.second, o.third)
+}
+
+func (o *delimitedParser[R, F, S, T]) Expected() []string {
+	return parser.ExpectedOf(o.first)
+}
+
+// Print prints the first and third parsers with their zero values, so they should be parsers of constants, such as
+// tags.
+func (o *delimitedParser[R, F, S, T]) Print(w io.Writer, v S) error {
+	var f F
+	var t T
+	if err := o.first.Print(w, f); err != nil {
+		return err
+	}
+	if err := o.second.Print(w, v); err != nil {
+		return err
+	}
+	return o.third.Print(w, t)
 }
 
 // Delimited Matches an object from the first parser and discards it, then gets an object from the second parser,
@@ -60,5 +90,5 @@ func (o *delimitedParser[R, F, S, T]) ParseBytes(in []byte) (S, []byte, error) {
 func Delimited[R parser.Reader, F, S, T any](
 	first parser.Parser[R, F], second parser.Parser[R, S], third parser.Parser[R, T],
 ) parser.Parser[R, S] {
-	return &delimitedParser[R, F, S, T]{first: first, second: second, third: third}
+	return &delimitedParser[R, F, S, T]{first: parser.NewChild(first), second: parser.NewChild(second), third: parser.NewChild(third)}
 }
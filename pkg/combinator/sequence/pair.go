@@ -7,96 +7,147 @@ import (
 
 type (
 	pairParser[R parser.Reader, F, S any] struct {
-		first  parser.Parser[R, F]
-		second parser.Parser[R, S]
+		first  parser.Child[R, F]
+		second parser.Child[R, S]
 	}
 
 	separatedPairParser[R parser.Reader, F, S, T any] struct {
-		first     parser.Parser[R, F]
-		separator parser.Parser[R, T]
-		second    parser.Parser[R, S]
+		first     parser.Child[R, F]
+		separator parser.Child[R, T]
+		second    parser.Child[R, S]
 	}
 )
 
 func (o *pairParser[R, F, S]) Parse(in R) (parser.Pair[F, S], error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *pairParser[R, F, S]) ParseBytes(in []byte) (parser.Pair[F, S], []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *pairParser[R, F, S]) ParseSession(s *parser.Session, in R) (parser.Pair[F, S], error) {
 	currentOffset, _ := in.Seek(0, io.SeekCurrent)
-	f, err := o.first.Parse(in)
+	f, err := o.first.ParseIn(s, in)
 	if err != nil {
 		var r parser.Pair[F, S]
 		return r, err
 	}
-	s, err := o.second.Parse(in)
+	v, err := o.second.ParseIn(s, in)
 	if err != nil {
 		_, _ = in.Seek(currentOffset, io.SeekStart)
 		var r parser.Pair[F, S]
 		return r, err
 	}
-	return parser.Pair[F, S]{First: f, Second: s}, err
+	return parser.Pair[F, S]{First: f, Second: v}, err
 }
 
-func (o *pairParser[R, F, S]) ParseBytes(in []byte) (parser.Pair[F, S], []byte, error) {
-	f, out, err := o.first.ParseBytes(in)
+func (o *pairParser[R, F, S]) ParseBytesSession(s *parser.Session, in []byte) (parser.Pair[F, S], []byte, error) {
+	f, out, err := o.first.ParseBytesIn(s, in)
 	if err != nil {
 		var r parser.Pair[F, S]
 		return r, in, err
 	}
-	s, out, err := o.second.ParseBytes(out)
+	v, out, err := o.second.ParseBytesIn(s, out)
 	if err != nil {
 		var r parser.Pair[F, S]
 		return r, in, err
 	}
-	return parser.Pair[F, S]{First: f, Second: s}, out, err
+	return parser.Pair[F, S]{First: f, Second: v}, out, err
+}
+
+func (o *pairParser[R, F, S]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.first, o.second)
 }
 
 func (o *separatedPairParser[R, F, S, T]) Parse(in R) (parser.Pair[F, S], error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *separatedPairParser[R, F, S, T]) ParseBytes(in []byte) (parser.Pair[F, S], []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *separatedPairParser[R, F, S, T]) ParseSession(s *parser.Session, in R) (parser.Pair[F, S], error) {
 	currentOffset, _ := in.Seek(0, io.SeekCurrent)
-	f, err := o.first.Parse(in)
+	f, err := o.first.ParseIn(s, in)
 	if err != nil {
 		var r parser.Pair[F, S]
 		return r, err
 	}
 
-	if _, err := o.separator.Parse(in); err != nil {
+	if _, err := o.separator.ParseIn(s, in); err != nil {
 		_, _ = in.Seek(currentOffset, io.SeekStart)
 		var r parser.Pair[F, S]
 		return r, err
 	}
 
-	s, err := o.second.Parse(in)
+	v, err := o.second.ParseIn(s, in)
 	if err != nil {
 		_, _ = in.Seek(currentOffset, io.SeekStart)
 		var r parser.Pair[F, S]
 		return r, err
 	}
-	return parser.Pair[F, S]{First: f, Second: s}, err
+	return parser.Pair[F, S]{First: f, Second: v}, err
 }
 
-func (o *separatedPairParser[R, F, S, T]) ParseBytes(in []byte) (parser.Pair[F, S], []byte, error) {
-	f, out, err := o.first.ParseBytes(in)
+func (o *separatedPairParser[R, F, S, T]) ParseBytesSession(s *parser.Session, in []byte) (parser.Pair[F, S], []byte, error) {
+	f, out, err := o.first.ParseBytesIn(s, in)
 	if err != nil {
 		var r parser.Pair[F, S]
 		return r, in, err
 	}
 
-	_, out, err = o.separator.ParseBytes(out)
+	_, out, err = o.separator.ParseBytesIn(s, out)
 	if err != nil {
 		var r parser.Pair[F, S]
 		return r, in, err
 	}
 
-	s, out, err := o.second.ParseBytes(out)
+	v, out, err := o.second.ParseBytesIn(s, out)
 	if err != nil {
 		var r parser.Pair[F, S]
 		return r, in, err
 	}
-	return parser.Pair[F, S]{First: f, Second: s}, out, err
+	return parser.Pair[F, S]{First: f, Second: v}, out, err
+}
+
+func (o *separatedPairParser[R, F, S, T]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.first, o.separator, o.second)
+}
+
+func (o *pairParser[R, F, S]) Expected() []string {
+	return parser.ExpectedOf(o.first)
+}
+
+func (o *separatedPairParser[R, F, S, T]) Expected() []string {
+	return parser.ExpectedOf(o.first)
+}
+
+func (o *pairParser[R, F, S]) Print(w io.Writer, v parser.Pair[F, S]) error {
+	if err := o.first.Print(w, v.First); err != nil {
+		return err
+	}
+	return o.second.Print(w, v.Second)
+}
+
+// Print prints the separator with its zero value, so it should be a parser of a constant, such as a tag.
+func (o *separatedPairParser[R, F, S, T]) Print(w io.Writer, v parser.Pair[F, S]) error {
+	var sep T
+	if err := o.first.Print(w, v.First); err != nil {
+		return err
+	}
+	if err := o.separator.Print(w, sep); err != nil {
+		return err
+	}
+	return o.second.Print(w, v.Second)
 }
 
 // Pair Gets an object from the first parser, then gets another object from the second parser.
 func Pair[R parser.Reader, F, S any](
 	first parser.Parser[R, F], second parser.Parser[R, S],
 ) parser.Parser[R, parser.Pair[F, S]] {
-	return &pairParser[R, F, S]{first: first, second: second}
+	return &pairParser[R, F, S]{first: parser.NewChild(first), second: parser.NewChild(second)}
 }
 
 // SeparatedPair Gets an object from the first parser, then checks the separator parser, before getting another object
@@ -104,5 +155,5 @@ func Pair[R parser.Reader, F, S any](
 func SeparatedPair[R parser.Reader, F, S, T any](
 	first parser.Parser[R, F], separator parser.Parser[R, T], second parser.Parser[R, S],
 ) parser.Parser[R, parser.Pair[F, S]] {
-	return &separatedPairParser[R, F, S, T]{first: first, separator: separator, second: second}
+	return &separatedPairParser[R, F, S, T]{first: parser.NewChild(first), separator: parser.NewChild(separator), second: parser.NewChild(second)}
 }
@@ -0,0 +1,172 @@
+package sequence
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"io"
+)
+
+type (
+	// PermutationMember is a parser in a permutation, which may be optional.
+	PermutationMember[R parser.Reader, T any] struct {
+		parser   parser.Child[R, T]
+		optional bool
+		fallback T
+	}
+
+	permutationParser[R parser.Reader, T any] struct {
+		members []PermutationMember[R, T]
+	}
+)
+
+func (o *permutationParser[R, T]) Parse(in R) ([]T, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *permutationParser[R, T]) ParseBytes(in []byte) ([]T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *permutationParser[R, T]) ParseSession(s *parser.Session, in R) ([]T, error) {
+	currentOffset, _ := in.Seek(0, io.SeekCurrent)
+
+	result := make([]T, len(o.members))
+	matched := make([]bool, len(o.members))
+	for {
+		var furthest error
+		found := false
+		for i, m := range o.members {
+			if matched[i] {
+				continue
+			}
+			r, err := m.parser.ParseIn(s, in)
+			if err == nil {
+				result[i], matched[i], found = r, true, true
+				break
+			}
+			if errors.IsFatal(err) {
+				_, _ = in.Seek(currentOffset, io.SeekStart)
+				return nil, err
+			}
+			if !m.optional {
+				furthest = errors.Furthest(furthest, err)
+			}
+		}
+		if found {
+			continue
+		}
+		if furthest != nil {
+			_, _ = in.Seek(currentOffset, io.SeekStart)
+			return nil, furthest
+		}
+		return o.fill(result, matched), nil
+	}
+}
+
+func (o *permutationParser[R, T]) ParseBytesSession(s *parser.Session, in []byte) ([]T, []byte, error) {
+	result := make([]T, len(o.members))
+	matched := make([]bool, len(o.members))
+	out := in
+	for {
+		var furthest error
+		found := false
+		for i, m := range o.members {
+			if matched[i] {
+				continue
+			}
+			r, next, err := m.parser.ParseBytesIn(s, out)
+			if err == nil {
+				result[i], matched[i], found = r, true, true
+				out = next
+				break
+			}
+			if errors.IsFatal(err) {
+				return nil, in, err
+			}
+			if !m.optional {
+				furthest = errors.Furthest(furthest, err)
+			}
+		}
+		if found {
+			continue
+		}
+		if furthest != nil {
+			return nil, in, furthest
+		}
+		return o.fill(result, matched), out, nil
+	}
+}
+
+// Describe describes the permutation as repeating a choice of the members, as a grammar can't describe each member
+// being matched once.
+func (o *permutationParser[R, T]) Describe() parser.Grammar {
+	members := make([]any, len(o.members))
+	required := 0
+	for i, m := range o.members {
+		members[i] = m.parser
+		if !m.optional {
+			required++
+		}
+	}
+	return parser.NewRepeatGrammar(parser.NewGrammar(parser.GrammarChoice, members...), required, len(o.members))
+}
+
+func (o *permutationParser[R, T]) Expected() []string {
+	var expected []string
+	for _, m := range o.members {
+		expected = append(expected, parser.ExpectedOf(m.parser)...)
+	}
+	return expected
+}
+
+// Print prints every member in the order they were given, including optional members.
+func (o *permutationParser[R, T]) Print(w io.Writer, v []T) error {
+	if len(v) != len(o.members) {
+		return errors.ErrNotMatched
+	}
+	for i, m := range o.members {
+		if err := m.parser.Print(w, v[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fill sets the results of the optional members which weren't matched to their fallback values.
+func (o *permutationParser[R, T]) fill(result []T, matched []bool) []T {
+	for i, m := range o.members {
+		if !matched[i] {
+			result[i] = m.fallback
+		}
+	}
+	return result
+}
+
+// Member is a permutation member which must be matched.
+func Member[R parser.Reader, T any](p parser.Parser[R, T]) PermutationMember[R, T] {
+	return PermutationMember[R, T]{parser: parser.NewChild(p)}
+}
+
+// OptionalMember is a permutation member which may be missing, in which case the fallback value is returned.
+func OptionalMember[R parser.Reader, T any](p parser.Parser[R, T], fallback T) PermutationMember[R, T] {
+	return PermutationMember[R, T]{parser: parser.NewChild(p), optional: true, fallback: fallback}
+}
+
+// Permutation applies each of the parsers exactly once, in any order, and returns their results in the order the
+// parsers were given. At each step the parsers which haven't matched yet are tried in order, and the first that matches
+// is used, without backtracking to try other orders.
+//   - If a parser never matches, it will return the error of the parser which got furthest in the last step.
+//   - If a parser returns a fatal error, it will return the error.
+func Permutation[R parser.Reader, T any](parsers ...parser.Parser[R, T]) parser.Parser[R, []T] {
+	members := make([]PermutationMember[R, T], len(parsers))
+	for i, p := range parsers {
+		members[i] = Member(p)
+	}
+	return PermutationOf(members...)
+}
+
+// PermutationOf is a Permutation where some of the members can be optional. Optional members are matched at most once,
+// and the permutation ends when no more members match and every required member has been matched.
+func PermutationOf[R parser.Reader, T any](members ...PermutationMember[R, T]) parser.Parser[R, []T] {
+	return &permutationParser[R, T]{members: members}
+}
@@ -0,0 +1,125 @@
+package sequence
+
+import (
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestPermutation(t *testing.T) {
+	tests := []struct {
+		name       string
+		parser     parser.Parser[parser.Reader, []byte]
+		input      string
+		wantMatch  []byte
+		wantRemain string
+		wantErr    error
+		wantFatal  bool
+	}{
+		{
+			name:    "empty input => EOF",
+			parser:  Permutation(bytes.Byte('a'), bytes.Byte('b')),
+			input:   "",
+			wantErr: io.EOF,
+		},
+		{
+			name:      "declaration order => results",
+			parser:    Permutation(bytes.Byte('a'), bytes.Byte('b'), bytes.Byte('c')),
+			input:     "abc",
+			wantMatch: []byte("abc"),
+		},
+		{
+			name:       "any order => results in declaration order",
+			parser:     Permutation(bytes.Byte('a'), bytes.Byte('b'), bytes.Byte('c')),
+			input:      "cabc",
+			wantMatch:  []byte("abc"),
+			wantRemain: "c",
+		},
+		{
+			name:       "member missing => no match",
+			parser:     Permutation(bytes.Byte('a'), bytes.Byte('b'), bytes.Byte('c')),
+			input:      "cbb",
+			wantRemain: "cbb",
+			wantErr:    errors.ErrNotMatched,
+		},
+		{
+			name:       "member repeated => no match",
+			parser:     Permutation(bytes.Byte('a'), bytes.Byte('b')),
+			input:      "aab",
+			wantRemain: "aab",
+			wantErr:    errors.ErrNotMatched,
+		},
+		{
+			name: "optional member missing => fallback",
+			parser: PermutationOf(
+				Member(bytes.Byte('a')),
+				OptionalMember(bytes.Byte('b'), '-'),
+				Member(bytes.Byte('c')),
+			),
+			input:      "caa",
+			wantMatch:  []byte("a-c"),
+			wantRemain: "a",
+		},
+		{
+			name: "optional member present => result",
+			parser: PermutationOf(
+				Member(bytes.Byte('a')),
+				OptionalMember(bytes.Byte('b'), '-'),
+			),
+			input:     "ba",
+			wantMatch: []byte("ab"),
+		},
+		{
+			name: "required member missing => no match",
+			parser: PermutationOf(
+				Member(bytes.Byte('a')),
+				OptionalMember(bytes.Byte('b'), '-'),
+			),
+			input:      "bc",
+			wantRemain: "bc",
+			wantErr:    errors.ErrNotMatched,
+		},
+		{
+			name:       "fatal error => fatal",
+			parser:     Permutation(bytes.Byte('a'), modifier.Cut(bytes.Byte('b'))),
+			input:      "ac",
+			wantRemain: "ac",
+			wantErr:    errors.ErrNotMatched,
+			wantFatal:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := strings.NewReader(tt.input)
+			s, err := tt.parser.Parse(input)
+
+			assert.Equal(t, tt.wantMatch, bytesOf(s))
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantFatal, errors.IsFatal(err))
+
+			remain, err := io.ReadAll(input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRemain, string(remain))
+
+			s, out, err := tt.parser.ParseBytes([]byte(tt.input))
+
+			assert.Equal(t, tt.wantMatch, bytesOf(s))
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantFatal, errors.IsFatal(err))
+			assert.Equal(t, tt.wantRemain, string(out))
+		})
+	}
+}
+
+func bytesOf(b []byte) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+	return b
+}
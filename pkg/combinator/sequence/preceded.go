@@ -7,38 +7,63 @@ import (
 
 type (
 	precededParser[R parser.Reader, F, S any] struct {
-		first  parser.Parser[R, F]
-		second parser.Parser[R, S]
+		first  parser.Child[R, F]
+		second parser.Child[R, S]
 	}
 )
 
 func (o *precededParser[R, F, S]) Parse(in R) (S, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *precededParser[R, F, S]) ParseBytes(in []byte) (S, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *precededParser[R, F, S]) ParseSession(s *parser.Session, in R) (S, error) {
 	currentOffset, _ := in.Seek(0, io.SeekCurrent)
-	if _, err := o.first.Parse(in); err != nil {
+	if _, err := o.first.ParseIn(s, in); err != nil {
 		var r S
 		return r, err
 	}
-	s, err := o.second.Parse(in)
+	v, err := o.second.ParseIn(s, in)
 	if err != nil {
 		_, _ = in.Seek(currentOffset, io.SeekStart)
 	}
-	return s, err
+	return v, err
 }
 
-func (o *precededParser[R, F, S]) ParseBytes(in []byte) (S, []byte, error) {
-	_, out, err := o.first.ParseBytes(in)
+func (o *precededParser[R, F, S]) ParseBytesSession(s *parser.Session, in []byte) (S, []byte, error) {
+	_, out, err := o.first.ParseBytesIn(s, in)
 	if err != nil {
 		var r S
 		return r, in, err
 	}
-	s, out, err := o.second.ParseBytes(out)
+	v, out, err := o.second.ParseBytesIn(s, out)
 	if err != nil {
-		return s, in, err
+		return v, in, err
+	}
+	return v, out, err
+}
+
+func (o *precededParser[R, F, S]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.first, o.second)
+}
+
+func (o *precededParser[R, F, S]) Expected() []string {
+	return parser.ExpectedOf(o.first)
+}
+
+// Print prints the first parser with its zero value, so it should be a parser of a constant, such as a tag.
+func (o *precededParser[R, F, S]) Print(w io.Writer, v S) error {
+	var f F
+	if err := o.first.Print(w, f); err != nil {
+		return err
 	}
-	return s, out, err
+	return o.second.Print(w, v)
 }
 
 // Preceded Matches an object from the first parser and discards it, then gets an object from the second parser.
 func Preceded[R parser.Reader, F, S any](first parser.Parser[R, F], second parser.Parser[R, S]) parser.Parser[R, S] {
-	return &precededParser[R, F, S]{first: first, second: second}
+	return &precededParser[R, F, S]{first: parser.NewChild(first), second: parser.NewChild(second)}
 }
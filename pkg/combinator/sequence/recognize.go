@@ -7,13 +7,21 @@ import (
 
 type (
 	recognizeParser[R parser.Reader, T any] struct {
-		parser parser.Parser[R, T]
+		parser parser.Child[R, T]
 	}
 )
 
 func (o *recognizeParser[R, T]) Parse(in R) ([]byte, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *recognizeParser[R, T]) ParseBytes(in []byte) ([]byte, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *recognizeParser[R, T]) ParseSession(s *parser.Session, in R) ([]byte, error) {
 	startOffset, _ := in.Seek(0, io.SeekCurrent)
-	_, err := o.parser.Parse(in)
+	_, err := o.parser.ParseIn(s, in)
 	if err != nil {
 		_, _ = in.Seek(startOffset, io.SeekStart)
 		return nil, err
@@ -25,15 +33,23 @@ func (o *recognizeParser[R, T]) Parse(in R) ([]byte, error) {
 	return result, nil
 }
 
-func (o *recognizeParser[R, T]) ParseBytes(in []byte) ([]byte, []byte, error) {
-	_, out, err := o.parser.ParseBytes(in)
+func (o *recognizeParser[R, T]) ParseBytesSession(s *parser.Session, in []byte) ([]byte, []byte, error) {
+	_, out, err := o.parser.ParseBytesIn(s, in)
 	if err != nil {
 		return nil, in, err
 	}
 	return in[:len(in)-len(out)], out, nil
 }
 
+func (o *recognizeParser[R, T]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.parser)
+}
+
+func (o *recognizeParser[R, T]) Expected() []string {
+	return parser.ExpectedOf(o.parser)
+}
+
 // Recognize If the child parser was successful, return the consumed input as produced value.
 func Recognize[R parser.Reader, T any](p parser.Parser[R, T]) parser.Parser[R, []byte] {
-	return &recognizeParser[R, T]{parser: p}
+	return &recognizeParser[R, T]{parser: parser.NewChild(p)}
 }
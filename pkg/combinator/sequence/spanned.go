@@ -0,0 +1,58 @@
+package sequence
+
+import (
+	"github.com/roblovelock/gobble/pkg/parser"
+	"io"
+)
+
+type (
+	spannedParser[R parser.Reader, T any] struct {
+		parser parser.Child[R, T]
+	}
+)
+
+func (o *spannedParser[R, T]) Parse(in R) (parser.Spanned[T], error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *spannedParser[R, T]) ParseBytes(in []byte) (parser.Spanned[T], []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *spannedParser[R, T]) ParseSession(s *parser.Session, in R) (parser.Spanned[T], error) {
+	startOffset, _ := in.Seek(0, io.SeekCurrent)
+	result, err := o.parser.ParseIn(s, in)
+	if err != nil {
+		_, _ = in.Seek(startOffset, io.SeekStart)
+		return parser.Spanned[T]{}, err
+	}
+	endOffset, _ := in.Seek(0, io.SeekCurrent)
+	return parser.Spanned[T]{Value: result, Start: startOffset, End: endOffset}, nil
+}
+
+func (o *spannedParser[R, T]) ParseBytesSession(s *parser.Session, in []byte) (parser.Spanned[T], []byte, error) {
+	result, out, err := o.parser.ParseBytesIn(s, in)
+	if err != nil {
+		return parser.Spanned[T]{}, in, err
+	}
+	return parser.Spanned[T]{Value: result, Start: s.Offset(in), End: s.Offset(out)}, out, nil
+}
+
+func (o *spannedParser[R, T]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.parser)
+}
+
+func (o *spannedParser[R, T]) Expected() []string {
+	return parser.ExpectedOf(o.parser)
+}
+
+// Print prints the value. The offsets are ignored.
+func (o *spannedParser[R, T]) Print(w io.Writer, v parser.Spanned[T]) error {
+	return o.parser.Print(w, v.Value)
+}
+
+// Spanned If the child parser was successful, return its value with the start and end offsets of the consumed input.
+// The offsets of ParseBytes are relative to the input passed to the top level parser.
+func Spanned[R parser.Reader, T any](p parser.Parser[R, T]) parser.Parser[R, parser.Spanned[T]] {
+	return &spannedParser[R, T]{parser: parser.NewChild(p)}
+}
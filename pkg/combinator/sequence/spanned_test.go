@@ -0,0 +1,99 @@
+package sequence
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+func digit1() parser.Parser[parser.Reader, []byte] {
+	return bytes.TakeWhile1(func(b byte) bool { return b >= '0' && b <= '9' })
+}
+
+func TestSpanned(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantMatch  parser.Spanned[[]byte]
+		wantRemain string
+		wantErr    error
+	}{
+		{
+			name:       "no match => no match",
+			input:      "a1",
+			wantRemain: "a1",
+			wantErr:    errors.ErrNotMatched,
+		},
+		{
+			name:       "match at start => span from 0",
+			input:      "12a",
+			wantMatch:  parser.Spanned[[]byte]{Value: []byte("12"), Start: 0, End: 2},
+			wantRemain: "a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Spanned(digit1())
+
+			input := strings.NewReader(tt.input)
+			s, err := p.Parse(input)
+
+			assert.Equal(t, tt.wantMatch, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			remain, err := io.ReadAll(input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRemain, string(remain))
+
+			s, out, err := p.ParseBytes([]byte(tt.input))
+
+			assert.Equal(t, tt.wantMatch, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantRemain, string(out))
+		})
+	}
+}
+
+func TestSpanned_nested(t *testing.T) {
+	number := Spanned(digit1())
+	p := Spanned(Pair(Preceded(bytes.Byte('('), number), Terminated(Preceded(bytes.Byte(','), number), bytes.Byte(')'))))
+	want := parser.Spanned[parser.Pair[parser.Spanned[[]byte], parser.Spanned[[]byte]]]{
+		Value: parser.Pair[parser.Spanned[[]byte], parser.Spanned[[]byte]]{
+			First:  parser.Spanned[[]byte]{Value: []byte("12"), Start: 2, End: 4},
+			Second: parser.Spanned[[]byte]{Value: []byte("345"), Start: 5, End: 8},
+		},
+		Start: 1,
+		End:   9,
+	}
+
+	input := strings.NewReader(" (12,345)")
+	_, _ = input.ReadByte()
+	s, err := p.Parse(input)
+	require.NoError(t, err)
+	assert.Equal(t, want, s)
+
+	in := []byte(" (12,345)")
+	s, out, err := Preceded(bytes.Byte(' '), p).ParseBytes(in)
+	require.NoError(t, err)
+	assert.Equal(t, want, s)
+	assert.Empty(t, out)
+}
+
+func TestSpanned_preceded(t *testing.T) {
+	p := Preceded(bytes.Tag([]byte("ab")), Spanned(bytes.Tag([]byte("cd"))))
+	want := parser.Spanned[[]byte]{Value: []byte("cd"), Start: 2, End: 4}
+
+	s, err := p.Parse(strings.NewReader("abcde"))
+	require.NoError(t, err)
+	assert.Equal(t, want, s)
+
+	s, out, err := p.ParseBytes([]byte("abcde"))
+	require.NoError(t, err)
+	assert.Equal(t, want, s)
+	assert.Equal(t, "e", string(out))
+}
@@ -7,19 +7,27 @@ import (
 
 type (
 	terminatedParser[R parser.Reader, F, S any] struct {
-		first  parser.Parser[R, F]
-		second parser.Parser[R, S]
+		first  parser.Child[R, F]
+		second parser.Child[R, S]
 	}
 )
 
 func (o *terminatedParser[R, F, S]) Parse(in R) (F, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *terminatedParser[R, F, S]) ParseBytes(in []byte) (F, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *terminatedParser[R, F, S]) ParseSession(s *parser.Session, in R) (F, error) {
 	currentOffset, _ := in.Seek(0, io.SeekCurrent)
-	f, err := o.first.Parse(in)
+	f, err := o.first.ParseIn(s, in)
 	if err != nil {
 		return f, err
 	}
 
-	if _, err := o.second.Parse(in); err != nil {
+	if _, err := o.second.ParseIn(s, in); err != nil {
 		_, _ = in.Seek(currentOffset, io.SeekStart)
 		var r F
 		return r, err
@@ -27,12 +35,12 @@ func (o *terminatedParser[R, F, S]) Parse(in R) (F, error) {
 	return f, err
 }
 
-func (o *terminatedParser[R, F, S]) ParseBytes(in []byte) (F, []byte, error) {
-	f, out, err := o.first.ParseBytes(in)
+func (o *terminatedParser[R, F, S]) ParseBytesSession(s *parser.Session, in []byte) (F, []byte, error) {
+	f, out, err := o.first.ParseBytesIn(s, in)
 	if err != nil {
 		return f, in, err
 	}
-	_, out, err = o.second.ParseBytes(out)
+	_, out, err = o.second.ParseBytesIn(s, out)
 	if err != nil {
 		var r F
 		return r, in, err
@@ -40,7 +48,24 @@ func (o *terminatedParser[R, F, S]) ParseBytes(in []byte) (F, []byte, error) {
 	return f, out, err
 }
 
+func (o *terminatedParser[R, F, S]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.first, o.second)
+}
+
+func (o *terminatedParser[R, F, S]) Expected() []string {
+	return parser.ExpectedOf(o.first)
+}
+
+// Print prints the second parser with its zero value, so it should be a parser of a constant, such as a tag.
+func (o *terminatedParser[R, F, S]) Print(w io.Writer, v F) error {
+	var s S
+	if err := o.first.Print(w, v); err != nil {
+		return err
+	}
+	return o.second.Print(w, s)
+}
+
 // Terminated Gets an object from the first parser, then matches an object from the second parser and discards it.
 func Terminated[R parser.Reader, F, S any](first parser.Parser[R, F], second parser.Parser[R, S]) parser.Parser[R, F] {
-	return &terminatedParser[R, F, S]{first: first, second: second}
+	return &terminatedParser[R, F, S]{first: parser.NewChild(first), second: parser.NewChild(second)}
 }
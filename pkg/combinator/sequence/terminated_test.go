@@ -100,7 +100,7 @@ func TestTerminated(t *testing.T) {
 			assert.Equal(t, tt.wantMatch, s)
 			assert.ErrorIs(t, err, tt.wantErr)
 
-			if err != io.EOF {
+			if !errors.Is(err, io.EOF) {
 				remain, err := io.ReadAll(tt.args.input)
 				require.NoError(t, err)
 				assert.Equal(t, tt.wantRemain, string(remain))
@@ -1,22 +1,31 @@
 package sequence
 
 import (
+	"github.com/roblovelock/gobble/pkg/errors"
 	"github.com/roblovelock/gobble/pkg/parser"
 	"io"
 )
 
 type (
 	tupleParser[R parser.Reader, T any] struct {
-		parsers []parser.Parser[R, T]
+		parsers []parser.Child[R, T]
 	}
 )
 
 func (o *tupleParser[R, T]) Parse(in R) ([]T, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *tupleParser[R, T]) ParseBytes(in []byte) ([]T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *tupleParser[R, T]) ParseSession(s *parser.Session, in R) ([]T, error) {
 	currentOffset, _ := in.Seek(0, io.SeekCurrent)
 
 	result := make([]T, len(o.parsers))
 	for i, p := range o.parsers {
-		r, err := p.Parse(in)
+		r, err := p.ParseIn(s, in)
 		if err != nil {
 			_, _ = in.Seek(currentOffset, io.SeekStart)
 			return nil, err
@@ -27,11 +36,12 @@ func (o *tupleParser[R, T]) Parse(in R) ([]T, error) {
 	return result, nil
 }
 
-func (o *tupleParser[R, T]) ParseBytes(in []byte) (result []T, out []byte, err error) {
+func (o *tupleParser[R, T]) ParseBytesSession(s *parser.Session, in []byte) (result []T, out []byte, err error) {
 	var r T
+	result = make([]T, len(o.parsers))
 	out = in
 	for i, p := range o.parsers {
-		r, out, err = p.ParseBytes(in)
+		r, out, err = p.ParseBytesIn(s, out)
 		if err != nil {
 			return nil, in, err
 		}
@@ -41,7 +51,35 @@ func (o *tupleParser[R, T]) ParseBytes(in []byte) (result []T, out []byte, err e
 	return result, out, nil
 }
 
+func (o *tupleParser[R, T]) Describe() parser.Grammar {
+	children := make([]any, len(o.parsers))
+	for i, p := range o.parsers {
+		children[i] = p
+	}
+	return parser.NewGrammar(parser.GrammarSequence, children...)
+}
+
+func (o *tupleParser[R, T]) Expected() []string {
+	if len(o.parsers) == 0 {
+		return nil
+	}
+	return parser.ExpectedOf(o.parsers[0])
+}
+
+// Print prints each value with the parser at the same index. There must be a value for each parser.
+func (o *tupleParser[R, T]) Print(w io.Writer, v []T) error {
+	if len(v) != len(o.parsers) {
+		return errors.ErrNotMatched
+	}
+	for i, p := range o.parsers {
+		if err := p.Print(w, v[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Tuple applies a number of parsers one by one and returns their results as a slice.
 func Tuple[R parser.Reader, T any](parsers ...parser.Parser[R, T]) parser.Parser[R, []T] {
-	return &tupleParser[R, T]{parsers: parsers}
+	return &tupleParser[R, T]{parsers: parser.NewChildren(parsers)}
 }
@@ -0,0 +1,66 @@
+package sequence
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestTuple(t *testing.T) {
+	p := Tuple(bytes.Byte('a'), bytes.Byte('b'), bytes.Byte('c'))
+
+	tests := []struct {
+		name       string
+		input      string
+		wantMatch  []byte
+		wantRemain string
+		wantErr    error
+	}{
+		{
+			name:    "empty input => EOF",
+			input:   "",
+			wantErr: io.EOF,
+		},
+		{
+			name:       "second mismatch => no match",
+			input:      "aac",
+			wantRemain: "aac",
+			wantErr:    errors.ErrNotMatched,
+		},
+		{
+			name:       "third EOF => EOF",
+			input:      "ab",
+			wantRemain: "ab",
+			wantErr:    io.EOF,
+		},
+		{
+			name:       "match => results in order",
+			input:      "abcd",
+			wantMatch:  []byte("abc"),
+			wantRemain: "d",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := strings.NewReader(tt.input)
+			s, err := p.Parse(input)
+
+			assert.Equal(t, tt.wantMatch, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			remain, err := io.ReadAll(input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRemain, string(remain))
+
+			s, out, err := p.ParseBytes([]byte(tt.input))
+
+			assert.Equal(t, tt.wantMatch, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantRemain, string(out))
+		})
+	}
+}
@@ -0,0 +1,682 @@
+package sequence
+
+import (
+	"github.com/roblovelock/gobble/pkg/parser"
+	"io"
+)
+
+type (
+	tuple3Parser[R parser.Reader, A, B, C any] struct {
+		a parser.Child[R, A]
+		b parser.Child[R, B]
+		c parser.Child[R, C]
+	}
+
+	tuple4Parser[R parser.Reader, A, B, C, D any] struct {
+		a parser.Child[R, A]
+		b parser.Child[R, B]
+		c parser.Child[R, C]
+		d parser.Child[R, D]
+	}
+
+	tuple5Parser[R parser.Reader, A, B, C, D, E any] struct {
+		a parser.Child[R, A]
+		b parser.Child[R, B]
+		c parser.Child[R, C]
+		d parser.Child[R, D]
+		e parser.Child[R, E]
+	}
+
+	tuple6Parser[R parser.Reader, A, B, C, D, E, F any] struct {
+		a parser.Child[R, A]
+		b parser.Child[R, B]
+		c parser.Child[R, C]
+		d parser.Child[R, D]
+		e parser.Child[R, E]
+		f parser.Child[R, F]
+	}
+
+	tuple7Parser[R parser.Reader, A, B, C, D, E, F, G any] struct {
+		a parser.Child[R, A]
+		b parser.Child[R, B]
+		c parser.Child[R, C]
+		d parser.Child[R, D]
+		e parser.Child[R, E]
+		f parser.Child[R, F]
+		g parser.Child[R, G]
+	}
+
+	tuple8Parser[R parser.Reader, A, B, C, D, E, F, G, H any] struct {
+		a parser.Child[R, A]
+		b parser.Child[R, B]
+		c parser.Child[R, C]
+		d parser.Child[R, D]
+		e parser.Child[R, E]
+		f parser.Child[R, F]
+		g parser.Child[R, G]
+		h parser.Child[R, H]
+	}
+)
+
+func (o *tuple3Parser[R, A, B, C]) Parse(in R) (parser.Tuple3[A, B, C], error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *tuple3Parser[R, A, B, C]) ParseBytes(in []byte) (parser.Tuple3[A, B, C], []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *tuple3Parser[R, A, B, C]) ParseSession(s *parser.Session, in R) (parser.Tuple3[A, B, C], error) {
+	currentOffset, _ := in.Seek(0, io.SeekCurrent)
+	var t parser.Tuple3[A, B, C]
+	var err error
+	if t.First, err = o.a.ParseIn(s, in); err != nil {
+		return parser.Tuple3[A, B, C]{}, err
+	}
+	if t.Second, err = o.b.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple3[A, B, C]{}, err
+	}
+	if t.Third, err = o.c.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple3[A, B, C]{}, err
+	}
+	return t, nil
+}
+
+func (o *tuple3Parser[R, A, B, C]) ParseBytesSession(
+	s *parser.Session, in []byte,
+) (parser.Tuple3[A, B, C], []byte, error) {
+	var t parser.Tuple3[A, B, C]
+	out := in
+	var err error
+	if t.First, out, err = o.a.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple3[A, B, C]{}, in, err
+	}
+	if t.Second, out, err = o.b.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple3[A, B, C]{}, in, err
+	}
+	if t.Third, out, err = o.c.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple3[A, B, C]{}, in, err
+	}
+	return t, out, nil
+}
+
+func (o *tuple3Parser[R, A, B, C]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.a, o.b, o.c)
+}
+
+func (o *tuple3Parser[R, A, B, C]) Expected() []string {
+	return parser.ExpectedOf(o.a)
+}
+
+func (o *tuple3Parser[R, A, B, C]) Print(w io.Writer, v parser.Tuple3[A, B, C]) error {
+	if err := o.a.Print(w, v.First); err != nil {
+		return err
+	}
+	if err := o.b.Print(w, v.Second); err != nil {
+		return err
+	}
+	return o.c.Print(w, v.Third)
+}
+
+func (o *tuple4Parser[R, A, B, C, D]) Parse(in R) (parser.Tuple4[A, B, C, D], error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *tuple4Parser[R, A, B, C, D]) ParseBytes(in []byte) (parser.Tuple4[A, B, C, D], []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *tuple4Parser[R, A, B, C, D]) ParseSession(s *parser.Session, in R) (parser.Tuple4[A, B, C, D], error) {
+	currentOffset, _ := in.Seek(0, io.SeekCurrent)
+	var t parser.Tuple4[A, B, C, D]
+	var err error
+	if t.First, err = o.a.ParseIn(s, in); err != nil {
+		return parser.Tuple4[A, B, C, D]{}, err
+	}
+	if t.Second, err = o.b.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple4[A, B, C, D]{}, err
+	}
+	if t.Third, err = o.c.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple4[A, B, C, D]{}, err
+	}
+	if t.Fourth, err = o.d.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple4[A, B, C, D]{}, err
+	}
+	return t, nil
+}
+
+func (o *tuple4Parser[R, A, B, C, D]) ParseBytesSession(
+	s *parser.Session, in []byte,
+) (parser.Tuple4[A, B, C, D], []byte, error) {
+	var t parser.Tuple4[A, B, C, D]
+	out := in
+	var err error
+	if t.First, out, err = o.a.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple4[A, B, C, D]{}, in, err
+	}
+	if t.Second, out, err = o.b.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple4[A, B, C, D]{}, in, err
+	}
+	if t.Third, out, err = o.c.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple4[A, B, C, D]{}, in, err
+	}
+	if t.Fourth, out, err = o.d.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple4[A, B, C, D]{}, in, err
+	}
+	return t, out, nil
+}
+
+func (o *tuple4Parser[R, A, B, C, D]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.a, o.b, o.c, o.d)
+}
+
+func (o *tuple4Parser[R, A, B, C, D]) Expected() []string {
+	return parser.ExpectedOf(o.a)
+}
+
+func (o *tuple4Parser[R, A, B, C, D]) Print(w io.Writer, v parser.Tuple4[A, B, C, D]) error {
+	if err := o.a.Print(w, v.First); err != nil {
+		return err
+	}
+	if err := o.b.Print(w, v.Second); err != nil {
+		return err
+	}
+	if err := o.c.Print(w, v.Third); err != nil {
+		return err
+	}
+	return o.d.Print(w, v.Fourth)
+}
+
+func (o *tuple5Parser[R, A, B, C, D, E]) Parse(in R) (parser.Tuple5[A, B, C, D, E], error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *tuple5Parser[R, A, B, C, D, E]) ParseBytes(in []byte) (parser.Tuple5[A, B, C, D, E], []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *tuple5Parser[R, A, B, C, D, E]) ParseSession(s *parser.Session, in R) (parser.Tuple5[A, B, C, D, E], error) {
+	currentOffset, _ := in.Seek(0, io.SeekCurrent)
+	var t parser.Tuple5[A, B, C, D, E]
+	var err error
+	if t.First, err = o.a.ParseIn(s, in); err != nil {
+		return parser.Tuple5[A, B, C, D, E]{}, err
+	}
+	if t.Second, err = o.b.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple5[A, B, C, D, E]{}, err
+	}
+	if t.Third, err = o.c.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple5[A, B, C, D, E]{}, err
+	}
+	if t.Fourth, err = o.d.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple5[A, B, C, D, E]{}, err
+	}
+	if t.Fifth, err = o.e.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple5[A, B, C, D, E]{}, err
+	}
+	return t, nil
+}
+
+func (o *tuple5Parser[R, A, B, C, D, E]) ParseBytesSession(
+	s *parser.Session, in []byte,
+) (parser.Tuple5[A, B, C, D, E], []byte, error) {
+	var t parser.Tuple5[A, B, C, D, E]
+	out := in
+	var err error
+	if t.First, out, err = o.a.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple5[A, B, C, D, E]{}, in, err
+	}
+	if t.Second, out, err = o.b.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple5[A, B, C, D, E]{}, in, err
+	}
+	if t.Third, out, err = o.c.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple5[A, B, C, D, E]{}, in, err
+	}
+	if t.Fourth, out, err = o.d.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple5[A, B, C, D, E]{}, in, err
+	}
+	if t.Fifth, out, err = o.e.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple5[A, B, C, D, E]{}, in, err
+	}
+	return t, out, nil
+}
+
+func (o *tuple5Parser[R, A, B, C, D, E]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.a, o.b, o.c, o.d, o.e)
+}
+
+func (o *tuple5Parser[R, A, B, C, D, E]) Expected() []string {
+	return parser.ExpectedOf(o.a)
+}
+
+func (o *tuple5Parser[R, A, B, C, D, E]) Print(w io.Writer, v parser.Tuple5[A, B, C, D, E]) error {
+	if err := o.a.Print(w, v.First); err != nil {
+		return err
+	}
+	if err := o.b.Print(w, v.Second); err != nil {
+		return err
+	}
+	if err := o.c.Print(w, v.Third); err != nil {
+		return err
+	}
+	if err := o.d.Print(w, v.Fourth); err != nil {
+		return err
+	}
+	return o.e.Print(w, v.Fifth)
+}
+
+func (o *tuple6Parser[R, A, B, C, D, E, F]) Parse(in R) (parser.Tuple6[A, B, C, D, E, F], error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *tuple6Parser[R, A, B, C, D, E, F]) ParseBytes(in []byte) (parser.Tuple6[A, B, C, D, E, F], []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *tuple6Parser[R, A, B, C, D, E, F]) ParseSession(
+	s *parser.Session, in R,
+) (parser.Tuple6[A, B, C, D, E, F], error) {
+	currentOffset, _ := in.Seek(0, io.SeekCurrent)
+	var t parser.Tuple6[A, B, C, D, E, F]
+	var err error
+	if t.First, err = o.a.ParseIn(s, in); err != nil {
+		return parser.Tuple6[A, B, C, D, E, F]{}, err
+	}
+	if t.Second, err = o.b.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple6[A, B, C, D, E, F]{}, err
+	}
+	if t.Third, err = o.c.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple6[A, B, C, D, E, F]{}, err
+	}
+	if t.Fourth, err = o.d.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple6[A, B, C, D, E, F]{}, err
+	}
+	if t.Fifth, err = o.e.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple6[A, B, C, D, E, F]{}, err
+	}
+	if t.Sixth, err = o.f.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple6[A, B, C, D, E, F]{}, err
+	}
+	return t, nil
+}
+
+func (o *tuple6Parser[R, A, B, C, D, E, F]) ParseBytesSession(
+	s *parser.Session, in []byte,
+) (parser.Tuple6[A, B, C, D, E, F], []byte, error) {
+	var t parser.Tuple6[A, B, C, D, E, F]
+	out := in
+	var err error
+	if t.First, out, err = o.a.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple6[A, B, C, D, E, F]{}, in, err
+	}
+	if t.Second, out, err = o.b.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple6[A, B, C, D, E, F]{}, in, err
+	}
+	if t.Third, out, err = o.c.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple6[A, B, C, D, E, F]{}, in, err
+	}
+	if t.Fourth, out, err = o.d.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple6[A, B, C, D, E, F]{}, in, err
+	}
+	if t.Fifth, out, err = o.e.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple6[A, B, C, D, E, F]{}, in, err
+	}
+	if t.Sixth, out, err = o.f.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple6[A, B, C, D, E, F]{}, in, err
+	}
+	return t, out, nil
+}
+
+func (o *tuple6Parser[R, A, B, C, D, E, F]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.a, o.b, o.c, o.d, o.e, o.f)
+}
+
+func (o *tuple6Parser[R, A, B, C, D, E, F]) Expected() []string {
+	return parser.ExpectedOf(o.a)
+}
+
+func (o *tuple6Parser[R, A, B, C, D, E, F]) Print(w io.Writer, v parser.Tuple6[A, B, C, D, E, F]) error {
+	if err := o.a.Print(w, v.First); err != nil {
+		return err
+	}
+	if err := o.b.Print(w, v.Second); err != nil {
+		return err
+	}
+	if err := o.c.Print(w, v.Third); err != nil {
+		return err
+	}
+	if err := o.d.Print(w, v.Fourth); err != nil {
+		return err
+	}
+	if err := o.e.Print(w, v.Fifth); err != nil {
+		return err
+	}
+	return o.f.Print(w, v.Sixth)
+}
+
+func (o *tuple7Parser[R, A, B, C, D, E, F, G]) Parse(in R) (parser.Tuple7[A, B, C, D, E, F, G], error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *tuple7Parser[R, A, B, C, D, E, F, G]) ParseBytes(
+	in []byte,
+) (parser.Tuple7[A, B, C, D, E, F, G], []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *tuple7Parser[R, A, B, C, D, E, F, G]) ParseSession(
+	s *parser.Session, in R,
+) (parser.Tuple7[A, B, C, D, E, F, G], error) {
+	currentOffset, _ := in.Seek(0, io.SeekCurrent)
+	var t parser.Tuple7[A, B, C, D, E, F, G]
+	var err error
+	if t.First, err = o.a.ParseIn(s, in); err != nil {
+		return parser.Tuple7[A, B, C, D, E, F, G]{}, err
+	}
+	if t.Second, err = o.b.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple7[A, B, C, D, E, F, G]{}, err
+	}
+	if t.Third, err = o.c.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple7[A, B, C, D, E, F, G]{}, err
+	}
+	if t.Fourth, err = o.d.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple7[A, B, C, D, E, F, G]{}, err
+	}
+	if t.Fifth, err = o.e.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple7[A, B, C, D, E, F, G]{}, err
+	}
+	if t.Sixth, err = o.f.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple7[A, B, C, D, E, F, G]{}, err
+	}
+	if t.Seventh, err = o.g.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple7[A, B, C, D, E, F, G]{}, err
+	}
+	return t, nil
+}
+
+func (o *tuple7Parser[R, A, B, C, D, E, F, G]) ParseBytesSession(
+	s *parser.Session, in []byte,
+) (parser.Tuple7[A, B, C, D, E, F, G], []byte, error) {
+	var t parser.Tuple7[A, B, C, D, E, F, G]
+	out := in
+	var err error
+	if t.First, out, err = o.a.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple7[A, B, C, D, E, F, G]{}, in, err
+	}
+	if t.Second, out, err = o.b.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple7[A, B, C, D, E, F, G]{}, in, err
+	}
+	if t.Third, out, err = o.c.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple7[A, B, C, D, E, F, G]{}, in, err
+	}
+	if t.Fourth, out, err = o.d.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple7[A, B, C, D, E, F, G]{}, in, err
+	}
+	if t.Fifth, out, err = o.e.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple7[A, B, C, D, E, F, G]{}, in, err
+	}
+	if t.Sixth, out, err = o.f.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple7[A, B, C, D, E, F, G]{}, in, err
+	}
+	if t.Seventh, out, err = o.g.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple7[A, B, C, D, E, F, G]{}, in, err
+	}
+	return t, out, nil
+}
+
+func (o *tuple7Parser[R, A, B, C, D, E, F, G]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.a, o.b, o.c, o.d, o.e, o.f, o.g)
+}
+
+func (o *tuple7Parser[R, A, B, C, D, E, F, G]) Expected() []string {
+	return parser.ExpectedOf(o.a)
+}
+
+func (o *tuple7Parser[R, A, B, C, D, E, F, G]) Print(w io.Writer, v parser.Tuple7[A, B, C, D, E, F, G]) error {
+	if err := o.a.Print(w, v.First); err != nil {
+		return err
+	}
+	if err := o.b.Print(w, v.Second); err != nil {
+		return err
+	}
+	if err := o.c.Print(w, v.Third); err != nil {
+		return err
+	}
+	if err := o.d.Print(w, v.Fourth); err != nil {
+		return err
+	}
+	if err := o.e.Print(w, v.Fifth); err != nil {
+		return err
+	}
+	if err := o.f.Print(w, v.Sixth); err != nil {
+		return err
+	}
+	return o.g.Print(w, v.Seventh)
+}
+
+func (o *tuple8Parser[R, A, B, C, D, E, F, G, H]) Parse(in R) (parser.Tuple8[A, B, C, D, E, F, G, H], error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *tuple8Parser[R, A, B, C, D, E, F, G, H]) ParseBytes(
+	in []byte,
+) (parser.Tuple8[A, B, C, D, E, F, G, H], []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *tuple8Parser[R, A, B, C, D, E, F, G, H]) ParseSession(
+	s *parser.Session, in R,
+) (parser.Tuple8[A, B, C, D, E, F, G, H], error) {
+	currentOffset, _ := in.Seek(0, io.SeekCurrent)
+	var t parser.Tuple8[A, B, C, D, E, F, G, H]
+	var err error
+	if t.First, err = o.a.ParseIn(s, in); err != nil {
+		return parser.Tuple8[A, B, C, D, E, F, G, H]{}, err
+	}
+	if t.Second, err = o.b.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple8[A, B, C, D, E, F, G, H]{}, err
+	}
+	if t.Third, err = o.c.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple8[A, B, C, D, E, F, G, H]{}, err
+	}
+	if t.Fourth, err = o.d.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple8[A, B, C, D, E, F, G, H]{}, err
+	}
+	if t.Fifth, err = o.e.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple8[A, B, C, D, E, F, G, H]{}, err
+	}
+	if t.Sixth, err = o.f.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple8[A, B, C, D, E, F, G, H]{}, err
+	}
+	if t.Seventh, err = o.g.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple8[A, B, C, D, E, F, G, H]{}, err
+	}
+	if t.Eighth, err = o.h.ParseIn(s, in); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return parser.Tuple8[A, B, C, D, E, F, G, H]{}, err
+	}
+	return t, nil
+}
+
+func (o *tuple8Parser[R, A, B, C, D, E, F, G, H]) ParseBytesSession(
+	s *parser.Session, in []byte,
+) (parser.Tuple8[A, B, C, D, E, F, G, H], []byte, error) {
+	var t parser.Tuple8[A, B, C, D, E, F, G, H]
+	out := in
+	var err error
+	if t.First, out, err = o.a.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple8[A, B, C, D, E, F, G, H]{}, in, err
+	}
+	if t.Second, out, err = o.b.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple8[A, B, C, D, E, F, G, H]{}, in, err
+	}
+	if t.Third, out, err = o.c.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple8[A, B, C, D, E, F, G, H]{}, in, err
+	}
+	if t.Fourth, out, err = o.d.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple8[A, B, C, D, E, F, G, H]{}, in, err
+	}
+	if t.Fifth, out, err = o.e.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple8[A, B, C, D, E, F, G, H]{}, in, err
+	}
+	if t.Sixth, out, err = o.f.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple8[A, B, C, D, E, F, G, H]{}, in, err
+	}
+	if t.Seventh, out, err = o.g.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple8[A, B, C, D, E, F, G, H]{}, in, err
+	}
+	if t.Eighth, out, err = o.h.ParseBytesIn(s, out); err != nil {
+		return parser.Tuple8[A, B, C, D, E, F, G, H]{}, in, err
+	}
+	return t, out, nil
+}
+
+func (o *tuple8Parser[R, A, B, C, D, E, F, G, H]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.a, o.b, o.c, o.d, o.e, o.f, o.g, o.h)
+}
+
+func (o *tuple8Parser[R, A, B, C, D, E, F, G, H]) Expected() []string {
+	return parser.ExpectedOf(o.a)
+}
+
+func (o *tuple8Parser[R, A, B, C, D, E, F, G, H]) Print(w io.Writer, v parser.Tuple8[A, B, C, D, E, F, G, H]) error {
+	if err := o.a.Print(w, v.First); err != nil {
+		return err
+	}
+	if err := o.b.Print(w, v.Second); err != nil {
+		return err
+	}
+	if err := o.c.Print(w, v.Third); err != nil {
+		return err
+	}
+	if err := o.d.Print(w, v.Fourth); err != nil {
+		return err
+	}
+	if err := o.e.Print(w, v.Fifth); err != nil {
+		return err
+	}
+	if err := o.f.Print(w, v.Sixth); err != nil {
+		return err
+	}
+	if err := o.g.Print(w, v.Seventh); err != nil {
+		return err
+	}
+	return o.h.Print(w, v.Eighth)
+}
+
+// Tuple3 applies three parsers one by one and returns their results as a parser.Tuple3.
+func Tuple3[R parser.Reader, A, B, C any](
+	a parser.Parser[R, A], b parser.Parser[R, B], c parser.Parser[R, C],
+) parser.Parser[R, parser.Tuple3[A, B, C]] {
+	return &tuple3Parser[R, A, B, C]{
+		a: parser.NewChild(a),
+		b: parser.NewChild(b),
+		c: parser.NewChild(c),
+	}
+}
+
+// Tuple4 applies four parsers one by one and returns their results as a parser.Tuple4.
+func Tuple4[R parser.Reader, A, B, C, D any](
+	a parser.Parser[R, A], b parser.Parser[R, B], c parser.Parser[R, C], d parser.Parser[R, D],
+) parser.Parser[R, parser.Tuple4[A, B, C, D]] {
+	return &tuple4Parser[R, A, B, C, D]{
+		a: parser.NewChild(a),
+		b: parser.NewChild(b),
+		c: parser.NewChild(c),
+		d: parser.NewChild(d),
+	}
+}
+
+// Tuple5 applies five parsers one by one and returns their results as a parser.Tuple5.
+func Tuple5[R parser.Reader, A, B, C, D, E any](
+	a parser.Parser[R, A], b parser.Parser[R, B],
+	c parser.Parser[R, C], d parser.Parser[R, D],
+	e parser.Parser[R, E],
+) parser.Parser[R, parser.Tuple5[A, B, C, D, E]] {
+	return &tuple5Parser[R, A, B, C, D, E]{
+		a: parser.NewChild(a),
+		b: parser.NewChild(b),
+		c: parser.NewChild(c),
+		d: parser.NewChild(d),
+		e: parser.NewChild(e),
+	}
+}
+
+// Tuple6 applies six parsers one by one and returns their results as a parser.Tuple6.
+func Tuple6[R parser.Reader, A, B, C, D, E, F any](
+	a parser.Parser[R, A], b parser.Parser[R, B],
+	c parser.Parser[R, C], d parser.Parser[R, D],
+	e parser.Parser[R, E], f parser.Parser[R, F],
+) parser.Parser[R, parser.Tuple6[A, B, C, D, E, F]] {
+	return &tuple6Parser[R, A, B, C, D, E, F]{
+		a: parser.NewChild(a),
+		b: parser.NewChild(b),
+		c: parser.NewChild(c),
+		d: parser.NewChild(d),
+		e: parser.NewChild(e),
+		f: parser.NewChild(f),
+	}
+}
+
+// Tuple7 applies seven parsers one by one and returns their results as a parser.Tuple7.
+func Tuple7[R parser.Reader, A, B, C, D, E, F, G any](
+	a parser.Parser[R, A], b parser.Parser[R, B],
+	c parser.Parser[R, C], d parser.Parser[R, D],
+	e parser.Parser[R, E], f parser.Parser[R, F],
+	g parser.Parser[R, G],
+) parser.Parser[R, parser.Tuple7[A, B, C, D, E, F, G]] {
+	return &tuple7Parser[R, A, B, C, D, E, F, G]{
+		a: parser.NewChild(a),
+		b: parser.NewChild(b),
+		c: parser.NewChild(c),
+		d: parser.NewChild(d),
+		e: parser.NewChild(e),
+		f: parser.NewChild(f),
+		g: parser.NewChild(g),
+	}
+}
+
+// Tuple8 applies eight parsers one by one and returns their results as a parser.Tuple8.
+func Tuple8[R parser.Reader, A, B, C, D, E, F, G, H any](
+	a parser.Parser[R, A], b parser.Parser[R, B],
+	c parser.Parser[R, C], d parser.Parser[R, D],
+	e parser.Parser[R, E], f parser.Parser[R, F],
+	g parser.Parser[R, G], h parser.Parser[R, H],
+) parser.Parser[R, parser.Tuple8[A, B, C, D, E, F, G, H]] {
+	return &tuple8Parser[R, A, B, C, D, E, F, G, H]{
+		a: parser.NewChild(a),
+		b: parser.NewChild(b),
+		c: parser.NewChild(c),
+		d: parser.NewChild(d),
+		e: parser.NewChild(e),
+		f: parser.NewChild(f),
+		g: parser.NewChild(g),
+		h: parser.NewChild(h),
+	}
+}
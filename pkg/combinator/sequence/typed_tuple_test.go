@@ -0,0 +1,81 @@
+package sequence
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/roblovelock/gobble/pkg/parser/runes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestTuple3(t *testing.T) {
+	p := Tuple3(bytes.Byte('a'), runes.Rune('😀'), bytes.Take(2))
+
+	tests := []struct {
+		name       string
+		input      string
+		wantMatch  parser.Tuple3[byte, rune, []byte]
+		wantRemain string
+		wantErr    error
+	}{
+		{
+			name:    "empty input => EOF",
+			input:   "",
+			wantErr: io.EOF,
+		},
+		{
+			name:       "second mismatch => no match",
+			input:      "abcd",
+			wantRemain: "abcd",
+			wantErr:    errors.ErrNotMatched,
+		},
+		{
+			name:       "third EOF => EOF",
+			input:      "a😀c",
+			wantRemain: "a😀c",
+			wantErr:    io.EOF,
+		},
+		{
+			name:       "match => typed results",
+			input:      "a😀cde",
+			wantMatch:  parser.Tuple3[byte, rune, []byte]{First: 'a', Second: '😀', Third: []byte("cd")},
+			wantRemain: "e",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := strings.NewReader(tt.input)
+			s, err := p.Parse(input)
+
+			assert.Equal(t, tt.wantMatch, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			remain, err := io.ReadAll(input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRemain, string(remain))
+
+			s, out, err := p.ParseBytes([]byte(tt.input))
+
+			assert.Equal(t, tt.wantMatch, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantRemain, string(out))
+		})
+	}
+}
+
+func TestTuple8(t *testing.T) {
+	b := bytes.One()
+	p := Tuple8(b, b, b, b, b, b, b, runes.One())
+
+	s, out, err := p.ParseBytes([]byte("abcdefg😀!"))
+
+	require.NoError(t, err)
+	assert.Equal(t, parser.Tuple8[byte, byte, byte, byte, byte, byte, byte, rune]{
+		First: 'a', Second: 'b', Third: 'c', Fourth: 'd', Fifth: 'e', Sixth: 'f', Seventh: 'g', Eighth: '😀',
+	}, s)
+	assert.Equal(t, []byte("!"), out)
+}
@@ -16,6 +16,10 @@ func (o *successParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
 	return o.value, in, nil
 }
 
+func (o *successParser[R, T]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarEmpty)
+}
+
 // Success always succeeds. It returns the provided value without consuming any input.
 func Success[R parser.Reader, T any](value T) parser.Parser[R, T] {
 	return &successParser[R, T]{value: value}
@@ -0,0 +1,73 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	// ContextError names the grammar rule a parser was in when it failed. The wrapped error is kept, so errors.Is,
+	// AsParseError and IsFatal see through it. Nested contexts are printed outermost first:
+	//
+	//	in object > in field value: expected ',' at offset 12
+	ContextError struct {
+		Label string
+		Err   error
+	}
+)
+
+func (e ContextError) Error() string {
+	labels := []string{"in " + e.Label}
+	err := e.Err
+	for {
+		c, ok := innerContext(err)
+		if !ok {
+			break
+		}
+		labels = append(labels, "in "+c.Label)
+		err = c.Err
+	}
+	return fmt.Sprintf("%s: %s", strings.Join(labels, " > "), err)
+}
+
+func (e ContextError) Unwrap() error {
+	return e.Err
+}
+
+func (e ContextError) IsFatal() bool {
+	return IsFatal(e.Err)
+}
+
+// WithContext wraps err with the label of the rule it occurred in. A nil error is returned unchanged.
+func WithContext(label string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return ContextError{Label: label, Err: err}
+}
+
+// Context returns the labels err was wrapped with, outermost first.
+func Context(err error) []string {
+	var labels []string
+	for {
+		var c ContextError
+		if !As(err, &c) {
+			return labels
+		}
+		labels = append(labels, c.Label)
+		err = c.Err
+	}
+}
+
+// innerContext finds a ContextError directly wrapped by err. Only fatal errors are looked through, as other wrappers
+// add to the message and would be lost if the inner context was printed in their place.
+func innerContext(err error) (ContextError, bool) {
+	switch e := err.(type) {
+	case ContextError:
+		return e, true
+	case fatalError:
+		return innerContext(e.error)
+	default:
+		return ContextError{}, false
+	}
+}
@@ -75,6 +75,16 @@ func (e wrapErr) IsFatal() bool {
 	return IsFatal(e.error) || IsFatal(e.cause)
 }
 
+// Is reports whether any error in err's chain matches target. It is a shorthand for the standard library errors.Is.
+func Is(err, target error) bool {
+	return errors.Is(err, target)
+}
+
+// As finds the first error in err's chain that matches target. It is a shorthand for the standard library errors.As.
+func As(err error, target any) bool {
+	return errors.As(err, target)
+}
+
 func NewFatalError(err error) error {
 	return fatalError{err}
 }
@@ -0,0 +1,83 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+)
+
+const (
+	ErrIncomplete = Error("incomplete input") // parser needs more input to decide if it matches
+)
+
+type (
+	// eofError is io.EOF, annotated with the number of extra bytes the parser needed.
+	eofError struct {
+		needed int
+	}
+
+	// IncompleteError reports that the parser ran out of input, in streaming mode, where more input may arrive. Needed
+	// is the number of extra bytes required, or 0 if it isn't known. It is fatal, so alternatives aren't tried until
+	// the rest of the input is available.
+	IncompleteError struct {
+		Needed int
+		Err    error
+	}
+)
+
+func (e eofError) Error() string {
+	return io.EOF.Error()
+}
+
+func (e eofError) Is(err error) bool {
+	return err == io.EOF
+}
+
+func (e IncompleteError) Error() string {
+	msg := ErrIncomplete.Error()
+	if e.Needed > 0 {
+		msg = fmt.Sprintf("%s, %d more bytes needed", msg, e.Needed)
+	}
+	if e.Err == nil {
+		return msg
+	}
+	return fmt.Sprintf("%s: %s", msg, e.Err)
+}
+
+func (e IncompleteError) Unwrap() error {
+	return e.Err
+}
+
+func (e IncompleteError) Is(err error) bool {
+	return err == ErrIncomplete
+}
+
+func (e IncompleteError) IsFatal() bool {
+	return true
+}
+
+// NewEOFError returns an error matching io.EOF, which records how many more bytes the parser needed. If needed isn't
+// known it should be 0.
+func NewEOFError(needed int) error {
+	return eofError{needed: needed}
+}
+
+// NewIncompleteError converts an end of input error into an IncompleteError. The number of bytes needed is taken from
+// err if it was created with NewEOFError.
+func NewIncompleteError(err error) error {
+	needed, _ := Needed(err)
+	return IncompleteError{Needed: needed, Err: err}
+}
+
+// Needed returns the number of extra bytes a parser needed when it failed with err. The result is false if err isn't
+// an end of input error, and 0 if the number isn't known.
+func Needed(err error) (int, bool) {
+	var incomplete IncompleteError
+	if As(err, &incomplete) {
+		return incomplete.Needed, true
+	}
+	var eof eofError
+	if As(err, &eof) {
+		return eof.needed, true
+	}
+	return 0, Is(err, io.EOF)
+}
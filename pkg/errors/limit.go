@@ -0,0 +1,17 @@
+package errors
+
+const (
+	ErrMaxDepth       = Error("maximum depth")                // too many nested parser.Pointer calls
+	ErrMaxBytes       = Error("maximum bytes")                // too much input consumed
+	ErrMaxRepetitions = Error("maximum repetitions")          // too many repetitions in one call of a combinator
+	ErrNoProgress     = Error("repetition consumed no input") // a repeated parser succeeded without consuming input
+)
+
+// ErrLimitExceeded is returned when a parse exceeds a resource limit. It is fatal, so the parse stops instead of
+// backtracking. The error is wrapped with the limit which was exceeded, such as ErrMaxDepth.
+var ErrLimitExceeded = NewFatalError(Error("limit exceeded"))
+
+// NewLimitError returns ErrLimitExceeded, wrapping the limit which was exceeded.
+func NewLimitError(limit error) error {
+	return wrapErr{error: ErrLimitExceeded, cause: limit}
+}
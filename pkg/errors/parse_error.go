@@ -0,0 +1,226 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type (
+	// ParseError records where in the input a parser failed.
+	//
+	// Offset is interpreted the same way as io.Seeker offsets:
+	//   - io.SeekStart: Offset is the number of bytes from the start of the input. This is used by Parse, which can
+	//     find its position with Seek.
+	//   - io.SeekEnd: Offset is the negated number of bytes remaining in the input. This is used by ParseBytes, which
+	//     only knows the length of the remaining slice.
+	//
+	// Expected lists descriptions of the input the failing parsers were looking for at Offset.
+	ParseError struct {
+		Err      error
+		Offset   int64
+		Whence   int
+		Expected []string
+	}
+)
+
+func (e ParseError) Error() string {
+	if e.Whence == io.SeekEnd {
+		if e.Offset == 0 {
+			return e.message("end of input")
+		}
+		return e.message(fmt.Sprintf("%d bytes before end of input", -e.Offset))
+	}
+	return e.message(fmt.Sprintf("offset %d", e.Offset))
+}
+
+// ErrorAt returns the error message with the position given as line:column within the input. The input must be the
+// same data that was passed to the parser.
+func (e ParseError) ErrorAt(input []byte) string {
+	_, line, column := e.Position(input)
+	return e.message(fmt.Sprintf("%d:%d", line, column))
+}
+
+func (e ParseError) message(position string) string {
+	return fmt.Sprintf("%s at %s", e.description(), position)
+}
+
+// description is the error message without its position.
+func (e ParseError) description() string {
+	if len(e.Expected) == 0 {
+		return e.Err.Error()
+	}
+	if errors.Is(e.Err, io.EOF) {
+		return fmt.Sprintf("unexpected end of input, expected %s", joinExpected(e.Expected))
+	}
+	if errors.Is(e.Err, ErrNotMatched) {
+		return fmt.Sprintf("expected %s", joinExpected(e.Expected))
+	}
+	return fmt.Sprintf("%s, expected %s", e.Err, joinExpected(e.Expected))
+}
+
+func (e ParseError) Unwrap() error {
+	return e.Err
+}
+
+func (e ParseError) IsFatal() bool {
+	return IsFatal(e.Err)
+}
+
+// StartOffset returns the number of bytes from the start of an input of length size to where the error occurred.
+func (e ParseError) StartOffset(size int64) int64 {
+	if e.Whence == io.SeekEnd {
+		return size + e.Offset
+	}
+	return e.Offset
+}
+
+// Position returns the offset, line and column of the error within the input. The input must be the same data that
+// was passed to the parser.
+func (e ParseError) Position(input []byte) (offset int64, line, column int) {
+	offset = e.StartOffset(int64(len(input)))
+	line, column = LineColumn(input, offset)
+	return
+}
+
+// NewParseError returns err annotated with the offset it occurred at. If err is already a ParseError it is returned
+// unchanged, so the position of the original failure is kept.
+func NewParseError(err error, offset int64, whence int) error {
+	return NewExpectedError(err, offset, whence, nil)
+}
+
+// NewExpectedError returns err annotated with the offset it occurred at and a description of the expected input. If err
+// is already a ParseError it is returned unchanged.
+func NewExpectedError(err error, offset int64, whence int, expected []string) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := AsParseError(err); ok {
+		return err
+	}
+	return ParseError{Err: err, Offset: offset, Whence: whence, Expected: expected}
+}
+
+// Furthest returns whichever error occurred furthest into the input. If both occurred at the same position the
+// result combines the expected values of both, which is how alternative parsers report every option they tried. The
+// cause is taken from the first error, unless only the second ran out of input.
+// Errors without a position are only returned if the other error is nil or also has no position.
+func Furthest(a, b error) error {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	pa, okA := AsParseError(a)
+	pb, okB := AsParseError(b)
+	switch {
+	case !okB:
+		return a
+	case !okA:
+		return b
+	case pa.Whence != pb.Whence || pa.Offset < pb.Offset:
+		return b
+	case pa.Offset > pb.Offset:
+		return a
+	}
+
+	pa.Expected = mergeExpected(pa.Expected, pb.Expected)
+	if !errors.Is(pa.Err, io.EOF) && errors.Is(pb.Err, io.EOF) {
+		// Running out of input takes priority, so it can be reported as incomplete in streaming mode.
+		pa.Err = pb.Err
+	}
+	return pa
+}
+
+// NotMatched converts err into an ErrNotMatched error. Other causes, such as io.EOF, are wrapped so errors.Is matches
+// both. The position and expected values of a ParseError are kept.
+func NotMatched(err error) error {
+	if err == nil {
+		return ErrNotMatched
+	}
+	if errors.Is(err, ErrNotMatched) {
+		return err
+	}
+	if e, ok := AsParseError(err); ok {
+		e.Err = ErrNotMatched.Wrap(e.Err)
+		return e
+	}
+	return ErrNotMatched.Wrap(err)
+}
+
+// Quote returns a description of a value suitable for an expected message. Bytes and runes are quoted as characters,
+// strings and byte slices as strings.
+func Quote(v any) string {
+	switch v := v.(type) {
+	case byte:
+		if v < utf8.RuneSelf {
+			return strconv.QuoteRuneToASCII(rune(v))
+		}
+		return fmt.Sprintf("0x%02x", v)
+	case rune:
+		return strconv.QuoteRune(v)
+	case string:
+		return strconv.Quote(v)
+	case []byte:
+		return strconv.Quote(string(v))
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// AsParseError finds the first ParseError in the chain of err.
+func AsParseError(err error) (ParseError, bool) {
+	var e ParseError
+	ok := errors.As(err, &e)
+	return e, ok
+}
+
+// LineColumn converts an offset into a 1 based line and column. Lines are terminated by '\n', so both `\n` and `\r\n`
+// line endings are supported. Columns are counted in runes.
+func LineColumn(input []byte, offset int64) (line, column int) {
+	if offset > int64(len(input)) {
+		offset = int64(len(input))
+	}
+	line, column = 1, 1
+	for i := 0; i < int(offset); {
+		if input[i] == '\n' {
+			line++
+			column = 1
+			i++
+			continue
+		}
+		if input[i] == '\r' && i+1 < len(input) && input[i+1] == '\n' {
+			i++
+			continue
+		}
+		_, size := utf8.DecodeRune(input[i:])
+		i += size
+		column++
+	}
+	return
+}
+
+func mergeExpected(a, b []string) []string {
+	result := make([]string, 0, len(a)+len(b))
+	seen := make(map[string]bool, len(a)+len(b))
+	for _, expected := range [][]string{a, b} {
+		for _, e := range expected {
+			if !seen[e] {
+				seen[e] = true
+				result = append(result, e)
+			}
+		}
+	}
+	return result
+}
+
+func joinExpected(expected []string) string {
+	if len(expected) == 1 {
+		return expected[0]
+	}
+	return strings.Join(expected[:len(expected)-1], ", ") + " or " + expected[len(expected)-1]
+}
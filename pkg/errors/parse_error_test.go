@@ -0,0 +1,207 @@
+package errors_test
+
+import (
+	"github.com/roblovelock/gobble/pkg/combinator/branch"
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLineColumn(t *testing.T) {
+	type args struct {
+		input  string
+		offset int64
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantLine   int
+		wantColumn int
+	}{
+		{
+			name:       "empty input => 1:1",
+			args:       args{input: "", offset: 0},
+			wantLine:   1,
+			wantColumn: 1,
+		},
+		{
+			name:       "first line => 1:3",
+			args:       args{input: "abc", offset: 2},
+			wantLine:   1,
+			wantColumn: 3,
+		},
+		{
+			name:       "LF => 2:2",
+			args:       args{input: "ab\ncd", offset: 4},
+			wantLine:   2,
+			wantColumn: 2,
+		},
+		{
+			name:       "CRLF => 2:2",
+			args:       args{input: "ab\r\ncd", offset: 5},
+			wantLine:   2,
+			wantColumn: 2,
+		},
+		{
+			name:       "multi-byte runes => 1:3",
+			args:       args{input: "😀😀a", offset: 8},
+			wantLine:   1,
+			wantColumn: 3,
+		},
+		{
+			name:       "offset past end => end",
+			args:       args{input: "a\nb", offset: 10},
+			wantLine:   2,
+			wantColumn: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, column := errors.LineColumn([]byte(tt.args.input), tt.args.offset)
+
+			assert.Equal(t, tt.wantLine, line)
+			assert.Equal(t, tt.wantColumn, column)
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantErr    error
+		wantFatal  bool
+		wantOffset int64
+		wantLine   int
+		wantColumn int
+	}{
+		{
+			name:       "mismatch => position of mismatch",
+			input:      "a\nbd",
+			wantErr:    errors.ErrNotMatched,
+			wantOffset: 3,
+			wantLine:   2,
+			wantColumn: 2,
+		},
+		{
+			name:       "short input => position of EOF",
+			input:      "a\nb",
+			wantErr:    io.EOF,
+			wantOffset: 3,
+			wantLine:   2,
+			wantColumn: 2,
+		},
+		{
+			name:       "cut => fatal with position",
+			input:      "a\nc",
+			wantErr:    errors.ErrNotMatched,
+			wantFatal:  true,
+			wantOffset: 2,
+			wantLine:   2,
+			wantColumn: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := sequence.Preceded(
+				bytes.Tag([]byte("a\n")),
+				modifier.Cut(sequence.Preceded(bytes.Byte('b'), bytes.Byte('c'))),
+			)
+			if !tt.wantFatal {
+				p = sequence.Preceded(
+					bytes.Tag([]byte("a\nb")),
+					bytes.Byte('c'),
+				)
+			}
+
+			_, err := p.Parse(strings.NewReader(tt.input))
+			assertParseError(t, err, tt.input, tt.wantErr, tt.wantFatal, tt.wantOffset, tt.wantLine, tt.wantColumn)
+
+			_, _, err = p.ParseBytes([]byte(tt.input))
+			assertParseError(t, err, tt.input, tt.wantErr, tt.wantFatal, tt.wantOffset, tt.wantLine, tt.wantColumn)
+		})
+	}
+}
+
+func assertParseError(
+	t *testing.T, err error, input string, wantErr error, wantFatal bool, wantOffset int64, wantLine, wantColumn int,
+) {
+	assert.ErrorIs(t, err, wantErr)
+	assert.Equal(t, wantFatal, errors.IsFatal(err))
+
+	e, ok := errors.AsParseError(err)
+	require.True(t, ok)
+
+	offset, line, column := e.Position([]byte(input))
+	assert.Equal(t, wantOffset, offset)
+	assert.Equal(t, wantLine, line)
+	assert.Equal(t, wantColumn, column)
+}
+
+func TestParseError_ErrorAt(t *testing.T) {
+	input := "a\nx"
+	p := sequence.Preceded(
+		bytes.Tag([]byte("a\n")),
+		branch.Alt(bytes.Byte('b'), bytes.Byte('c')),
+	)
+
+	_, err := p.Parse(strings.NewReader(input))
+	e, ok := errors.AsParseError(err)
+	require.True(t, ok)
+	assert.Equal(t, "expected 'b' or 'c' at 2:1", e.ErrorAt([]byte(input)))
+
+	_, _, err = p.ParseBytes([]byte(input))
+	e, ok = errors.AsParseError(err)
+	require.True(t, ok)
+	assert.Equal(t, "expected 'b' or 'c' at 2:1", e.ErrorAt([]byte(input)))
+}
+
+func TestFurthest(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       error
+		b       error
+		wantErr error
+	}{
+		{
+			name:    "nil first => second",
+			b:       errors.NewExpectedError(errors.ErrNotMatched, 1, io.SeekStart, []string{"'a'"}),
+			wantErr: errors.NewExpectedError(errors.ErrNotMatched, 1, io.SeekStart, []string{"'a'"}),
+		},
+		{
+			name:    "further second => second",
+			a:       errors.NewExpectedError(errors.ErrNotMatched, 1, io.SeekStart, []string{"'a'"}),
+			b:       errors.NewExpectedError(errors.ErrNotMatched, 2, io.SeekStart, []string{"'b'"}),
+			wantErr: errors.NewExpectedError(errors.ErrNotMatched, 2, io.SeekStart, []string{"'b'"}),
+		},
+		{
+			name:    "further first => first",
+			a:       errors.NewExpectedError(errors.ErrNotMatched, -1, io.SeekEnd, []string{"'a'"}),
+			b:       errors.NewExpectedError(errors.ErrNotMatched, -2, io.SeekEnd, []string{"'b'"}),
+			wantErr: errors.NewExpectedError(errors.ErrNotMatched, -1, io.SeekEnd, []string{"'a'"}),
+		},
+		{
+			name:    "same position => merged",
+			a:       errors.NewExpectedError(errors.ErrNotMatched, 1, io.SeekStart, []string{"'a'", "'b'"}),
+			b:       errors.NewExpectedError(errors.ErrNotMatched, 1, io.SeekStart, []string{"'b'", "'c'"}),
+			wantErr: errors.NewExpectedError(errors.ErrNotMatched, 1, io.SeekStart, []string{"'a'", "'b'", "'c'"}),
+		},
+		{
+			name:    "no position => positioned error",
+			a:       errors.ErrNotMatched,
+			b:       errors.NewExpectedError(io.EOF, 0, io.SeekStart, []string{"'b'"}),
+			wantErr: errors.NewExpectedError(io.EOF, 0, io.SeekStart, []string{"'b'"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, errors.Furthest(tt.a, tt.b))
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Render formats err as a diagnostic showing the line of input it occurred on, with a caret under the failing column
+// and the chain of labels the parser was in. The input must be the same data that was passed to the parser. Errors
+// without a position are rendered as a single line.
+//
+//	error: expected ',' or '}'
+//	 --> 2:9
+//	  |
+//	2 |   "a": 1 "b": 2
+//	  |         ^
+//	  = in object > in field value
+func Render(input []byte, err error) string {
+	if err == nil {
+		return ""
+	}
+
+	e, ok := AsParseError(err)
+	if !ok {
+		return fmt.Sprintf("error: %s\n", err)
+	}
+
+	offset, line, column := e.Position(input)
+	number := strconv.Itoa(line)
+	gutter := strings.Repeat(" ", len(number))
+	text := lineAt(input, offset)
+
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "error: %s\n", e.description())
+	fmt.Fprintf(sb, "%s--> %d:%d\n", gutter, line, column)
+	fmt.Fprintf(sb, "%s |\n", gutter)
+	fmt.Fprintf(sb, "%s | %s\n", number, text)
+	fmt.Fprintf(sb, "%s | %s^\n", gutter, caretPadding(text, column))
+	if labels := Context(err); len(labels) > 0 {
+		fmt.Fprintf(sb, "%s = in %s\n", gutter, strings.Join(labels, " > in "))
+	}
+	return sb.String()
+}
+
+// lineAt returns the line containing offset, without its line ending.
+func lineAt(input []byte, offset int64) []byte {
+	if offset > int64(len(input)) {
+		offset = int64(len(input))
+	}
+	start := bytes.LastIndexByte(input[:offset], '\n') + 1
+	end := len(input)
+	if i := bytes.IndexByte(input[start:], '\n'); i >= 0 {
+		end = start + i
+	}
+	return bytes.TrimSuffix(input[start:end], []byte{'\r'})
+}
+
+// caretPadding returns the whitespace needed to place a caret under the column. Tabs in the line are kept so the caret
+// lines up however they are displayed.
+func caretPadding(line []byte, column int) string {
+	sb := &strings.Builder{}
+	for i := 1; i < column; i++ {
+		r, size := utf8.DecodeRune(line)
+		if r == '\t' {
+			sb.WriteByte('\t')
+		} else {
+			sb.WriteByte(' ')
+		}
+		line = line[size:]
+	}
+	return sb.String()
+}
@@ -0,0 +1,32 @@
+package errors_test
+
+import (
+	"fmt"
+	"github.com/roblovelock/gobble/pkg/combinator/branch"
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser/ascii"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+)
+
+func ExampleRender() {
+	value := modifier.Label(
+		sequence.Terminated(ascii.Digit(), branch.Alt(bytes.Byte(','), bytes.Byte('}'))),
+		"field value",
+	)
+	object := modifier.Label(sequence.Preceded(bytes.Tag([]byte("{\n  \"a\": ")), value), "object")
+
+	input := []byte("{\n  \"a\": 1 \"b\": 2\n}")
+	_, _, err := object.ParseBytes(input)
+
+	fmt.Print(errors.Render(input, err))
+
+	// Output:
+	// error: expected ',' or '}'
+	//  --> 2:9
+	//   |
+	// 2 |   "a": 1 "b": 2
+	//   |         ^
+	//   = in object > in field value
+}
@@ -0,0 +1,136 @@
+package errors_test
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	type args struct {
+		input string
+		err   error
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "nil error => empty",
+			args: args{input: "abc"},
+			want: "",
+		},
+		{
+			name: "no position => single line",
+			args: args{input: "abc", err: errors.ErrNotMatched},
+			want: "error: not matched\n",
+		},
+		{
+			name: "first line => caret under column",
+			args: args{
+				input: "abc",
+				err:   errors.NewExpectedError(errors.ErrNotMatched, 1, io.SeekStart, []string{"'x'"}),
+			},
+			want: "error: expected 'x'\n" +
+				" --> 1:2\n" +
+				"  |\n" +
+				"1 | abc\n" +
+				"  |  ^\n",
+		},
+		{
+			name: "LF => failing line only",
+			args: args{
+				input: "ab\ncd\nef",
+				err:   errors.NewExpectedError(errors.ErrNotMatched, 4, io.SeekStart, []string{"'x'", "'y'"}),
+			},
+			want: "error: expected 'x' or 'y'\n" +
+				" --> 2:2\n" +
+				"  |\n" +
+				"2 | cd\n" +
+				"  |  ^\n",
+		},
+		{
+			name: "CRLF => line ending removed",
+			args: args{
+				input: "ab\r\ncd\r\nef",
+				err:   errors.NewExpectedError(errors.ErrNotMatched, -5, io.SeekEnd, []string{"'x'"}),
+			},
+			want: "error: expected 'x'\n" +
+				" --> 2:2\n" +
+				"  |\n" +
+				"2 | cd\n" +
+				"  |  ^\n",
+		},
+		{
+			name: "multi-byte runes => caret counts runes",
+			args: args{
+				input: "😀é!",
+				err:   errors.NewExpectedError(errors.ErrNotMatched, 6, io.SeekStart, []string{"'x'"}),
+			},
+			want: "error: expected 'x'\n" +
+				" --> 1:3\n" +
+				"  |\n" +
+				"1 | 😀é!\n" +
+				"  |   ^\n",
+		},
+		{
+			name: "tabs => kept in padding",
+			args: args{
+				input: "\ta",
+				err:   errors.NewExpectedError(errors.ErrNotMatched, 1, io.SeekStart, []string{"'x'"}),
+			},
+			want: "error: expected 'x'\n" +
+				" --> 1:2\n" +
+				"  |\n" +
+				"1 | \ta\n" +
+				"  | \t^\n",
+		},
+		{
+			name: "end of input => caret after last character",
+			args: args{
+				input: "ab\n",
+				err:   errors.NewExpectedError(io.EOF, 0, io.SeekEnd, []string{"'x'"}),
+			},
+			want: "error: unexpected end of input, expected 'x'\n" +
+				" --> 2:1\n" +
+				"  |\n" +
+				"2 | \n" +
+				"  | ^\n",
+		},
+		{
+			name: "labels => context chain",
+			args: args{
+				input: "ab",
+				err: errors.WithContext("object", errors.NewFatalError(errors.WithContext(
+					"field value",
+					errors.NewExpectedError(errors.ErrNotMatched, 2, io.SeekStart, []string{"','"}),
+				))),
+			},
+			want: "error: expected ','\n" +
+				" --> 1:3\n" +
+				"  |\n" +
+				"1 | ab\n" +
+				"  |   ^\n" +
+				"  = in object > in field value\n",
+		},
+		{
+			name: "wide line number => gutter aligned",
+			args: args{
+				input: "\n\n\n\n\n\n\n\n\nabc",
+				err:   errors.NewExpectedError(errors.ErrNotMatched, 10, io.SeekStart, []string{"'x'"}),
+			},
+			want: "error: expected 'x'\n" +
+				"  --> 10:2\n" +
+				"   |\n" +
+				"10 | abc\n" +
+				"   |  ^\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errors.Render([]byte(tt.args.input), tt.args.err))
+		})
+	}
+}
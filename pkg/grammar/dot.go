@@ -0,0 +1,99 @@
+package grammar
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"io"
+	"strings"
+)
+
+// dotWriter numbers the nodes of the graph as they are written.
+type dotWriter struct {
+	w     *bufio.Writer
+	nodes int
+}
+
+// WriteDOT writes the productions of the parser to w as a Graphviz digraph. Each production is a box, with edges to
+// the expression it matches. References to a production are dashed edges to its box, so recursion is a cycle in the
+// graph. The edges of a sequence are numbered in order.
+func WriteDOT(w io.Writer, p any) error {
+	d := &dotWriter{w: bufio.NewWriter(w)}
+	productions := Productions(p)
+
+	d.printf("digraph grammar {\n")
+	d.printf("\tnode [fontname=\"Helvetica\"];\n")
+	for _, prod := range productions {
+		d.printf("\t%s [label=%s, shape=box, style=bold];\n", dotID(prod.Name), dotQuote(prod.Name))
+	}
+	for _, prod := range productions {
+		d.edge(dotID(prod.Name), prod.Node, "")
+	}
+	d.printf("}\n")
+	return d.w.Flush()
+}
+
+// edge writes an edge from the graph node called from to the expression, and the nodes of the expression.
+func (d *dotWriter) edge(from string, n *Node, label string) {
+	var attrs []string
+	if label != "" {
+		attrs = append(attrs, "label="+dotQuote(label))
+	}
+	if n.Kind == parser.GrammarReference {
+		attrs = append(attrs, "style=dashed")
+		d.printf("\t%s -> %s%s;\n", from, dotID(n.Name), dotAttrs(attrs))
+		return
+	}
+
+	d.nodes++
+	id := fmt.Sprintf("n%d", d.nodes)
+	d.printf("\t%s -> %s%s;\n", from, id, dotAttrs(attrs))
+
+	switch n.Kind {
+	case parser.GrammarTerminal:
+		d.printf("\t%s [label=%s, shape=ellipse];\n", id, dotQuote(n.Name))
+	case parser.GrammarOpaque:
+		d.printf("\t%s [label=%s, shape=ellipse, style=dashed];\n", id, dotQuote(n.Name))
+	case parser.GrammarEmpty:
+		d.printf("\t%s [label=\"ε\", shape=plaintext];\n", id)
+	case parser.GrammarSequence:
+		d.printf("\t%s [label=\"sequence\", shape=point];\n", id)
+		for i, c := range n.Children {
+			d.edge(id, c, fmt.Sprint(i+1))
+		}
+		return
+	case parser.GrammarChoice:
+		d.printf("\t%s [label=\"|\", shape=diamond];\n", id)
+	case parser.GrammarRepeat:
+		d.printf("\t%s [label=%s, shape=circle];\n", id, dotQuote(repeatSuffix(n.Min, n.Max)))
+	case parser.GrammarNot:
+		d.printf("\t%s [label=\"!\", shape=circle];\n", id)
+	case parser.GrammarPeek:
+		d.printf("\t%s [label=\"&\", shape=circle];\n", id)
+	}
+	for _, c := range n.Children {
+		d.edge(id, c, "")
+	}
+}
+
+func (d *dotWriter) printf(format string, args ...any) {
+	_, _ = fmt.Fprintf(d.w, format, args...)
+}
+
+func dotAttrs(attrs []string) string {
+	if len(attrs) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(attrs, ", ") + "]"
+}
+
+// dotID is the graph node of the production called name. Production names are prefixed so they can't clash with
+// the numbered nodes of expressions.
+func dotID(name string) string {
+	return dotQuote("rule:" + name)
+}
+
+// dotQuote quotes the string as a DOT identifier.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
@@ -0,0 +1,120 @@
+package grammar
+
+import (
+	"fmt"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"io"
+	"strings"
+)
+
+// WriteEBNF writes the productions of the parser to w in the EBNF notation of the W3C XML specification, such as
+// `list ::= '[' (value (',' value)*)? ']'`. Repetitions with other bounds are written as a{min,max}, and lookahead as
+// !a and &a. Terminals which aren't quoted, such as digit, and parsers which can't describe themselves are written
+// as <digit>.
+func WriteEBNF(w io.Writer, p any) error {
+	productions := Productions(p)
+	width := 0
+	for _, prod := range productions {
+		if len(prod.Name) > width {
+			width = len(prod.Name)
+		}
+	}
+	for _, prod := range productions {
+		if _, err := fmt.Fprintf(w, "%-*s ::= %s\n", width, prod.Name, EBNF(prod.Node)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EBNF returns the expression of the node in the notation of WriteEBNF.
+func EBNF(n *Node) string {
+	var b strings.Builder
+	writeEBNF(&b, n)
+	return b.String()
+}
+
+func writeEBNF(b *strings.Builder, n *Node) {
+	switch n.Kind {
+	case parser.GrammarTerminal:
+		b.WriteString(terminal(n.Name))
+	case parser.GrammarOpaque:
+		b.WriteString("<" + n.Name + ">")
+	case parser.GrammarReference:
+		b.WriteString(n.Name)
+	case parser.GrammarEmpty:
+		b.WriteString("()")
+	case parser.GrammarSequence:
+		for i, c := range n.Children {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			writeEBNFGroup(b, c, c.Kind == parser.GrammarChoice)
+		}
+	case parser.GrammarChoice:
+		if len(n.Children) == 0 {
+			b.WriteString("<nothing>")
+		}
+		for i, c := range n.Children {
+			if i > 0 {
+				b.WriteString(" | ")
+			}
+			writeEBNF(b, c)
+		}
+	case parser.GrammarRepeat:
+		writeEBNFGroup(b, n.Children[0], !atomic(n.Children[0]))
+		b.WriteString(repeatSuffix(n.Min, n.Max))
+	case parser.GrammarNot, parser.GrammarPeek:
+		if n.Kind == parser.GrammarNot {
+			b.WriteByte('!')
+		} else {
+			b.WriteByte('&')
+		}
+		writeEBNFGroup(b, n.Children[0], !atomic(n.Children[0]))
+	}
+}
+
+func writeEBNFGroup(b *strings.Builder, n *Node, group bool) {
+	if group {
+		b.WriteByte('(')
+	}
+	writeEBNF(b, n)
+	if group {
+		b.WriteByte(')')
+	}
+}
+
+// atomic is whether the node is written as a single term, so it doesn't need brackets.
+func atomic(n *Node) bool {
+	switch n.Kind {
+	case parser.GrammarTerminal, parser.GrammarOpaque, parser.GrammarReference, parser.GrammarEmpty:
+		return true
+	}
+	return false
+}
+
+// terminal is the terminal as written in EBNF. Quoted strings, character classes and #xNN bytes are written as they
+// are, and other names in angle brackets.
+func terminal(name string) string {
+	if name != "" && (strings.IndexByte(`'"[`, name[0]) >= 0 || strings.HasPrefix(name, "#x")) {
+		return name
+	}
+	return "<" + name + ">"
+}
+
+// repeatSuffix is the operator for repeating from min to max times. Max is -1 if it is unbounded.
+func repeatSuffix(min, max int) string {
+	switch {
+	case min == 0 && max == 1:
+		return "?"
+	case min == 0 && max < 0:
+		return "*"
+	case min == 1 && max < 0:
+		return "+"
+	case max < 0:
+		return fmt.Sprintf("{%d,}", min)
+	case min == max:
+		return fmt.Sprintf("{%d}", min)
+	}
+	return fmt.Sprintf("{%d,%d}", min, max)
+}
@@ -0,0 +1,216 @@
+// Package grammar documents the grammar of a parser, from the structure described by parser.DescribeOf. The grammar
+// can be written as EBNF, as a Graphviz graph or as railroad diagrams.
+package grammar
+
+import (
+	"fmt"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"reflect"
+)
+
+// rootName is the name of the first production, unless the parser is a rule.
+const rootName = "grammar"
+
+type (
+	// Node is an expression of a production. Children are sequences, choices and repetitions of other nodes, and
+	// references to productions are GrammarReference nodes.
+	Node struct {
+		Kind     parser.GrammarKind
+		Name     string
+		Min      int
+		Max      int
+		Children []*Node
+	}
+
+	// Production is a named rule of a grammar.
+	Production struct {
+		Name string
+		Node *Node
+	}
+
+	walker struct {
+		recursive   map[any]bool
+		names       map[any]string
+		used        map[string]bool
+		productions []Production
+		generated   int
+	}
+)
+
+// Productions returns the productions of the grammar of the parser, starting with the parser itself. Each
+// parser.Rule is a production, as is each parser which refers to itself, such as through a parser.Pointer, so a
+// recursive grammar is described by references to the productions rather than expanded forever.
+func Productions(p any) []Production {
+	w := &walker{recursive: map[any]bool{}, names: map[any]string{}, used: map[string]bool{}}
+	w.findRecursion(p, map[any]bool{}, map[any]bool{})
+
+	name := rootName
+	for g := parser.DescribeOf(p); ; g = parser.DescribeOf(p) {
+		if g.Kind == parser.GrammarRule {
+			name = g.Name
+			break
+		}
+		// look through parsers which only wrap a rule
+		if g.Kind != parser.GrammarSequence || len(g.Children) != 1 {
+			break
+		}
+		p = g.Children[0]
+	}
+	w.define(p, name)
+	return w.productions
+}
+
+// Walk calls fn for each node of the production, parents before their children. Children aren't visited if fn
+// returns false.
+func Walk(n *Node, fn func(*Node) bool) {
+	if !fn(n) {
+		return
+	}
+	for _, c := range n.Children {
+		Walk(c, fn)
+	}
+}
+
+// findRecursion marks the parsers which are reached again while describing themselves.
+func (w *walker) findRecursion(p any, active, done map[any]bool) {
+	key, ok := identity(p)
+	if ok {
+		if active[key] {
+			w.recursive[key] = true
+			return
+		}
+		if done[key] {
+			return
+		}
+		active[key] = true
+		defer func() {
+			delete(active, key)
+			done[key] = true
+		}()
+	}
+	for _, c := range parser.DescribeOf(p).Children {
+		w.findRecursion(c, active, done)
+	}
+}
+
+// node describes the parser, referring to productions for rules and recursive parsers.
+func (w *walker) node(p any) *Node {
+	key, ok := identity(p)
+	if ok {
+		if name, ok := w.names[key]; ok {
+			return &Node{Kind: parser.GrammarReference, Name: name}
+		}
+	}
+
+	g := parser.DescribeOf(p)
+	switch {
+	case g.Kind == parser.GrammarRule:
+		return &Node{Kind: parser.GrammarReference, Name: w.define(p, g.Name)}
+	case ok && w.recursive[key]:
+		w.generated++
+		return &Node{Kind: parser.GrammarReference, Name: w.define(p, fmt.Sprintf("rule%d", w.generated))}
+	}
+	return w.expand(g)
+}
+
+// define adds a production for the parser, and returns its name. The name is made unique if another production
+// already has it.
+func (w *walker) define(p any, name string) string {
+	unique := name
+	for i := 2; w.used[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	w.used[unique] = true
+	if key, ok := identity(p); ok {
+		w.names[key] = unique
+	}
+
+	i := len(w.productions)
+	w.productions = append(w.productions, Production{Name: unique})
+	g := parser.DescribeOf(p)
+	if g.Kind == parser.GrammarRule {
+		g = parser.NewGrammar(parser.GrammarSequence, g.Children...)
+	}
+	w.productions[i].Node = w.expand(g)
+	return unique
+}
+
+func (w *walker) expand(g parser.Grammar) *Node {
+	n := &Node{Kind: g.Kind, Name: g.Name, Min: g.Min, Max: g.Max}
+	for _, c := range g.Children {
+		n.Children = append(n.Children, w.node(c))
+	}
+	return simplify(n)
+}
+
+// identity is the key of a parser which can be referred to more than once. Only pointers are used, as other values
+// may not be comparable, and Grammar values are described afresh each time.
+func identity(p any) (any, bool) {
+	if p == nil {
+		return nil, false
+	}
+	if reflect.TypeOf(p).Kind() != reflect.Pointer {
+		return nil, false
+	}
+	return p, true
+}
+
+// never is a choice without alternatives, which can't match.
+func never(n *Node) bool {
+	return n.Kind == parser.GrammarChoice && len(n.Children) == 0
+}
+
+// simplify removes the structure of wrapping parsers, such as sequences of a single parser.
+func simplify(n *Node) *Node {
+	switch n.Kind {
+	case parser.GrammarSequence:
+		children := make([]*Node, 0, len(n.Children))
+		for _, c := range n.Children {
+			switch {
+			case never(c):
+				return c
+			case c.Kind == parser.GrammarEmpty:
+			case c.Kind == parser.GrammarSequence:
+				children = append(children, c.Children...)
+			default:
+				children = append(children, c)
+			}
+		}
+		switch len(children) {
+		case 0:
+			return &Node{Kind: parser.GrammarEmpty}
+		case 1:
+			return children[0]
+		}
+		n.Children = children
+
+	case parser.GrammarChoice:
+		children := make([]*Node, 0, len(n.Children))
+		for _, c := range n.Children {
+			if c.Kind == parser.GrammarChoice {
+				children = append(children, c.Children...)
+			} else {
+				children = append(children, c)
+			}
+		}
+		if len(children) == 1 {
+			return children[0]
+		}
+		n.Children = children
+
+	case parser.GrammarRepeat:
+		c := n.Children[0]
+		switch {
+		case never(c) && n.Min == 0, c.Kind == parser.GrammarEmpty:
+			return &Node{Kind: parser.GrammarEmpty}
+		case never(c):
+			return c
+		case n.Min == 1 && n.Max == 1:
+			return c
+		case n.Min == 0 && n.Max == 1 && c.Kind == parser.GrammarRepeat && c.Min <= 1 && c.Max == -1:
+			// (a*)? and (a+)? are a*
+			return &Node{Kind: parser.GrammarRepeat, Min: 0, Max: -1, Children: c.Children}
+		}
+	}
+	return n
+}
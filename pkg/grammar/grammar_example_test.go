@@ -0,0 +1,32 @@
+package grammar_test
+
+import (
+	"github.com/roblovelock/gobble/pkg/combinator/branch"
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/combinator/multi"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/grammar"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/ascii"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"os"
+)
+
+func ExampleWriteEBNF() {
+	var expr parser.Parser[parser.Reader, []byte]
+	number := parser.Rule("number", sequence.Recognize(sequence.Pair(
+		modifier.Optional(bytes.Byte('-')), ascii.Digit1(),
+	)))
+	group := parser.Rule("group", sequence.Delimited(bytes.Byte('('), parser.Pointer(&expr), bytes.Byte(')')))
+	term := branch.Alt(number, group)
+	expr = parser.Rule("expr", sequence.Recognize(sequence.Pair(
+		term, multi.Many0(sequence.Pair(bytes.OneOf('+', '-', '*', '/'), term)),
+	)))
+
+	_ = grammar.WriteEBNF(os.Stdout, expr)
+
+	// Output:
+	// expr   ::= (number | group) ([*+#x2D/] (number | group))*
+	// number ::= '-'? <digit>
+	// group  ::= '(' expr ')'
+}
@@ -0,0 +1,171 @@
+package grammar_test
+
+import (
+	"github.com/roblovelock/gobble/pkg/combinator/branch"
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/combinator/multi"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/grammar"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/ascii"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+// list = '[' (value (',' value)*)? ']'
+// value = digit | list
+func recursiveList() parser.Parser[parser.Reader, any] {
+	var value parser.Parser[parser.Reader, any]
+	list := parser.Rule("list", modifier.Map(
+		sequence.Delimited(bytes.Byte('['), multi.Separated0(parser.Pointer(&value), bytes.Byte(',')), bytes.Byte(']')),
+		func(vs []any) (any, error) { return vs, nil },
+	))
+	value = parser.Rule("value", branch.Alt(parser.Untyped(ascii.Digit1()), list))
+	return value
+}
+
+func TestWriteEBNF(t *testing.T) {
+	tests := []struct {
+		name   string
+		parser any
+		want   string
+	}{
+		{
+			name:   "tag => terminal",
+			parser: bytes.Tag([]byte("abc")),
+			want:   `grammar ::= "abc"` + "\n",
+		},
+		{
+			name:   "alt => choice",
+			parser: branch.Alt(bytes.Byte('a'), bytes.Byte('b')),
+			want:   "grammar ::= 'a' | 'b'\n",
+		},
+		{
+			name:   "sequence of choice => grouped",
+			parser: sequence.Pair(branch.Alt(bytes.Byte('a'), bytes.Byte('b')), bytes.Byte('c')),
+			want:   "grammar ::= ('a' | 'b') 'c'\n",
+		},
+		{
+			name:   "wrappers => described by their parser",
+			parser: modifier.Value(modifier.Cut(sequence.Preceded(bytes.Byte('a'), bytes.Byte('b'))), 1),
+			want:   "grammar ::= 'a' 'b'\n",
+		},
+		{
+			name: "repetitions",
+			parser: sequence.Tuple(
+				parser.Untyped(multi.Many0(bytes.Byte('a'))),
+				parser.Untyped(multi.Many1(bytes.Tag([]byte("bc")))),
+				parser.Untyped(modifier.Optional(bytes.Byte('d'))),
+				parser.Untyped(multi.TakeWhileMinMax(bytes.Byte('e'), 2, 3, func(byte) bool { return true })),
+			),
+			want: `grammar ::= 'a'* "bc"+ 'd'? 'e'{2,3}` + "\n",
+		},
+		{
+			name:   "separated => optional list",
+			parser: multi.Separated0(bytes.Byte('a'), bytes.Byte(',')),
+			want:   "grammar ::= ('a' (',' 'a')*)?\n",
+		},
+		{
+			name:   "lookahead",
+			parser: sequence.Pair(modifier.Not(bytes.Byte('a')), modifier.Peek(bytes.Byte('b'))),
+			want:   "grammar ::= !'a' &'b'\n",
+		},
+		{
+			name:   "byte set => character class",
+			parser: sequence.Pair(bytes.OneOf('0', '1', '2', '3', 'a', 'b', '-'), bytes.NotOneOf('"')),
+			want:   "grammar ::= [#x2D0-3ab] [^\"]\n",
+		},
+		{
+			name:   "not describable => opaque",
+			parser: bytes.TakeWhile1(ascii.IsDigit),
+			want:   "grammar ::= <takeWhileMinMax>\n",
+		},
+		{
+			name:   "rule => production",
+			parser: parser.Rule("ab", sequence.Pair(parser.Rule("a", bytes.Byte('a')), bytes.Byte('b'))),
+			want:   "ab ::= a 'b'\na  ::= 'a'\n",
+		},
+		{
+			name:   "wrapped rule => production",
+			parser: parser.Limited(parser.Rule("a", bytes.Byte('a')), parser.Limits{}),
+			want:   "a ::= 'a'\n",
+		},
+		{
+			name:   "recursive rules => references",
+			parser: recursiveList(),
+			want:   "value ::= <digit> | list\nlist  ::= '[' (value (',' value)*)? ']'\n",
+		},
+		{
+			name: "recursive pointer => generated rule",
+			parser: func() parser.Parser[parser.Reader, byte] {
+				var p parser.Parser[parser.Reader, byte]
+				p = branch.Alt(bytes.Byte('a'), sequence.Delimited(bytes.Byte('('), parser.Pointer(&p), bytes.Byte(')')))
+				return p
+			}(),
+			want: "grammar ::= 'a' | '(' grammar ')'\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			require.NoError(t, grammar.WriteEBNF(&out, tt.parser))
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestProductions_generatedNames(t *testing.T) {
+	var p parser.Parser[parser.Reader, byte]
+	inner := parser.Pointer(&p)
+	p = branch.Alt(bytes.Byte('a'), sequence.Preceded(bytes.Byte('-'), inner))
+	root := sequence.Terminated(inner, bytes.Byte('.'))
+
+	productions := grammar.Productions(root)
+	require.Len(t, productions, 2)
+	assert.Equal(t, "grammar", productions[0].Name)
+	assert.Equal(t, "rule1 '.'", grammar.EBNF(productions[0].Node))
+	assert.Equal(t, "rule1", productions[1].Name)
+	assert.Equal(t, "'a' | '-' rule1", grammar.EBNF(productions[1].Node))
+}
+
+func TestWriteDOT(t *testing.T) {
+	var out strings.Builder
+	require.NoError(t, grammar.WriteDOT(&out, recursiveList()))
+
+	dot := out.String()
+	assert.True(t, strings.HasPrefix(dot, "digraph grammar {\n"))
+	assert.True(t, strings.HasSuffix(dot, "}\n"))
+	for _, s := range []string{
+		`"rule:value" [label="value", shape=box, style=bold];`,
+		`"rule:list" [label="list", shape=box, style=bold];`,
+		`"rule:value" -> n1;`,
+		`n1 [label="|", shape=diamond];`,
+		`n1 -> "rule:list" [style=dashed];`,
+		`[label="'['", shape=ellipse];`,
+		`n6 -> "rule:value" [label="1", style=dashed];`,
+	} {
+		assert.Contains(t, dot, s)
+	}
+}
+
+func TestWriteRailroad(t *testing.T) {
+	var out strings.Builder
+	require.NoError(t, grammar.WriteRailroad(&out, recursiveList()))
+
+	page := out.String()
+	assert.True(t, strings.HasPrefix(page, "<!DOCTYPE html>\n"))
+	assert.Equal(t, 2, strings.Count(page, "<svg "))
+	assert.Equal(t, 2, strings.Count(page, "</svg>"))
+	for _, s := range []string{
+		`<h2 id="value">value</h2>`,
+		`<h2 id="list">list</h2>`,
+		`<a href="#list"><rect class="reference"`,
+		`<a href="#value"><rect class="reference"`,
+		`<text x="52" y="35">&#39;[&#39;</text>`,
+	} {
+		assert.Contains(t, page, s)
+	}
+}
@@ -0,0 +1,251 @@
+package grammar
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"html"
+	"io"
+	"unicode/utf8"
+)
+
+// The sizes of the parts of a railroad diagram, in pixels.
+const (
+	railCharWidth = 8  // the width of a character of the monospace font
+	railBoxHeight = 22 // the height of a terminal or reference
+	railPadding   = 10 // the space either side of the text in a box
+	railArc       = 10 // the radius of the curves of the track
+	railGap       = 10 // the space between the parts of a diagram
+	railLabel     = 14 // the height of a label
+	railMargin    = 20 // the space around a diagram
+)
+
+const railroadStyle = `svg { display: block; margin-bottom: 2em; }
+svg path { stroke: #333; stroke-width: 2; fill: none; }
+svg rect { stroke: #333; stroke-width: 2; fill: #ffc; }
+svg rect.reference { fill: #def; }
+svg rect.opaque, svg rect.lookahead { fill: none; stroke-dasharray: 4 3; }
+svg text { font: 13px monospace; text-anchor: middle; }
+svg text.label { font-size: 11px; text-anchor: start; fill: #666; }`
+
+type (
+	// railBox is a part of a railroad diagram. The track enters on the left and leaves on the right, up pixels below
+	// the top. draw draws it with the track entering at x, y.
+	railBox struct {
+		w, up, down int
+		draw        func(r *railroad, x, y int)
+	}
+
+	railroad struct {
+		w *bufio.Writer
+	}
+)
+
+// WriteRailroad writes the productions of the parser to w as an HTML page of railroad diagrams, one SVG image per
+// production. References to productions link to their diagrams.
+func WriteRailroad(w io.Writer, p any) error {
+	r := &railroad{w: bufio.NewWriter(w)}
+	r.printf("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>Grammar</title>\n")
+	r.printf("<style>\n%s\n</style>\n</head>\n<body>\n", railroadStyle)
+	for _, prod := range Productions(p) {
+		name := html.EscapeString(prod.Name)
+		r.printf("<h2 id=\"%s\">%s</h2>\n", name, name)
+		r.diagram(railLayout(prod.Node))
+	}
+	r.printf("</body>\n</html>\n")
+	return r.w.Flush()
+}
+
+// diagram draws the box as an SVG image, between the bars marking the start and end of the track.
+func (r *railroad) diagram(b railBox) {
+	width := b.w + 2*railMargin + 2*railGap
+	height := b.up + b.down + 2*railMargin
+	r.printf("<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n",
+		width, height, width, height)
+
+	x, y := railMargin, railMargin+b.up
+	r.path("M%d %dv%d", x, y-railArc, 2*railArc)
+	r.path("M%d %dh%d", x, y, railGap)
+	b.draw(r, x+railGap, y)
+	r.path("M%d %dh%d", x+railGap+b.w, y, railGap)
+	r.path("M%d %dv%d", x+2*railGap+b.w, y-railArc, 2*railArc)
+	r.printf("</svg>\n")
+}
+
+func (r *railroad) printf(format string, args ...any) {
+	_, _ = fmt.Fprintf(r.w, format, args...)
+}
+
+func (r *railroad) path(format string, args ...any) {
+	r.printf("<path d=\"%s\"/>\n", fmt.Sprintf(format, args...))
+}
+
+func railLayout(n *Node) railBox {
+	switch n.Kind {
+	case parser.GrammarTerminal:
+		return railText(n.Name, "terminal", railArc, "")
+	case parser.GrammarOpaque:
+		return railText(n.Name, "opaque", 0, "")
+	case parser.GrammarReference:
+		return railText(n.Name, "reference", 0, "#"+n.Name)
+	case parser.GrammarSequence:
+		boxes := make([]railBox, len(n.Children))
+		for i, c := range n.Children {
+			boxes[i] = railLayout(c)
+		}
+		return railSequence(boxes)
+	case parser.GrammarChoice:
+		boxes := make([]railBox, len(n.Children))
+		for i, c := range n.Children {
+			boxes[i] = railLayout(c)
+		}
+		return railChoice(boxes)
+	case parser.GrammarRepeat:
+		child := railLayout(n.Children[0])
+		if n.Max == 1 {
+			return railChoice([]railBox{railEmpty(), child})
+		}
+		label := ""
+		if n.Min > 1 || n.Max >= 0 {
+			label = repeatSuffix(n.Min, n.Max)
+		}
+		loop := railLoop(child, label)
+		if n.Min == 0 {
+			return railChoice([]railBox{railEmpty(), loop})
+		}
+		return loop
+	case parser.GrammarNot:
+		return railLookahead(railLayout(n.Children[0]), "not")
+	case parser.GrammarPeek:
+		return railLookahead(railLayout(n.Children[0]), "followed by")
+	}
+	return railEmpty()
+}
+
+func railEmpty() railBox {
+	return railBox{draw: func(*railroad, int, int) {}}
+}
+
+// railText is a box containing the text. Terminals have rounded corners, and references link to their production.
+func railText(text, class string, radius int, link string) railBox {
+	w := utf8.RuneCountInString(text)*railCharWidth + 2*railPadding
+	half := railBoxHeight / 2
+	return railBox{w: w, up: half, down: half, draw: func(r *railroad, x, y int) {
+		if link != "" {
+			r.printf("<a href=\"%s\">", html.EscapeString(link))
+		}
+		r.printf("<rect class=\"%s\" x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" rx=\"%d\"/>",
+			class, x, y-half, w, railBoxHeight, radius)
+		r.printf("<text x=\"%d\" y=\"%d\">%s</text>", x+w/2, y+4, html.EscapeString(text))
+		if link != "" {
+			r.printf("</a>")
+		}
+		r.printf("\n")
+	}}
+}
+
+// railSequence joins the boxes left to right.
+func railSequence(boxes []railBox) railBox {
+	s := railBox{}
+	for i, b := range boxes {
+		if i > 0 {
+			s.w += railGap
+		}
+		s.w += b.w
+		s.up = maxInt(s.up, b.up)
+		s.down = maxInt(s.down, b.down)
+	}
+	s.draw = func(r *railroad, x, y int) {
+		for i, b := range boxes {
+			if i > 0 {
+				r.path("M%d %dh%d", x, y, railGap)
+				x += railGap
+			}
+			b.draw(r, x, y)
+			x += b.w
+		}
+	}
+	return s
+}
+
+// railChoice stacks the boxes top to bottom. The track goes through the first box, and branches to the others.
+func railChoice(boxes []railBox) railBox {
+	inner := 0
+	for _, b := range boxes {
+		inner = maxInt(inner, b.w)
+	}
+	offsets := make([]int, len(boxes))
+	for i := 1; i < len(boxes); i++ {
+		offsets[i] = maxInt(offsets[i-1]+boxes[i-1].down+railGap+boxes[i].up, offsets[i-1]+2*railArc)
+	}
+
+	c := railBox{w: inner + 4*railArc}
+	if len(boxes) > 0 {
+		c.up = boxes[0].up
+		c.down = offsets[len(boxes)-1] + boxes[len(boxes)-1].down
+	}
+	c.draw = func(r *railroad, x, y int) {
+		for i, b := range boxes {
+			if i == 0 {
+				r.path("M%d %dh%d", x, y, 2*railArc)
+				b.draw(r, x+2*railArc, y)
+				r.path("M%d %dH%d", x+2*railArc+b.w, y, x+c.w)
+				continue
+			}
+			by := y + offsets[i]
+			r.path("M%d %da%d %d 0 0 1 %d %dV%da%d %d 0 0 0 %d %d",
+				x, y, railArc, railArc, railArc, railArc, by-railArc, railArc, railArc, railArc, railArc)
+			b.draw(r, x+2*railArc, by)
+			r.path("M%d %dH%da%d %d 0 0 0 %d %dV%da%d %d 0 0 1 %d %d",
+				x+2*railArc+b.w, by, x+c.w-2*railArc, railArc, railArc, railArc, -railArc, y+railArc,
+				railArc, railArc, railArc, -railArc)
+		}
+	}
+	return c
+}
+
+// railLoop is the box with a track looping back below it, so it can be repeated. The label describes the number of
+// repetitions.
+func railLoop(b railBox, label string) railBox {
+	loopY := maxInt(b.down+railGap, 2*railArc)
+	l := railBox{w: b.w + 4*railArc, up: b.up, down: loopY}
+	if label != "" {
+		l.down += railLabel
+	}
+	l.draw = func(r *railroad, x, y int) {
+		r.path("M%d %dh%d", x, y, 2*railArc)
+		b.draw(r, x+2*railArc, y)
+		r.path("M%d %dh%d", x+2*railArc+b.w, y, 2*railArc)
+		r.path("M%d %da%d %d 0 0 1 %d %dV%da%d %d 0 0 1 %d %dH%da%d %d 0 0 1 %d %dV%da%d %d 0 0 1 %d %d",
+			x+l.w-2*railArc, y, railArc, railArc, railArc, railArc, y+loopY-railArc, railArc, railArc, -railArc,
+			railArc, x+2*railArc, railArc, railArc, -railArc, -railArc, y+railArc, railArc, railArc, railArc,
+			-railArc)
+		if label != "" {
+			r.printf("<text class=\"label\" x=\"%d\" y=\"%d\">%s</text>\n",
+				x+2*railArc, y+loopY+railLabel-2, html.EscapeString(label))
+		}
+	}
+	return l
+}
+
+// railLookahead is the box in a dashed frame, with a label describing the lookahead.
+func railLookahead(b railBox, label string) railBox {
+	l := railBox{w: b.w + 2*railGap, up: b.up + railGap + railLabel, down: b.down + railGap}
+	l.draw = func(r *railroad, x, y int) {
+		top := y - b.up - railGap
+		r.printf("<rect class=\"lookahead\" x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\"/>\n",
+			x, top, l.w, b.up+b.down+2*railGap)
+		r.printf("<text class=\"label\" x=\"%d\" y=\"%d\">%s</text>\n", x, top-4, html.EscapeString(label))
+		r.path("M%d %dh%d", x, y, railGap)
+		b.draw(r, x+railGap, y)
+		r.path("M%d %dh%d", x+railGap+b.w, y, railGap)
+	}
+	return l
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
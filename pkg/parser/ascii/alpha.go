@@ -19,7 +19,7 @@ type (
 func (o *alphaParser) Parse(in parser.Reader) (byte, error) {
 	b, err := in.ReadByte()
 	if err != nil {
-		return 0, err
+		return 0, parser.NewExpectedError(in, err, alphaExpected)
 	}
 
 	if IsLetter(b) {
@@ -27,18 +27,18 @@ func (o *alphaParser) Parse(in parser.Reader) (byte, error) {
 	}
 
 	_, _ = in.Seek(-1, io.SeekCurrent)
-	return 0, errors.ErrNotMatched
+	return 0, parser.NewExpectedError(in, errors.ErrNotMatched, alphaExpected)
 }
 
 func (o *alphaParser) ParseBytes(in []byte) (byte, []byte, error) {
 	if len(in) == 0 {
-		return 0, in, io.EOF
+		return 0, in, parser.NewExpectedBytesError(in, errors.NewEOFError(1), alphaExpected)
 	}
 
 	if IsLetter(in[0]) {
 		return in[0], in[1:], nil
 	}
-	return 0, in, errors.ErrNotMatched
+	return 0, in, parser.NewExpectedBytesError(in, errors.ErrNotMatched, alphaExpected)
 }
 
 func (o *alpha0Parser) Parse(in parser.Reader) ([]byte, error) {
@@ -68,12 +68,12 @@ func (o *alpha0Parser) ParseBytes(in []byte) ([]byte, []byte, error) {
 func (o *alpha1Parser) Parse(in parser.Reader) ([]byte, error) {
 	b, err := in.ReadByte()
 	if err != nil {
-		return nil, err
+		return nil, parser.NewExpectedError(in, err, alphaExpected)
 	}
 
 	if !IsLetter(b) {
 		_, _ = in.Seek(-1, io.SeekCurrent)
-		return nil, errors.ErrNotMatched
+		return nil, parser.NewExpectedError(in, errors.ErrNotMatched, alphaExpected)
 	}
 
 	chars := []byte{b}
@@ -99,12 +99,21 @@ func (o *alpha1Parser) ParseBytes(in []byte) ([]byte, []byte, error) {
 		}
 	}
 	if i == 0 {
-		return nil, in, errors.ErrNotMatched
+		return nil, in, parser.NewExpectedBytesError(in, errors.ErrNotMatched, alphaExpected)
 	}
 	return in[0:i], in[i:], nil
 }
 
+func (o *alphaParser) Expected() []string {
+	return alphaExpected
+}
+
+func (o *alpha1Parser) Expected() []string {
+	return alphaExpected
+}
+
 var alphaParserInstance = &alphaParser{}
+var alphaExpected = []string{"letter"}
 var alpha0ParserInstance = &alpha0Parser{}
 var alpha1ParserInstance = &alpha1Parser{}
 
@@ -28,7 +28,7 @@ func ExampleAlpha_noMatch() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'not matched', Remainder: '123'
+	// Match: 0, Error: 'expected letter at offset 0', Remainder: '123'
 }
 
 func ExampleAlpha_endOfFile() {
@@ -40,7 +40,7 @@ func ExampleAlpha_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: ''
+	// Match: 0, Error: 'unexpected end of input, expected letter at offset 0', Remainder: ''
 }
 
 func ExampleAlpha1_match() {
@@ -64,7 +64,7 @@ func ExampleAlpha1_noMatch() {
 	fmt.Printf("Match: '%s', Error: '%v', Remainder: '%s'", string(match), err, string(remainder))
 
 	// Output:
-	// Match: '', Error: 'not matched', Remainder: '123'
+	// Match: '', Error: 'expected letter at offset 0', Remainder: '123'
 }
 
 func ExampleAlpha1_endOfFile() {
@@ -76,7 +76,7 @@ func ExampleAlpha1_endOfFile() {
 	fmt.Printf("Match: '%s', Error: '%v', Remainder: '%s'", string(match), err, string(remainder))
 
 	// Output:
-	// Match: '', Error: 'EOF', Remainder: ''
+	// Match: '', Error: 'unexpected end of input, expected letter at offset 0', Remainder: ''
 }
 
 func ExampleAlpha0_match() {
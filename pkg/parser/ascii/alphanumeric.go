@@ -19,7 +19,7 @@ type (
 func (o *alphanumericParser) Parse(in parser.Reader) (byte, error) {
 	b, err := in.ReadByte()
 	if err != nil {
-		return 0, err
+		return 0, parser.NewExpectedError(in, err, alphanumericExpected)
 	}
 
 	if IsAlphanumeric(b) {
@@ -27,18 +27,18 @@ func (o *alphanumericParser) Parse(in parser.Reader) (byte, error) {
 	}
 
 	_, _ = in.Seek(-1, io.SeekCurrent)
-	return 0, errors.ErrNotMatched
+	return 0, parser.NewExpectedError(in, errors.ErrNotMatched, alphanumericExpected)
 }
 
 func (o *alphanumericParser) ParseBytes(in []byte) (byte, []byte, error) {
 	if len(in) == 0 {
-		return 0, in, io.EOF
+		return 0, in, parser.NewExpectedBytesError(in, errors.NewEOFError(1), alphanumericExpected)
 	}
 
 	if IsAlphanumeric(in[0]) {
 		return in[0], in[1:], nil
 	}
-	return 0, in, errors.ErrNotMatched
+	return 0, in, parser.NewExpectedBytesError(in, errors.ErrNotMatched, alphanumericExpected)
 }
 
 func (o *alphanumeric0Parser) Parse(in parser.Reader) ([]byte, error) {
@@ -67,12 +67,12 @@ func (o *alphanumeric0Parser) ParseBytes(in []byte) ([]byte, []byte, error) {
 func (o *alphanumeric1Parser) Parse(in parser.Reader) ([]byte, error) {
 	b, err := in.ReadByte()
 	if err != nil {
-		return nil, err
+		return nil, parser.NewExpectedError(in, err, alphanumericExpected)
 	}
 
 	if !IsAlphanumeric(b) {
 		_, _ = in.Seek(-1, io.SeekCurrent)
-		return nil, errors.ErrNotMatched
+		return nil, parser.NewExpectedError(in, errors.ErrNotMatched, alphanumericExpected)
 	}
 
 	chars := []byte{b}
@@ -98,12 +98,21 @@ func (o *alphanumeric1Parser) ParseBytes(in []byte) ([]byte, []byte, error) {
 		}
 	}
 	if i == 0 {
-		return nil, in, errors.ErrNotMatched
+		return nil, in, parser.NewExpectedBytesError(in, errors.ErrNotMatched, alphanumericExpected)
 	}
 	return in[0:i], in[i:], nil
 }
 
+func (o *alphanumericParser) Expected() []string {
+	return alphanumericExpected
+}
+
+func (o *alphanumeric1Parser) Expected() []string {
+	return alphanumericExpected
+}
+
 var alphanumericParserInstance = &alphanumericParser{}
+var alphanumericExpected = []string{"alphanumeric"}
 var alphanumeric0ParserInstance = &alphanumeric0Parser{}
 var alphanumeric1ParserInstance = &alphanumeric1Parser{}
 
@@ -28,7 +28,7 @@ func ExampleAlphanumeric_noMatch() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'not matched', Remainder: '+-'
+	// Match: 0, Error: 'expected alphanumeric at offset 0', Remainder: '+-'
 }
 
 func ExampleAlphanumeric_endOfFile() {
@@ -40,7 +40,7 @@ func ExampleAlphanumeric_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: ''
+	// Match: 0, Error: 'unexpected end of input, expected alphanumeric at offset 0', Remainder: ''
 }
 
 func ExampleAlphanumeric1_match() {
@@ -64,7 +64,7 @@ func ExampleAlphanumeric1_noMatch() {
 	fmt.Printf("Match: '%s', Error: '%v', Remainder: '%s'", string(match), err, string(remainder))
 
 	// Output:
-	// Match: '', Error: 'not matched', Remainder: '+-'
+	// Match: '', Error: 'expected alphanumeric at offset 0', Remainder: '+-'
 }
 
 func ExampleAlphanumeric1_endOfFile() {
@@ -76,7 +76,7 @@ func ExampleAlphanumeric1_endOfFile() {
 	fmt.Printf("Match: '%s', Error: '%v', Remainder: '%s'", string(match), err, string(remainder))
 
 	// Output:
-	// Match: '', Error: 'EOF', Remainder: ''
+	// Match: '', Error: 'unexpected end of input, expected alphanumeric at offset 0', Remainder: ''
 }
 
 func ExampleAlphanumeric0_match() {
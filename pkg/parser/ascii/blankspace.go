@@ -11,17 +11,20 @@ type (
 	blankSpaceParser struct{}
 )
 
-var blankSpaceParserInstance = &blankSpaceParser{}
+var (
+	blankSpaceParserInstance = &blankSpaceParser{}
+	blankSpaceExpected       = []string{"blank space"}
+)
 
 func (o *blankSpaceParser) Parse(in parser.Reader) (byte, error) {
 	b, err := in.ReadByte()
 	if err != nil {
-		return 0, err
+		return 0, parser.NewExpectedError(in, err, blankSpaceExpected)
 	}
 
 	if !IsBlankSpace(b) {
 		_, _ = in.Seek(-1, io.SeekCurrent)
-		return 0, errors.ErrNotMatched
+		return 0, parser.NewExpectedError(in, errors.ErrNotMatched, blankSpaceExpected)
 	}
 
 	return b, nil
@@ -29,13 +32,17 @@ func (o *blankSpaceParser) Parse(in parser.Reader) (byte, error) {
 
 func (o *blankSpaceParser) ParseBytes(in []byte) (byte, []byte, error) {
 	if len(in) == 0 {
-		return 0, in, io.EOF
+		return 0, in, parser.NewExpectedBytesError(in, errors.NewEOFError(1), blankSpaceExpected)
 	}
 
 	if IsBlankSpace(in[0]) {
 		return in[0], in[1:], nil
 	}
-	return 0, in, errors.ErrNotMatched
+	return 0, in, parser.NewExpectedBytesError(in, errors.ErrNotMatched, blankSpaceExpected)
+}
+
+func (o *blankSpaceParser) Expected() []string {
+	return blankSpaceExpected
 }
 
 // BlankSpace returns a single ASCII blank space character: [ \t\r\n]
@@ -19,7 +19,7 @@ type (
 func (o *digitParser) Parse(in parser.Reader) (byte, error) {
 	b, err := in.ReadByte()
 	if err != nil {
-		return 0, err
+		return 0, parser.NewExpectedError(in, err, digitExpected)
 	}
 
 	if IsDigit(b) {
@@ -27,18 +27,18 @@ func (o *digitParser) Parse(in parser.Reader) (byte, error) {
 	}
 
 	_, _ = in.Seek(-1, io.SeekCurrent)
-	return 0, errors.ErrNotMatched
+	return 0, parser.NewExpectedError(in, errors.ErrNotMatched, digitExpected)
 }
 
 func (o *digitParser) ParseBytes(in []byte) (byte, []byte, error) {
 	if len(in) == 0 {
-		return 0, in, io.EOF
+		return 0, in, parser.NewExpectedBytesError(in, errors.NewEOFError(1), digitExpected)
 	}
 
 	if IsDigit(in[0]) {
 		return in[0], in[1:], nil
 	}
-	return 0, in, errors.ErrNotMatched
+	return 0, in, parser.NewExpectedBytesError(in, errors.ErrNotMatched, digitExpected)
 }
 
 func (o *digit0Parser) Parse(in parser.Reader) ([]byte, error) {
@@ -68,12 +68,12 @@ func (o *digit0Parser) ParseBytes(in []byte) ([]byte, []byte, error) {
 func (o *digit1Parser) Parse(in parser.Reader) ([]byte, error) {
 	b, err := in.ReadByte()
 	if err != nil {
-		return nil, err
+		return nil, parser.NewExpectedError(in, err, digitExpected)
 	}
 
 	if !IsDigit(b) {
 		_, _ = in.Seek(-1, io.SeekCurrent)
-		return nil, errors.ErrNotMatched
+		return nil, parser.NewExpectedError(in, errors.ErrNotMatched, digitExpected)
 	}
 
 	digits := []byte{b}
@@ -99,12 +99,21 @@ func (o *digit1Parser) ParseBytes(in []byte) ([]byte, []byte, error) {
 		}
 	}
 	if i == 0 {
-		return nil, in, errors.ErrNotMatched
+		return nil, in, parser.NewExpectedBytesError(in, errors.ErrNotMatched, digitExpected)
 	}
 	return in[0:i], in[i:], nil
 }
 
+func (o *digitParser) Expected() []string {
+	return digitExpected
+}
+
+func (o *digit1Parser) Expected() []string {
+	return digitExpected
+}
+
 var digitParserInstance = &digitParser{}
+var digitExpected = []string{"digit"}
 var digit0ParserInstance = &digit0Parser{}
 var digit1ParserInstance = &digit1Parser{}
 
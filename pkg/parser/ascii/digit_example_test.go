@@ -28,7 +28,7 @@ func ExampleDigit_noMatch() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'not matched', Remainder: 'abc'
+	// Match: 0, Error: 'expected digit at offset 0', Remainder: 'abc'
 }
 
 func ExampleDigit_endOfFile() {
@@ -40,7 +40,7 @@ func ExampleDigit_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: ''
+	// Match: 0, Error: 'unexpected end of input, expected digit at offset 0', Remainder: ''
 }
 
 func ExampleDigit1_match() {
@@ -64,7 +64,7 @@ func ExampleDigit1_noMatch() {
 	fmt.Printf("Match: '%s', Error: '%v', Remainder: '%s'", string(match), err, string(remainder))
 
 	// Output:
-	// Match: '', Error: 'not matched', Remainder: 'abc'
+	// Match: '', Error: 'expected digit at offset 0', Remainder: 'abc'
 }
 
 func ExampleDigit1_endOfFile() {
@@ -76,7 +76,7 @@ func ExampleDigit1_endOfFile() {
 	fmt.Printf("Match: '%s', Error: '%v', Remainder: '%s'", string(match), err, string(remainder))
 
 	// Output:
-	// Match: '', Error: 'EOF', Remainder: ''
+	// Match: '', Error: 'unexpected end of input, expected digit at offset 0', Remainder: ''
 }
 
 func ExampleDigit0_match() {
@@ -28,7 +28,7 @@ func ExampleUInt8_noMatch() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'not matched', Remainder: 'abc'
+	// Match: 0, Error: 'expected digit at offset 0', Remainder: 'abc'
 }
 
 func ExampleUInt8_overflow() {
@@ -40,7 +40,7 @@ func ExampleUInt8_overflow() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'overflow', Remainder: '1234a'
+	// Match: 0, Error: 'overflow at offset 0', Remainder: '1234a'
 }
 
 func ExampleUInt8_endOfFile() {
@@ -52,7 +52,7 @@ func ExampleUInt8_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: ''
+	// Match: 0, Error: 'unexpected end of input, expected digit at offset 0', Remainder: ''
 }
 
 func ExampleUInt16_match() {
@@ -76,7 +76,7 @@ func ExampleUInt16_noMatch() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'not matched', Remainder: 'abc'
+	// Match: 0, Error: 'expected digit at offset 0', Remainder: 'abc'
 }
 
 func ExampleUInt16_overflow() {
@@ -88,7 +88,7 @@ func ExampleUInt16_overflow() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'overflow', Remainder: '65536a'
+	// Match: 0, Error: 'overflow at offset 0', Remainder: '65536a'
 }
 
 func ExampleUInt16_endOfFile() {
@@ -100,7 +100,7 @@ func ExampleUInt16_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: ''
+	// Match: 0, Error: 'unexpected end of input, expected digit at offset 0', Remainder: ''
 }
 
 func ExampleUInt32_match() {
@@ -124,7 +124,7 @@ func ExampleUInt32_noMatch() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'not matched', Remainder: 'abc'
+	// Match: 0, Error: 'expected digit at offset 0', Remainder: 'abc'
 }
 
 func ExampleUInt32_overflow() {
@@ -136,7 +136,7 @@ func ExampleUInt32_overflow() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'overflow', Remainder: '42949672950'
+	// Match: 0, Error: 'overflow at offset 0', Remainder: '42949672950'
 }
 
 func ExampleUInt32_endOfFile() {
@@ -148,7 +148,7 @@ func ExampleUInt32_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: ''
+	// Match: 0, Error: 'unexpected end of input, expected digit at offset 0', Remainder: ''
 }
 
 func ExampleUInt64_match() {
@@ -172,7 +172,7 @@ func ExampleUInt64_noMatch() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'not matched', Remainder: 'abc'
+	// Match: 0, Error: 'expected digit at offset 0', Remainder: 'abc'
 }
 
 func ExampleUInt64_overflow() {
@@ -184,7 +184,7 @@ func ExampleUInt64_overflow() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'overflow', Remainder: '18446744073709551616'
+	// Match: 0, Error: 'overflow at offset 0', Remainder: '18446744073709551616'
 }
 
 func ExampleUInt64_endOfFile() {
@@ -196,7 +196,7 @@ func ExampleUInt64_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: ''
+	// Match: 0, Error: 'unexpected end of input, expected digit at offset 0', Remainder: ''
 }
 
 func ExampleInt8_match() {
@@ -220,7 +220,7 @@ func ExampleInt8_noMatch() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'not matched', Remainder: 'abc'
+	// Match: 0, Error: 'expected digit at offset 0', Remainder: 'abc'
 }
 
 func ExampleInt8_overflow() {
@@ -232,7 +232,7 @@ func ExampleInt8_overflow() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'overflow', Remainder: '1234a'
+	// Match: 0, Error: 'overflow at offset 0', Remainder: '1234a'
 }
 
 func ExampleInt8_endOfFile() {
@@ -244,7 +244,7 @@ func ExampleInt8_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: ''
+	// Match: 0, Error: 'unexpected end of input, expected digit at offset 0', Remainder: ''
 }
 
 func ExampleInt16_match() {
@@ -268,7 +268,7 @@ func ExampleInt16_noMatch() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'not matched', Remainder: 'abc'
+	// Match: 0, Error: 'expected digit at offset 0', Remainder: 'abc'
 }
 
 func ExampleInt16_overflow() {
@@ -280,7 +280,7 @@ func ExampleInt16_overflow() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'overflow', Remainder: '65536a'
+	// Match: 0, Error: 'overflow at offset 0', Remainder: '65536a'
 }
 
 func ExampleInt16_endOfFile() {
@@ -292,7 +292,7 @@ func ExampleInt16_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: ''
+	// Match: 0, Error: 'unexpected end of input, expected digit at offset 0', Remainder: ''
 }
 
 func ExampleInt32_match() {
@@ -316,7 +316,7 @@ func ExampleInt32_noMatch() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'not matched', Remainder: 'abc'
+	// Match: 0, Error: 'expected digit at offset 0', Remainder: 'abc'
 }
 
 func ExampleInt32_overflow() {
@@ -328,7 +328,7 @@ func ExampleInt32_overflow() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'overflow', Remainder: '42949672950'
+	// Match: 0, Error: 'overflow at offset 0', Remainder: '42949672950'
 }
 
 func ExampleInt32_endOfFile() {
@@ -340,7 +340,7 @@ func ExampleInt32_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: ''
+	// Match: 0, Error: 'unexpected end of input, expected digit at offset 0', Remainder: ''
 }
 
 func ExampleInt64_match() {
@@ -364,7 +364,7 @@ func ExampleInt64_noMatch() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'not matched', Remainder: 'abc'
+	// Match: 0, Error: 'expected digit at offset 0', Remainder: 'abc'
 }
 
 func ExampleInt64_overflow() {
@@ -376,7 +376,7 @@ func ExampleInt64_overflow() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'overflow', Remainder: '18446744073709551616'
+	// Match: 0, Error: 'overflow at offset 0', Remainder: '18446744073709551616'
 }
 
 func ExampleInt64_endOfFile() {
@@ -388,5 +388,5 @@ func ExampleInt64_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: ''
+	// Match: 0, Error: 'unexpected end of input, expected digit at offset 0', Remainder: ''
 }
@@ -13,28 +13,31 @@ type (
 	}
 )
 
-var lineEndingParserInstance = &lineEndingParser{}
+var (
+	lineEndingParserInstance = &lineEndingParser{}
+	lineEndingExpected       = []string{"line ending"}
+)
 
 func (o *lineEndingParser) Parse(in parser.Reader) ([]byte, error) {
 	b, err := in.ReadByte()
 	if err != nil {
-		return nil, err
+		return nil, parser.NewExpectedError(in, err, lineEndingExpected)
 	}
 	if b == '\n' {
 		return []byte{'\n'}, nil
 	}
 	if b != '\r' {
 		_, _ = in.Seek(-1, io.SeekCurrent)
-		return nil, errors.ErrNotMatched
+		return nil, parser.NewExpectedError(in, errors.ErrNotMatched, lineEndingExpected)
 	}
 	b, err = in.ReadByte()
 	if err != nil {
 		_, _ = in.Seek(-1, io.SeekCurrent)
-		return nil, err
+		return nil, parser.NewExpectedError(in, err, lineEndingExpected)
 	}
 	if b != '\n' {
 		_, _ = in.Seek(-2, io.SeekCurrent)
-		return nil, errors.ErrNotMatched
+		return nil, parser.NewExpectedError(in, errors.ErrNotMatched, lineEndingExpected)
 	}
 
 	return []byte{'\r', '\n'}, nil
@@ -42,7 +45,7 @@ func (o *lineEndingParser) Parse(in parser.Reader) ([]byte, error) {
 
 func (o *lineEndingParser) ParseBytes(in []byte) ([]byte, []byte, error) {
 	if len(in) == 0 {
-		return nil, in, io.EOF
+		return nil, in, parser.NewExpectedBytesError(in, errors.NewEOFError(1), lineEndingExpected)
 	}
 
 	if in[0] == '\n' {
@@ -51,7 +54,7 @@ func (o *lineEndingParser) ParseBytes(in []byte) ([]byte, []byte, error) {
 
 	if in[0] == '\r' {
 		if len(in) == 1 {
-			return nil, in, io.EOF
+			return nil, in, parser.NewExpectedBytesError(in, errors.NewEOFError(1), lineEndingExpected)
 		}
 
 		if in[1] == '\n' {
@@ -59,8 +62,12 @@ func (o *lineEndingParser) ParseBytes(in []byte) ([]byte, []byte, error) {
 		}
 	}
 
-	return nil, in, errors.ErrNotMatched
+	return nil, in, parser.NewExpectedBytesError(in, errors.ErrNotMatched, lineEndingExpected)
+
+}
 
+func (o *lineEndingParser) Expected() []string {
+	return lineEndingExpected
 }
 
 // CRLF matches the sequence `\r\n`
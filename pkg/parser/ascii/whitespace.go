@@ -12,17 +12,20 @@ type (
 	}
 )
 
-var whitespaceParserInstance = whitespaceParser{}
+var (
+	whitespaceParserInstance = whitespaceParser{}
+	whitespaceExpected       = []string{"whitespace"}
+)
 
 func (*whitespaceParser) Parse(in parser.Reader) (byte, error) {
 	b, err := in.ReadByte()
 	if err != nil {
-		return 0, err
+		return 0, parser.NewExpectedError(in, err, whitespaceExpected)
 	}
 
 	if !IsWhitespace(b) {
 		_, _ = in.Seek(-1, io.SeekCurrent)
-		return 0, errors.ErrNotMatched
+		return 0, parser.NewExpectedError(in, errors.ErrNotMatched, whitespaceExpected)
 	}
 
 	return b, nil
@@ -30,16 +33,20 @@ func (*whitespaceParser) Parse(in parser.Reader) (byte, error) {
 
 func (o *whitespaceParser) ParseBytes(in []byte) (byte, []byte, error) {
 	if len(in) == 0 {
-		return 0, in, io.EOF
+		return 0, in, parser.NewExpectedBytesError(in, errors.NewEOFError(1), whitespaceExpected)
 	}
 
 	if !IsWhitespace(in[0]) {
-		return 0, in, errors.ErrNotMatched
+		return 0, in, parser.NewExpectedBytesError(in, errors.ErrNotMatched, whitespaceExpected)
 	}
 
 	return in[0], in[1:], nil
 }
 
+func (o *whitespaceParser) Expected() []string {
+	return whitespaceExpected
+}
+
 // Whitespace returns a single ASCII whitespace character: [ \t\r\n\v\f]
 func Whitespace() parser.Parser[parser.Reader, byte] {
 	return &whitespaceParserInstance
@@ -22,6 +22,12 @@ type (
 		cache byte  // unread bits
 		bits  uint8 // number of unread bits in cache
 	}
+
+	bitWriter struct {
+		w     io.Writer
+		cache byte  // unwritten bits
+		bits  uint8 // number of unwritten bits in cache
+	}
 )
 
 func (r *bitReader) Read(p []byte) (n int, err error) {
@@ -158,3 +164,44 @@ func (r *bitReader) ReadBits(n uint8) (uint64, uint8, error) {
 func (r *bitReader) isAligned() bool {
 	return r.bits == 0
 }
+
+func (w *bitWriter) Write(p []byte) (int, error) {
+	if w.bits == 0 {
+		return w.w.Write(p)
+	}
+	for i, b := range p {
+		if err := w.WriteBits(uint64(b), BitsInByte); err != nil {
+			return i, err
+		}
+	}
+	return len(p), nil
+}
+
+// WriteBits writes the n least significant bits of v, most significant first. Whole bytes are passed to the
+// underlying writer as they are completed.
+func (w *bitWriter) WriteBits(v uint64, n uint8) error {
+	if n > MaxBits {
+		n = MaxBits
+	}
+	for n > 0 {
+		free := BitsInByte - w.bits
+		take := free
+		if n < take {
+			take = n
+		}
+		n -= take
+		w.cache = w.cache<<take | byte(v>>n)&(1<<take-1)
+		w.bits += take
+		if w.bits == BitsInByte {
+			if _, err := w.w.Write([]byte{w.cache}); err != nil {
+				return err
+			}
+			w.cache, w.bits = 0, 0
+		}
+	}
+	return nil
+}
+
+func (w *bitWriter) isAligned() bool {
+	return w.bits == 0
+}
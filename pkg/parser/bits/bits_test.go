@@ -454,3 +454,81 @@ func Test_bitReader_Seek(t *testing.T) {
 		})
 	}
 }
+
+func Test_bitWriter_WriteBits(t *testing.T) {
+	type write struct {
+		v uint64
+		n uint8
+	}
+	tests := []struct {
+		name        string
+		writes      []write
+		want        []byte
+		wantAligned bool
+	}{
+		{
+			name:        "whole bytes => written",
+			writes:      []write{{v: 0x0102, n: 16}},
+			want:        []byte{0x01, 0x02},
+			wantAligned: true,
+		},
+		{
+			name:        "partial bytes => combined",
+			writes:      []write{{v: 0x3, n: 2}, {v: 0x1, n: 2}, {v: 0xA, n: 4}},
+			want:        []byte{0xDA},
+			wantAligned: true,
+		},
+		{
+			name:        "across bytes => split",
+			writes:      []write{{v: 0x1, n: 4}, {v: 0xABC, n: 12}},
+			want:        []byte{0x1A, 0xBC},
+			wantAligned: true,
+		},
+		{
+			name:   "incomplete byte => cached",
+			writes: []write{{v: 0xFF, n: 8}, {v: 0x1, n: 3}},
+			want:   []byte{0xFF},
+		},
+		{
+			name:        "extra high bits => ignored",
+			writes:      []write{{v: 0xFF, n: 4}, {v: 0, n: 4}},
+			want:        []byte{0xF0},
+			wantAligned: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := &bitWriter{w: &buf}
+			for _, write := range tt.writes {
+				require.NoError(t, w.WriteBits(write.v, write.n))
+			}
+
+			assert.Equal(t, tt.want, buf.Bytes())
+			assert.Equal(t, tt.wantAligned, w.isAligned())
+		})
+	}
+}
+
+func Test_bitWriter_roundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w := &bitWriter{w: &buf}
+	sizes := []uint8{1, 3, 7, 9, 12, 33, 6}
+	for i, n := range sizes {
+		require.NoError(t, w.WriteBits(uint64(i+1), n))
+	}
+	_, err := w.Write([]byte{0xAB})
+	require.NoError(t, err)
+	require.NoError(t, w.WriteBits(0, 1))
+	require.True(t, w.isAligned())
+
+	r := &bitReader{Reader: bytes.NewReader(buf.Bytes())}
+	for i, n := range sizes {
+		v, _, err := r.ReadBits(n)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(i+1), v)
+	}
+	b, err := r.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(t, byte(0xAB), b)
+}
@@ -8,37 +8,70 @@ import (
 
 type (
 	bitsParser[T any] struct {
-		parser parser.Parser[parser.BitReader, T]
+		parser parser.Child[parser.BitReader, T]
 	}
 )
 
 func (o *bitsParser[T]) Parse(in parser.Reader) (T, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *bitsParser[T]) ParseBytes(in []byte) (T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *bitsParser[T]) ParseSession(s *parser.Session, in parser.Reader) (T, error) {
 	currentOffset, _ := in.Seek(0, io.SeekCurrent)
 	reader := &bitReader{Reader: in}
-	result, err := o.parser.Parse(reader)
+	defer s.SetScope(s.SetScope(reader))
+	result, err := o.parser.ParseIn(s, reader)
 	if err != nil {
 		var t T
-		return t, err
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return t, parser.NewError(in, err)
 	}
 
 	if !reader.isAligned() {
 		_, _ = in.Seek(currentOffset, io.SeekStart)
-		return result, ErrRemainingBits
+		return result, parser.NewError(in, ErrRemainingBits)
 	}
 
 	return result, nil
 }
 
-func (o *bitsParser[T]) ParseBytes(in []byte) (T, []byte, error) {
+func (o *bitsParser[T]) ParseBytesSession(s *parser.Session, in []byte) (T, []byte, error) {
 	inReader := bytes.NewReader(in)
-	t, err := o.Parse(&bitReader{Reader: inReader})
+	reader := &bitReader{Reader: inReader}
+	defer s.SetScope(s.SetScope(reader))
+	result, err := o.parser.ParseIn(s, reader)
 	if err != nil {
-		return t, in, err
+		var t T
+		return t, in, parser.NewBytesError(in, err)
+	}
+
+	if !reader.isAligned() {
+		return result, in, parser.NewBytesError(in, ErrRemainingBits)
 	}
+
 	currentOffset, _ := inReader.Seek(0, io.SeekCurrent)
-	return t, in[currentOffset:], err
+	return result, in[currentOffset:], nil
+}
+
+func (o *bitsParser[T]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.parser)
+}
+
+func (o *bitsParser[T]) Print(w io.Writer, v T) error {
+	writer := &bitWriter{w: w}
+	if err := o.parser.Print(writer, v); err != nil {
+		return err
+	}
+	if !writer.isAligned() {
+		return ErrRemainingBits
+	}
+	return nil
 }
 
 func Bits[T any](p parser.Parser[parser.BitReader, T]) parser.Parser[parser.Reader, T] {
-	return &bitsParser[T]{parser: p}
+	return &bitsParser[T]{parser: parser.NewChild(p)}
 }
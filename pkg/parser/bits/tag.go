@@ -41,6 +41,18 @@ func (*tagParser[T]) ParseBytes(in []byte) (T, []byte, error) {
 	return 0, in, errors.ErrNotSupported
 }
 
+// Print writes the tag. The value must be the tag, or 0.
+func (o *tagParser[T]) Print(w io.Writer, v T) error {
+	if v != 0 && v != o.tag {
+		return errors.ErrNotMatched
+	}
+	bw, ok := w.(parser.BitWriter)
+	if !ok {
+		return errors.ErrNotSupported
+	}
+	return bw.WriteBits(uint64(o.tag), o.n)
+}
+
 func Tag[T tagParserConstraint](n uint8, tag T) parser.Parser[parser.BitReader, T] {
 	var t T
 	l := bits.Len64(uint64(t) - 1)
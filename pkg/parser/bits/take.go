@@ -34,6 +34,17 @@ func (*takeParser[T]) ParseBytes(in []byte) (T, []byte, error) {
 	return 0, in, errors.ErrNotSupported
 }
 
+func (o *takeParser[T]) Print(w io.Writer, v T) error {
+	bw, ok := w.(parser.BitWriter)
+	if !ok {
+		return errors.ErrNotSupported
+	}
+	if o.n < MaxBits && uint64(v)>>o.n != 0 {
+		return ErrBitsOverflow
+	}
+	return bw.WriteBits(uint64(v), o.n)
+}
+
 func Take[T takeParserConstraint](n uint8) parser.Parser[parser.BitReader, T] {
 	var t T
 	l := bits.Len64(uint64(t) - 1)
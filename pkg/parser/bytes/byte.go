@@ -9,19 +9,20 @@ import (
 
 type (
 	byteParser struct {
-		b byte
+		b        byte
+		expected []string
 	}
 )
 
 func (o *byteParser) Parse(in parser.Reader) (byte, error) {
 	b, err := in.ReadByte()
 	if err != nil {
-		return 0, err
+		return 0, parser.NewExpectedError(in, err, o.expected)
 	}
 
 	if b != o.b {
 		_, _ = in.Seek(-1, io.SeekCurrent)
-		return 0, errors.ErrNotMatched
+		return 0, parser.NewExpectedError(in, errors.ErrNotMatched, o.expected)
 	}
 
 	return b, nil
@@ -29,16 +30,29 @@ func (o *byteParser) Parse(in parser.Reader) (byte, error) {
 
 func (o *byteParser) ParseBytes(in []byte) (byte, []byte, error) {
 	if len(in) < 1 {
-		return 0, in, io.EOF
+		return 0, in, parser.NewExpectedBytesError(in, errors.NewEOFError(1), o.expected)
 	}
 
 	if in[0] != o.b {
-		return 0, in, errors.ErrNotMatched
+		return 0, in, parser.NewExpectedBytesError(in, errors.ErrNotMatched, o.expected)
 	}
 
 	return in[0], in[1:], nil
 }
 
+func (o *byteParser) Expected() []string {
+	return o.expected
+}
+
+// Print writes the matched byte. The value must be the byte, or 0.
+func (o *byteParser) Print(w io.Writer, v byte) error {
+	if v != 0 && v != o.b {
+		return errors.ErrNotMatched
+	}
+	_, err := w.Write([]byte{o.b})
+	return err
+}
+
 // Byte matches a single byte
 //
 // The input data will be compared to the match argument.
@@ -46,5 +60,5 @@ func (o *byteParser) ParseBytes(in []byte) (byte, []byte, error) {
 //   - If the input is empty, it will return io.EOF
 //   - If the input doesn't match the argument, it will return errors.ErrNotMatched
 func Byte(match byte) parser.Parser[parser.Reader, byte] {
-	return &byteParser{b: match}
+	return &byteParser{b: match, expected: []string{errors.Quote(match)}}
 }
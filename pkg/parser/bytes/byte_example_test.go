@@ -28,7 +28,7 @@ func ExampleByte_noMatch() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'not matched', Remainder: 'abc'
+	// Match: 0, Error: 'expected 'b' at offset 0', Remainder: 'abc'
 }
 
 func ExampleByte_endOfFile() {
@@ -40,5 +40,5 @@ func ExampleByte_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: ''
+	// Match: 0, Error: 'unexpected end of input, expected 'a' at offset 0', Remainder: ''
 }
@@ -63,9 +63,9 @@ func (o *escapedParser) ParseBytes(in []byte) (string, []byte, error) {
 	}
 	if n == 0 {
 		if len(in) == 0 {
-			return "", in, io.EOF
+			return "", in, parser.NewBytesError(in, errors.NewEOFError(1))
 		}
-		return "", in, errors.ErrNotMatched
+		return "", in, parser.NewBytesError(in, errors.ErrNotMatched)
 	}
 	return string(in[:n]), in[n:], nil
 }
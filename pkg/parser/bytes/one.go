@@ -2,6 +2,7 @@
 package bytes
 
 import (
+	"github.com/roblovelock/gobble/pkg/errors"
 	"github.com/roblovelock/gobble/pkg/parser"
 	"io"
 )
@@ -11,19 +12,35 @@ type (
 	}
 )
 
-var oneParserInstance = &oneParser{}
+var (
+	oneParserInstance = &oneParser{}
+	oneExpected       = []string{"any byte"}
+)
 
 func (o *oneParser) Parse(in parser.Reader) (byte, error) {
-	return in.ReadByte()
+	b, err := in.ReadByte()
+	if err != nil {
+		return 0, parser.NewExpectedError(in, err, oneExpected)
+	}
+	return b, nil
 }
 
 func (o *oneParser) ParseBytes(in []byte) (byte, []byte, error) {
 	if len(in) == 0 {
-		return 0, in, io.EOF
+		return 0, in, parser.NewExpectedBytesError(in, errors.NewEOFError(1), oneExpected)
 	}
 	return in[0], in[1:], nil
 }
 
+func (o *oneParser) Expected() []string {
+	return oneExpected
+}
+
+func (o *oneParser) Print(w io.Writer, v byte) error {
+	_, err := w.Write([]byte{v})
+	return err
+}
+
 // One reads a single byte
 //
 //   - If the input isn't empty, it will return a single byte.
@@ -5,11 +5,13 @@ import (
 	"github.com/roblovelock/gobble/pkg/parser"
 	"github.com/roblovelock/gobble/pkg/utils"
 	"io"
+	"strings"
 )
 
 type (
 	oneOfParser struct {
-		bytes [256]bool
+		bytes    [256]bool
+		expected []string
 	}
 
 	oneOf0Parser struct {
@@ -17,33 +19,42 @@ type (
 	}
 
 	oneOf1Parser struct {
-		bytes [256]bool
+		bytes    [256]bool
+		expected []string
 	}
 )
 
 func (o *oneOfParser) Parse(in parser.Reader) (byte, error) {
 	b, err := in.ReadByte()
 	if err != nil {
-		return 0, err
+		return 0, parser.NewExpectedError(in, err, o.expected)
 	}
 	if o.bytes[b] {
 		return b, nil
 	}
 	_, _ = in.Seek(-1, io.SeekCurrent)
-	return 0, errors.ErrNotMatched
+	return 0, parser.NewExpectedError(in, errors.ErrNotMatched, o.expected)
 }
 
 func (o *oneOfParser) ParseBytes(in []byte) (byte, []byte, error) {
 	if len(in) == 0 {
-		return 0, in, io.EOF
+		return 0, in, parser.NewExpectedBytesError(in, errors.NewEOFError(1), o.expected)
 	}
 	if !o.bytes[in[0]] {
-		return 0, in, errors.ErrNotMatched
+		return 0, in, parser.NewExpectedBytesError(in, errors.ErrNotMatched, o.expected)
 	}
 
 	return in[0], in[1:], nil
 }
 
+func (o *oneOfParser) Expected() []string {
+	return o.expected
+}
+
+func (o *oneOfParser) Describe() parser.Grammar {
+	return parser.NewByteSetGrammar(&o.bytes)
+}
+
 func (o *oneOf0Parser) Parse(in parser.Reader) ([]byte, error) {
 	result := make([]byte, 0)
 	for {
@@ -71,20 +82,24 @@ func (o *oneOf0Parser) ParseBytes(in []byte) ([]byte, []byte, error) {
 	return in[:n], in[n:], nil
 }
 
+func (o *oneOf0Parser) Describe() parser.Grammar {
+	return parser.NewRepeatGrammar(parser.NewByteSetGrammar(&o.bytes), 0, -1)
+}
+
 func (o *oneOf1Parser) Parse(in parser.Reader) ([]byte, error) {
 	result := make([]byte, 0, 1)
 	for {
 		b, err := in.ReadByte()
 		if err != nil {
 			if len(result) == 0 {
-				return nil, err
+				return nil, parser.NewExpectedError(in, err, o.expected)
 			}
 			break
 		}
 		if !o.bytes[b] {
 			_, _ = in.Seek(-1, io.SeekCurrent)
 			if len(result) == 0 {
-				return nil, errors.ErrNotMatched
+				return nil, parser.NewExpectedError(in, errors.ErrNotMatched, o.expected)
 			}
 			break
 		}
@@ -96,7 +111,7 @@ func (o *oneOf1Parser) Parse(in parser.Reader) ([]byte, error) {
 
 func (o *oneOf1Parser) ParseBytes(in []byte) ([]byte, []byte, error) {
 	if len(in) == 0 {
-		return nil, in, io.EOF
+		return nil, in, parser.NewExpectedBytesError(in, errors.NewEOFError(1), o.expected)
 	}
 	n := 0
 	for ; n < len(in); n++ {
@@ -105,18 +120,26 @@ func (o *oneOf1Parser) ParseBytes(in []byte) ([]byte, []byte, error) {
 		}
 	}
 	if n < 1 {
-		return nil, in, errors.ErrNotMatched
+		return nil, in, parser.NewExpectedBytesError(in, errors.ErrNotMatched, o.expected)
 	}
 
 	return in[:n], in[n:], nil
 }
 
+func (o *oneOf1Parser) Expected() []string {
+	return o.expected
+}
+
+func (o *oneOf1Parser) Describe() parser.Grammar {
+	return parser.NewRepeatGrammar(parser.NewByteSetGrammar(&o.bytes), 1, -1)
+}
+
 // OneOf matches one of the argument bytes
 //   - If the input matches the argument, it will return a single matched byte.
 //   - If the input is empty, it will return io.EOF
 //   - If the input doesn't match the argument, it will return errors.ErrNotMatched
 func OneOf(bytes ...byte) parser.Parser[parser.Reader, byte] {
-	return &oneOfParser{bytes: utils.NewByteLookupArray(bytes)}
+	return &oneOfParser{bytes: utils.NewByteLookupArray(bytes), expected: quoteBytes(bytes)}
 }
 
 // NotOneOf matches any byte not matching the argument bytes
@@ -124,7 +147,10 @@ func OneOf(bytes ...byte) parser.Parser[parser.Reader, byte] {
 //   - If the input is empty, it will return io.EOF
 //   - If the input matches the argument, it will return errors.ErrNotMatched
 func NotOneOf(bytes ...byte) parser.Parser[parser.Reader, byte] {
-	return &oneOfParser{bytes: utils.NewInverseByteLookupArray(bytes)}
+	return &oneOfParser{
+		bytes:    utils.NewInverseByteLookupArray(bytes),
+		expected: []string{"any byte except " + strings.Join(quoteBytes(bytes), ", ")},
+	}
 }
 
 // OneOf0 matches zero or more bytes matching one of the argument bytes
@@ -140,5 +166,13 @@ func OneOf0(bytes ...byte) parser.Parser[parser.Reader, []byte] {
 //   - If the input is empty, it will return io.EOF
 //   - If the input doesn't match the argument, it will return errors.ErrNotMatched
 func OneOf1(bytes ...byte) parser.Parser[parser.Reader, []byte] {
-	return &oneOf1Parser{bytes: utils.NewByteLookupArray(bytes)}
+	return &oneOf1Parser{bytes: utils.NewByteLookupArray(bytes), expected: quoteBytes(bytes)}
+}
+
+func quoteBytes(bytes []byte) []string {
+	expected := make([]string, len(bytes))
+	for i, b := range bytes {
+		expected[i] = errors.Quote(b)
+	}
+	return expected
 }
@@ -28,7 +28,7 @@ func ExampleOneOf_noMatch() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'not matched', Remainder: '123'
+	// Match: 0, Error: 'expected 'a', 'b' or 'c' at offset 0', Remainder: '123'
 }
 
 func ExampleOneOf_endOfFile() {
@@ -40,7 +40,7 @@ func ExampleOneOf_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: ''
+	// Match: 0, Error: 'unexpected end of input, expected 'a', 'b' or 'c' at offset 0', Remainder: ''
 }
 
 func ExampleOneOf1_match() {
@@ -64,7 +64,7 @@ func ExampleOneOf1_noMatch() {
 	fmt.Printf("Match: '%s', Error: '%v', Remainder: '%s'", string(match), err, string(remainder))
 
 	// Output:
-	// Match: '', Error: 'not matched', Remainder: '123'
+	// Match: '', Error: 'expected 'a', 'b' or 'c' at offset 0', Remainder: '123'
 }
 
 func ExampleOneOf1_endOfFile() {
@@ -76,7 +76,7 @@ func ExampleOneOf1_endOfFile() {
 	fmt.Printf("Match: '%s', Error: '%v', Remainder: '%s'", string(match), err, string(remainder))
 
 	// Output:
-	// Match: '', Error: 'EOF', Remainder: ''
+	// Match: '', Error: 'unexpected end of input, expected 'a', 'b' or 'c' at offset 0', Remainder: ''
 }
 
 func ExampleOneOf0_match() {
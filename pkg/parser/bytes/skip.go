@@ -27,7 +27,7 @@ func (o *skipWhileMinMaxParser) Parse(in parser.Reader) (parser.Empty, error) {
 		b, err := in.ReadByte()
 		if err != nil {
 			if n < o.min {
-				return nil, err
+				return nil, parser.NewError(in, err)
 			}
 			break
 		}
@@ -36,7 +36,7 @@ func (o *skipWhileMinMaxParser) Parse(in parser.Reader) (parser.Empty, error) {
 			_, _ = in.Seek(-1, io.SeekCurrent)
 			if n < o.min {
 				_, _ = in.Seek(startOffset, io.SeekStart)
-				return nil, errors.ErrNotMatched
+				return nil, parser.NewError(in, errors.ErrNotMatched)
 			}
 			break
 		}
@@ -47,7 +47,7 @@ func (o *skipWhileMinMaxParser) Parse(in parser.Reader) (parser.Empty, error) {
 
 func (o *skipWhileMinMaxParser) ParseBytes(in []byte) (parser.Empty, []byte, error) {
 	if len(in) < o.min {
-		return nil, in, io.EOF
+		return nil, in, parser.NewBytesError(in, errors.NewEOFError(o.min-len(in)))
 	}
 
 	max := utils.Min(o.max, len(in))
@@ -59,7 +59,7 @@ func (o *skipWhileMinMaxParser) ParseBytes(in []byte) (parser.Empty, []byte, err
 	}
 
 	if n < o.min {
-		return nil, in, errors.ErrNotMatched
+		return nil, in, parser.NewBytesError(in, errors.ErrNotMatched)
 	}
 
 	return nil, in[n:], nil
@@ -68,12 +68,12 @@ func (o *skipWhileMinMaxParser) ParseBytes(in []byte) (parser.Empty, []byte, err
 func (o *skipParser) Parse(in parser.Reader) (parser.Empty, error) {
 	b, err := in.ReadByte()
 	if err != nil {
-		return nil, err
+		return nil, parser.NewError(in, err)
 	}
 
 	if !o.predicate(b) {
 		_, _ = in.Seek(-1, io.SeekCurrent)
-		return nil, errors.ErrNotMatched
+		return nil, parser.NewError(in, errors.ErrNotMatched)
 	}
 
 	return nil, nil
@@ -81,11 +81,11 @@ func (o *skipParser) Parse(in parser.Reader) (parser.Empty, error) {
 
 func (o *skipParser) ParseBytes(in []byte) (parser.Empty, []byte, error) {
 	if len(in) == 0 {
-		return nil, in, io.EOF
+		return nil, in, parser.NewBytesError(in, errors.NewEOFError(1))
 	}
 
 	if !o.predicate(in[0]) {
-		return nil, in, errors.ErrNotMatched
+		return nil, in, parser.NewBytesError(in, errors.ErrNotMatched)
 	}
 
 	return nil, in[1:], nil
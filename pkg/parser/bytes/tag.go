@@ -9,21 +9,25 @@ import (
 
 type (
 	tagParser struct {
-		tag []byte
+		tag      []byte
+		expected []string
 	}
 )
 
 func (o *tagParser) Parse(in parser.Reader) ([]byte, error) {
 	result := make([]byte, len(o.tag))
-	n, err := in.Read(result)
-	if err != nil || n != len(o.tag) {
+	n, err := io.ReadFull(in, result)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
 		_, _ = in.Seek(-int64(n), io.SeekCurrent)
-		return nil, io.EOF
+		return nil, parser.NewExpectedError(in, errors.NewEOFError(len(o.tag)-n), o.expected)
+	}
+	if err != nil {
+		return nil, parser.NewExpectedError(in, err, o.expected)
 	}
 
 	if bytes.Compare(o.tag, result) != 0 {
 		_, _ = in.Seek(-int64(n), io.SeekCurrent)
-		return nil, errors.ErrNotMatched
+		return nil, parser.NewExpectedError(in, errors.ErrNotMatched, o.expected)
 	}
 
 	return result, nil
@@ -31,19 +35,32 @@ func (o *tagParser) Parse(in parser.Reader) ([]byte, error) {
 
 func (o *tagParser) ParseBytes(in []byte) ([]byte, []byte, error) {
 	if len(in) < len(o.tag) {
-		return nil, in, io.EOF
+		return nil, in, parser.NewExpectedBytesError(in, errors.NewEOFError(len(o.tag)-len(in)), o.expected)
 	}
 	if bytes.Compare(o.tag, in[:len(o.tag)]) != 0 {
-		return nil, in, errors.ErrNotMatched
+		return nil, in, parser.NewExpectedBytesError(in, errors.ErrNotMatched, o.expected)
 	}
 
 	return in[:len(o.tag)], in[len(o.tag):], nil
 }
 
+func (o *tagParser) Expected() []string {
+	return o.expected
+}
+
+// Print writes the tag. The value must be the tag, or empty.
+func (o *tagParser) Print(w io.Writer, v []byte) error {
+	if len(v) != 0 && !bytes.Equal(v, o.tag) {
+		return errors.ErrNotMatched
+	}
+	_, err := w.Write(o.tag)
+	return err
+}
+
 // Tag matches the argument
 //   - If the input matches the argument, it will return the tag.
 //   - If the input is empty, it will return io.EOF
 //   - If the input doesn't match the argument, it will return errors.ErrNotMatched
 func Tag(tag []byte) parser.Parser[parser.Reader, []byte] {
-	return &tagParser{tag: tag}
+	return &tagParser{tag: tag, expected: []string{errors.Quote(tag)}}
 }
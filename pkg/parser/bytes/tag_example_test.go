@@ -28,7 +28,7 @@ func ExampleTag_noMatch() {
 	fmt.Printf("Match: '%s', Error: '%v', Remainder: '%s'", string(match), err, string(remainder))
 
 	// Output:
-	// Match: '', Error: 'not matched', Remainder: 'abc'
+	// Match: '', Error: 'expected "bc" at offset 0', Remainder: 'abc'
 }
 
 func ExampleTag_endOfFile() {
@@ -40,5 +40,5 @@ func ExampleTag_endOfFile() {
 	fmt.Printf("Match: '%s', Error: '%v', Remainder: '%s'", string(match), err, string(remainder))
 
 	// Output:
-	// Match: '', Error: 'EOF', Remainder: ''
+	// Match: '', Error: 'unexpected end of input, expected "ab" at offset 0', Remainder: ''
 }
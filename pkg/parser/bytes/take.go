@@ -1,6 +1,7 @@
 package bytes
 
 import (
+	"github.com/roblovelock/gobble/pkg/errors"
 	"github.com/roblovelock/gobble/pkg/parser"
 	"io"
 )
@@ -18,7 +19,10 @@ func (o *takeParser) Parse(in parser.Reader) ([]byte, error) {
 		if err == io.ErrUnexpectedEOF {
 			_, _ = in.Seek(-int64(n), io.SeekCurrent)
 		}
-		return nil, io.EOF
+		if err == io.ErrUnexpectedEOF || err == io.EOF {
+			err = errors.NewEOFError(int(o.n) - n)
+		}
+		return nil, parser.NewError(in, err)
 	}
 
 	return b, nil
@@ -26,12 +30,21 @@ func (o *takeParser) Parse(in parser.Reader) ([]byte, error) {
 
 func (o *takeParser) ParseBytes(in []byte) ([]byte, []byte, error) {
 	if len(in) < int(o.n) {
-		return nil, in, io.EOF
+		return nil, in, parser.NewBytesError(in, errors.NewEOFError(int(o.n)-len(in)))
 	}
 
 	return in[:o.n], in[o.n:], nil
 }
 
+// Print writes v, which must be n bytes long.
+func (o *takeParser) Print(w io.Writer, v []byte) error {
+	if len(v) != int(o.n) {
+		return errors.ErrNotMatched
+	}
+	_, err := w.Write(v)
+	return err
+}
+
 // Take returns a slice of n bytes from the input
 //   - If the input contains n bytes, it will return a slice of n bytes.
 //   - If the input doesn't contain n bytes, it will return io.EOF
@@ -28,7 +28,7 @@ func ExampleTake_unexpectedEndOfFile() {
 	fmt.Printf("Match: '%s', Error: '%v', Remainder: '%s'", string(match), err, string(remainder))
 
 	// Output:
-	// Match: '', Error: 'EOF', Remainder: 'abc'
+	// Match: '', Error: 'EOF at offset 0', Remainder: 'abc'
 }
 
 func ExampleTake_endOfFile() {
@@ -40,5 +40,5 @@ func ExampleTake_endOfFile() {
 	fmt.Printf("Match: '%s', Error: '%v', Remainder: '%s'", string(match), err, string(remainder))
 
 	// Output:
-	// Match: '', Error: 'EOF', Remainder: ''
+	// Match: '', Error: 'EOF at offset 0', Remainder: ''
 }
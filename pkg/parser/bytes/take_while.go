@@ -17,14 +17,27 @@ type (
 )
 
 func (o *takeWhileMinMaxParser) Parse(in parser.Reader) ([]byte, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *takeWhileMinMaxParser) ParseBytes(in []byte) ([]byte, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *takeWhileMinMaxParser) ParseSession(s *parser.Session, in parser.Reader) ([]byte, error) {
 	startOffset, _ := in.Seek(0, io.SeekCurrent)
+	canceller := s.Canceller()
 	n := 0
 	for ; n < o.max; n++ {
+		if err := canceller.Check(); err != nil {
+			_, _ = in.Seek(startOffset, io.SeekStart)
+			return nil, parser.NewError(in, err)
+		}
 		b, err := in.ReadByte()
 		if err != nil {
 			if n < o.min {
 				_, _ = in.Seek(startOffset, io.SeekStart)
-				return nil, err
+				return nil, parser.NewError(in, err)
 			}
 			break
 		}
@@ -36,7 +49,7 @@ func (o *takeWhileMinMaxParser) Parse(in parser.Reader) ([]byte, error) {
 
 	_, _ = in.Seek(startOffset, io.SeekStart)
 	if n < o.min {
-		return nil, errors.ErrNotMatched
+		return nil, parser.NewError(in, errors.ErrNotMatched)
 	}
 	result := make([]byte, n)
 	_, _ = in.Read(result)
@@ -44,16 +57,20 @@ func (o *takeWhileMinMaxParser) Parse(in parser.Reader) ([]byte, error) {
 	return result, nil
 }
 
-func (o *takeWhileMinMaxParser) ParseBytes(in []byte) ([]byte, []byte, error) {
+func (o *takeWhileMinMaxParser) ParseBytesSession(s *parser.Session, in []byte) ([]byte, []byte, error) {
 	max := utils.Min(len(in), o.max)
+	canceller := s.Canceller()
 	n := 0
 	for ; n < max; n++ {
+		if err := canceller.Check(); err != nil {
+			return nil, in, parser.NewBytesError(in, err)
+		}
 		if !o.predicate(in[n]) {
 			break
 		}
 	}
 	if n < o.min {
-		return nil, in, errors.ErrNotMatched
+		return nil, in, parser.NewBytesError(in, errors.ErrNotMatched)
 	}
 
 	return in[:n], in[n:], nil
@@ -61,6 +78,7 @@ func (o *takeWhileMinMaxParser) ParseBytes(in []byte) ([]byte, []byte, error) {
 
 // TakeWhile Returns zero or more bytes that match the predicate.
 //   - If the input matches the predicate, it will return the matched bytes.
+//   - If the context bound by parser.ParseContext is cancelled, it will return the fatal ctx.Err()
 //   - If the input is empty, it will return an empty slice
 //   - If the input doesn't match the predicate, it will return an empty slice
 func TakeWhile(predicate parser.Predicate[byte]) parser.Parser[parser.Reader, []byte] {
@@ -69,6 +87,7 @@ func TakeWhile(predicate parser.Predicate[byte]) parser.Parser[parser.Reader, []
 
 // TakeWhile1 Returns one or more bytes that match the predicate.
 //   - If the input matches the predicate, it will return the matched bytes.
+//   - If the context bound by parser.ParseContext is cancelled, it will return the fatal ctx.Err()
 //   - If the input is empty, it will return io.EOF
 //   - If the input doesn't match the predicate, it will return errors.ErrNotMatched
 func TakeWhile1(predicate parser.Predicate[byte]) parser.Parser[parser.Reader, []byte] {
@@ -77,6 +96,7 @@ func TakeWhile1(predicate parser.Predicate[byte]) parser.Parser[parser.Reader, [
 
 // TakeWhileMinMax Returns the longest (m <= len <= n) input slice that matches the predicate.
 //   - If the input matches the predicate and (m <= len <= n), it will return the matched bytes.
+//   - If the context bound by parser.ParseContext is cancelled, it will return the fatal ctx.Err()
 //   - If the input is empty and m > 0, it will return io.EOF
 //   - If the number of matched bytes < m, it will return errors.ErrNotMatched
 func TakeWhileMinMax(min, max int, predicate parser.Predicate[byte]) parser.Parser[parser.Reader, []byte] {
@@ -29,7 +29,7 @@ func ExampleTakeWhileMinMax_noMatch() {
 	fmt.Printf("Match: '%s', Error: '%v', Remainder: '%s'", string(match), err, string(remainder))
 
 	// Output:
-	// Match: '', Error: 'not matched', Remainder: 'abc'
+	// Match: '', Error: 'not matched at offset 0', Remainder: 'abc'
 }
 
 func ExampleTakeWhileMinMax_endOfFile() {
@@ -41,7 +41,7 @@ func ExampleTakeWhileMinMax_endOfFile() {
 	fmt.Printf("Match: '%s', Error: '%v', Remainder: '%s'", string(match), err, string(remainder))
 
 	// Output:
-	// Match: '', Error: 'EOF', Remainder: ''
+	// Match: '', Error: 'EOF at offset 0', Remainder: ''
 }
 
 func ExampleTakeWhile1_match() {
@@ -65,7 +65,7 @@ func ExampleTakeWhile1_noMatch() {
 	fmt.Printf("Match: '%s', Error: '%v', Remainder: '%s'", string(match), err, string(remainder))
 
 	// Output:
-	// Match: '', Error: 'not matched', Remainder: 'abc'
+	// Match: '', Error: 'not matched at offset 0', Remainder: 'abc'
 }
 
 func ExampleTakeWhile1_endOfFile() {
@@ -77,7 +77,7 @@ func ExampleTakeWhile1_endOfFile() {
 	fmt.Printf("Match: '%s', Error: '%v', Remainder: '%s'", string(match), err, string(remainder))
 
 	// Output:
-	// Match: '', Error: 'EOF', Remainder: ''
+	// Match: '', Error: 'EOF at offset 0', Remainder: ''
 }
 
 func ExampleTakeWhile_match() {
@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"context"
+	"github.com/roblovelock/gobble/pkg/errors"
+)
+
+// contextCheckInterval is the number of calls to Canceller.Check between checks of the context.
+const contextCheckInterval = 1024
+
+// Canceller checks the context bound to a parse by ParseContext. Long running loops call Check on each iteration, and
+// the context is checked periodically. The zero value has no context.
+type Canceller struct {
+	ctx   context.Context
+	calls int
+}
+
+// ParseContext parses the input with the context bound to the parse. The repetition combinators and take-while
+// parsers stop when the context is cancelled.
+//   - If the context is cancelled, it will return ctx.Err() wrapped as a fatal error
+func ParseContext[R Reader, T any](ctx context.Context, p Parser[R, T], in R) (T, error) {
+	if err := ctx.Err(); err != nil {
+		var t T
+		return t, errors.NewFatalError(err)
+	}
+
+	s := NewSession()
+	s.ctx = ctx
+	return NewChild(p).ParseIn(s, in)
+}
+
+// ParseBytesContext is ParseContext for ParseBytes.
+//   - If the context is cancelled, it will return ctx.Err() wrapped as a fatal error
+func ParseBytesContext[R Reader, T any](ctx context.Context, p Parser[R, T], in []byte) (T, []byte, error) {
+	if err := ctx.Err(); err != nil {
+		var t T
+		return t, in, errors.NewFatalError(err)
+	}
+
+	s := NewBytesSession(in)
+	s.ctx = ctx
+	return NewChild(p).ParseBytesIn(s, in)
+}
+
+// Check returns the error of the context if it has been cancelled. The context is checked on the first call and
+// periodically after that.
+//   - If the context is cancelled, it will return ctx.Err() wrapped as a fatal error
+func (c *Canceller) Check() error {
+	if c.ctx == nil {
+		return nil
+	}
+	c.calls++
+	if c.calls%contextCheckInterval != 1 {
+		return nil
+	}
+	if err := c.ctx.Err(); err != nil {
+		return errors.NewFatalError(err)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package parser_test
+
+import (
+	"context"
+	"github.com/roblovelock/gobble/pkg/combinator"
+	"github.com/roblovelock/gobble/pkg/combinator/branch"
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/combinator/multi"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/roblovelock/gobble/pkg/parser/runes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+// cancelAfter returns a context which is cancelled by the n-th call to the returned function.
+func cancelAfter(n int) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	return ctx, func() {
+		calls++
+		if calls == n {
+			cancel()
+		}
+	}
+}
+
+func TestParseContext(t *testing.T) {
+	input := strings.Repeat("a", 5000)
+	tests := []struct {
+		name   string
+		parser func(tick func()) parser.Parser[parser.Reader, int]
+	}{
+		{
+			name: "many0",
+			parser: func(tick func()) parser.Parser[parser.Reader, int] {
+				return modifier.Count[parser.Reader, byte, []byte](multi.Many0(
+					modifier.Map(bytes.Byte('a'), func(b byte) (byte, error) { tick(); return b, nil }),
+				))
+			},
+		},
+		{
+			name: "fold many0",
+			parser: func(tick func()) parser.Parser[parser.Reader, int] {
+				return multi.FoldMany0(bytes.Byte('a'), 0, func(n int, _ byte) int { tick(); return n + 1 })
+			},
+		},
+		{
+			name: "separated0",
+			parser: func(tick func()) parser.Parser[parser.Reader, int] {
+				return multi.Separated0Count(
+					modifier.Map(bytes.Byte('a'), func(b byte) (byte, error) { tick(); return b, nil }),
+					bytes.TakeWhile(func(byte) bool { return false }),
+				)
+			},
+		},
+		{
+			name: "bytes take while",
+			parser: func(tick func()) parser.Parser[parser.Reader, int] {
+				return modifier.Count[parser.Reader, byte, []byte](
+					bytes.TakeWhile(func(byte) bool { tick(); return true }),
+				)
+			},
+		},
+		{
+			name: "runes take while",
+			parser: func(tick func()) parser.Parser[parser.Reader, int] {
+				return modifier.Map(
+					runes.TakeWhile(func(rune) bool { tick(); return true }),
+					func(s string) (int, error) { return len(s), nil },
+				)
+			},
+		},
+		{
+			name: "multi take while",
+			parser: func(tick func()) parser.Parser[parser.Reader, int] {
+				return modifier.Count[parser.Reader, byte, []byte](
+					multi.TakeWhileMinMax(bytes.Byte('a'), 0, 10000, func(byte) bool { tick(); return true }),
+				)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, tick := cancelAfter(10)
+			p := branch.Alt(tt.parser(tick), combinator.Success[parser.Reader](0))
+
+			var in parser.Reader = strings.NewReader(input)
+			_, err := parser.ParseContext(ctx, p, in)
+
+			assert.ErrorIs(t, err, context.Canceled)
+			assert.True(t, errors.IsFatal(err))
+			remain, err := io.ReadAll(in)
+			require.NoError(t, err)
+			assert.Equal(t, len(input), len(remain))
+
+			ctx, tick = cancelAfter(10)
+			p = branch.Alt(tt.parser(tick), combinator.Success[parser.Reader](0))
+
+			_, out, err := parser.ParseBytesContext(ctx, p, []byte(input))
+
+			assert.ErrorIs(t, err, context.Canceled)
+			assert.True(t, errors.IsFatal(err))
+			assert.Equal(t, len(input), len(out))
+		})
+	}
+}
+
+func TestParseContext_notCancelled(t *testing.T) {
+	p := multi.Many0(bytes.Byte('a'))
+
+	r, err := parser.ParseContext[parser.Reader](context.Background(), p, strings.NewReader("aab"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("aa"), r)
+
+	r, out, err := parser.ParseBytesContext(context.Background(), p, []byte("aab"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("aa"), r)
+	assert.Equal(t, "b", string(out))
+}
+
+func TestParseContext_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p := bytes.Byte('a')
+
+	_, err := parser.ParseContext[parser.Reader](ctx, p, strings.NewReader("a"))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.True(t, errors.IsFatal(err))
+
+	_, out, err := parser.ParseBytesContext(ctx, p, []byte("a"))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, "a", string(out))
+}
+
+func TestParseBytesContext_siblingSubSlice(t *testing.T) {
+	buf := []byte(strings.Repeat("a", 4000))
+	started, release := make(chan struct{}), make(chan struct{})
+	first := true
+	blocking := multi.Many0(modifier.Map(bytes.Byte('a'), func(b byte) (byte, error) {
+		if first {
+			first = false
+			close(started)
+			<-release
+		}
+		return b, nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error)
+	go func() {
+		_, _, err := parser.ParseBytesContext(ctx, blocking, buf[:2000])
+		errs <- err
+	}()
+	<-started
+	cancel()
+
+	r, out, err := multi.Many0(bytes.Byte('a')).ParseBytes(buf[2000:])
+	require.NoError(t, err)
+	assert.Len(t, r, 2000)
+	assert.Empty(t, out)
+
+	close(release)
+	assert.ErrorIs(t, <-errs, context.Canceled)
+}
@@ -3,91 +3,88 @@ package parser
 import (
 	"fmt"
 	"io"
+	"os"
+	"sync"
 	"time"
 )
 
 type (
 	parserDebug[R Reader, T any] struct {
-		parser             Parser[R, T]
-		callCount          int
-		successCount       int
-		errorCount         int
-		totalBytesConsumed int64
-		totalTime          time.Duration
-	}
-
-	callDebug[R Reader, T any] struct {
-		*parserDebug[R, T]
-		callTime      time.Duration
-		startOffset   int64
-		endOffset     int64
-		bytesConsumed int64
-		result        T
-		err           error
+		parser Child[R, T]
+		mu     sync.Mutex
+		totals RuleProfile
 	}
 )
 
+// debugTrace writes the trace of a parse which isn't already traced when it reaches a Debug parser.
+var debugTrace = TraceWriter(os.Stdout)
+
 func (d *parserDebug[R, T]) String() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	return fmt.Sprintf(
 		"calls: %d\ttime: %s\tbytes: %d\tsuccess: %d\terror: %d",
-		d.callCount, d.totalTime.String(), d.totalBytesConsumed, d.successCount, d.errorCount,
+		d.totals.Calls, d.totals.Time.String(), d.totals.Bytes, d.totals.Successes, d.totals.Failures,
 	)
 }
 
-func (d *callDebug[R, T]) String() string {
-	return fmt.Sprintf(
-		"start: %d\tend: %d\ttime: %d\tbytes: %d\tsuccess: '%v'\terror: '%v'\nTotals(%s)",
-		d.startOffset, d.endOffset, d.callTime.Nanoseconds(), d.bytesConsumed, d.result, d.err, d.parserDebug.String(),
-	)
+func (d *parserDebug[R, T]) Parse(in R) (T, error) {
+	return d.ParseSession(NewSession(), in)
 }
 
-func (d *parserDebug[R, T]) Parse(in R) (T, error) {
-	call := callDebug[R, T]{
-		parserDebug: d,
-	}
-	d.callCount++
-	call.startOffset, _ = in.Seek(0, io.SeekCurrent)
-	startTime := time.Unix(0, time.Now().UnixNano())
-	call.result, call.err = d.parser.Parse(in)
-	call.callTime = time.Since(startTime)
-	call.endOffset, _ = in.Seek(0, io.SeekCurrent)
-	d.totalTime += call.callTime
-	call.bytesConsumed = call.endOffset - call.startOffset
-	d.totalBytesConsumed += call.bytesConsumed
-	if call.err != nil {
-		d.errorCount++
-	} else {
-		d.successCount++
-	}
+func (d *parserDebug[R, T]) ParseBytes(in []byte) (T, []byte, error) {
+	return d.ParseBytesSession(NewBytesSession(in), in)
+}
 
-	fmt.Printf("%s\n", call.String())
-	return call.result, call.err
+func (d *parserDebug[R, T]) ParseSession(s *Session, in R) (T, error) {
+	defer d.enter(s)()
+	startOffset, _ := in.Seek(0, io.SeekCurrent)
+	startTime := time.Now()
+	r, err := d.parser.ParseIn(s, in)
+	endOffset, _ := in.Seek(0, io.SeekCurrent)
+	d.record(endOffset-startOffset, time.Since(startTime), err)
+	return r, err
 }
 
-func (d *parserDebug[R, T]) ParseBytes(in []byte) (T, []byte, error) {
-	var out []byte
-	call := callDebug[R, T]{
-		parserDebug: d,
+func (d *parserDebug[R, T]) ParseBytesSession(s *Session, in []byte) (T, []byte, error) {
+	defer d.enter(s)()
+	startTime := time.Now()
+	r, out, err := d.parser.ParseBytesIn(s, in)
+	d.record(int64(len(in)-len(out)), time.Since(startTime), err)
+	return r, out, err
+}
+
+func (d *parserDebug[R, T]) Describe() Grammar {
+	return NewGrammar(GrammarSequence, d.parser)
+}
+
+func (d *parserDebug[R, T]) Expected() []string {
+	return ExpectedOf(d.parser)
+}
+
+// enter traces the session to stdout, unless the parse is already traced.
+func (d *parserDebug[R, T]) enter(s *Session) func() {
+	if s.tracer != nil {
+		return func() {}
 	}
-	d.callCount++
-	call.startOffset = 0
-	startTime := time.Unix(0, time.Now().UnixNano())
-	call.result, out, call.err = d.parser.ParseBytes(in)
-	call.callTime = time.Since(startTime)
-	call.endOffset = int64(len(in) - len(out))
-	d.totalTime += call.callTime
-	call.bytesConsumed = call.endOffset - call.startOffset
-	d.totalBytesConsumed += call.bytesConsumed
-	if call.err != nil {
-		d.errorCount++
-	} else {
-		d.successCount++
+	s.tracer = &tracer{trace: debugTrace}
+	return func() {
+		s.tracer = nil
 	}
+}
 
-	fmt.Printf("%s\n", call.String())
-	return call.result, out, call.err
+func (d *parserDebug[R, T]) record(bytesConsumed int64, callTime time.Duration, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.totals.add(bytesConsumed, callTime, err)
 }
 
+// Debug writes a trace of each call of the parser, and the rules it calls, to stdout. Within a traced parse, such as
+// one reaching a parser Traced or another Debug parser first, the calls are nested in the enclosing trace instead. The
+// parser also keeps a RuleProfile of its calls across all parses, without the reparsed bytes and self time, which is
+// formatted by its String method.
+//
+// Deprecated: name the rules of the grammar with Rule, and trace a parse with Traced and TraceWriter.
 func Debug[R Reader, T any](p Parser[R, T]) Parser[R, T] {
-	return &parserDebug[R, T]{parser: p}
+	return &parserDebug[R, T]{parser: NewChild(Rule(fmt.Sprintf("%T", p), p))}
 }
@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"io"
+)
+
+// ErrIncomplete is returned by parsers in streaming mode when they need more input. The error is an
+// errors.IncompleteError, which reports how many more bytes are needed.
+var ErrIncomplete = errors.ErrIncomplete
+
+// NewError annotates err with the current offset of the reader. The reader should be positioned where the failure
+// happened, after any rewind has taken place. A BitReader reports its offset in bits, which is converted to the offset
+// of the byte containing the current bit.
+func NewError(in io.Seeker, err error) error {
+	return NewExpectedError(in, err, nil)
+}
+
+// NewBytesError annotates err with the position of the remaining input. The input should be the slice at the point
+// the failure happened.
+func NewBytesError(in []byte, err error) error {
+	return NewExpectedBytesError(in, err, nil)
+}
+
+// NewExpectedError annotates err with the current offset of the reader and a description of the expected input.
+func NewExpectedError(in io.Seeker, err error, expected []string) error {
+	if _, ok := errors.AsParseError(err); ok || err == nil {
+		return err
+	}
+	offset, _ := in.Seek(0, io.SeekCurrent)
+	if _, ok := in.(BitReader); ok {
+		offset /= 8
+	}
+	return errors.NewExpectedError(err, offset, io.SeekStart, expected)
+}
+
+// NewExpectedBytesError annotates err with the position of the remaining input and a description of the expected
+// input.
+func NewExpectedBytesError(in []byte, err error, expected []string) error {
+	return errors.NewExpectedError(err, -int64(len(in)), io.SeekEnd, expected)
+}
+
+// ExpectedOf returns the description of the input p expects to find, if p implements Expecter.
+func ExpectedOf(p any) []string {
+	if e, ok := p.(Expecter); ok {
+		return e.Expected()
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package parser
+
+import (
+	"fmt"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"reflect"
+	"strings"
+)
+
+const (
+	GrammarOpaque    GrammarKind = iota // a parser which can't describe itself. Name is its type
+	GrammarTerminal                     // input described by Name, such as 'a' or digit
+	GrammarEmpty                        // matches without consuming input
+	GrammarSequence                     // each child in order
+	GrammarChoice                       // the first child which matches
+	GrammarRepeat                       // the child from Min to Max times. Max is -1 if it is unbounded
+	GrammarNot                          // matches if the child doesn't, without consuming input
+	GrammarPeek                         // matches if the child does, without consuming input
+	GrammarRule                         // a rule called Name, which matches the child
+	GrammarReference                    // a reference to the rule called Name, used by tools which walk a grammar
+)
+
+type (
+	// GrammarKind is the kind of a Grammar.
+	GrammarKind int
+
+	// Grammar describes the structure of a parser. The children are parsers, which are described in turn by
+	// DescribeOf, or Grammar values. Describing the children lazily lets recursive grammars, built with Pointer, be
+	// walked without expanding forever.
+	Grammar struct {
+		Kind     GrammarKind
+		Name     string
+		Min      int
+		Max      int
+		Children []any
+	}
+
+	// Describer is implemented by parsers that can describe their structure, so grammars can be documented. See
+	// DescribeOf.
+	Describer interface {
+		Describe() Grammar
+	}
+)
+
+// DescribeOf returns the structure of the parser, or the Grammar itself if p is a Grammar. Parsers which aren't a
+// Describer are described as a terminal for each input they expect, or as opaque if they aren't an Expecter.
+func DescribeOf(p any) Grammar {
+	switch p := p.(type) {
+	case Grammar:
+		return p
+	case Describer:
+		return p.Describe()
+	}
+
+	expected := ExpectedOf(p)
+	switch len(expected) {
+	case 0:
+		return Grammar{Kind: GrammarOpaque, Name: typeName(p)}
+	case 1:
+		return Grammar{Kind: GrammarTerminal, Name: expected[0]}
+	}
+	children := make([]any, len(expected))
+	for i, e := range expected {
+		children[i] = Grammar{Kind: GrammarTerminal, Name: e}
+	}
+	return Grammar{Kind: GrammarChoice, Children: children}
+}
+
+// NewGrammar returns a Grammar of the kind with the children, which are parsers or Grammar values.
+func NewGrammar(kind GrammarKind, children ...any) Grammar {
+	for i, c := range children {
+		children[i] = unwrapChild(c)
+	}
+	return Grammar{Kind: kind, Children: children}
+}
+
+// NewRepeatGrammar returns a Grammar which repeats the child from min to max times. Max is -1 if it is unbounded.
+func NewRepeatGrammar(child any, min, max int) Grammar {
+	return Grammar{Kind: GrammarRepeat, Min: min, Max: max, Children: []any{unwrapChild(child)}}
+}
+
+// NewByteSetGrammar returns a terminal which matches one of the bytes in the set, named as a character class such as
+// [0-9a-f]. Sets with more than half of the bytes are named by the bytes they don't match, such as [^"].
+func NewByteSetGrammar(set *[256]bool) Grammar {
+	count := 0
+	for _, ok := range set {
+		if ok {
+			count++
+		}
+	}
+	if count == 1 {
+		for b, ok := range set {
+			if ok {
+				return Grammar{Kind: GrammarTerminal, Name: errors.Quote(byte(b))}
+			}
+		}
+	}
+
+	var name strings.Builder
+	name.WriteByte('[')
+	match := true
+	if count > len(set)/2 {
+		name.WriteByte('^')
+		match = false
+	}
+	for b := 0; b < len(set); b++ {
+		if set[b] != match {
+			continue
+		}
+		end := b
+		for end+1 < len(set) && set[end+1] == match {
+			end++
+		}
+		name.WriteString(classByte(byte(b)))
+		if end > b+1 {
+			name.WriteByte('-')
+		}
+		if end > b {
+			name.WriteString(classByte(byte(end)))
+		}
+		b = end
+	}
+	name.WriteByte(']')
+	return Grammar{Kind: GrammarTerminal, Name: name.String()}
+}
+
+// classByte is the byte as written in a character class. Bytes which aren't printable, or have a meaning in a class,
+// are written as #xNN.
+func classByte(b byte) string {
+	if b <= ' ' || b > '~' || strings.IndexByte(`-[]\^`, b) >= 0 {
+		return fmt.Sprintf("#x%02X", b)
+	}
+	return string(b)
+}
+
+// typeName is the name of the type of the parser, without its package, type parameters or "Parser" suffix.
+func typeName(p any) string {
+	if p == nil {
+		return "nil"
+	}
+	t := reflect.TypeOf(p)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	name := t.Name()
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		name = name[:i]
+	}
+	if trimmed := strings.TrimSuffix(name, "Parser"); trimmed != "" {
+		name = trimmed
+	}
+	return name
+}
@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"io"
+)
+
+type (
+	leftRecursiveParser[R Reader, T any] struct {
+		parser Child[R, T]
+	}
+
+	// seedKey is the start position of a left recursive call.
+	seedKey struct {
+		parser any
+		scope  any
+		offset int64
+	}
+
+	// seed is the longest match found so far, which is returned when the parser is re-entered at the same position.
+	// end is the offset after the match, or the length of the remaining input for ParseBytes.
+	seed[T any] struct {
+		result T
+		end    int64
+		grown  bool
+	}
+)
+
+func (o *leftRecursiveParser[R, T]) Parse(in R) (T, error) {
+	return o.ParseSession(NewSession(), in)
+}
+
+func (o *leftRecursiveParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
+	return o.ParseBytesSession(NewBytesSession(in), in)
+}
+
+func (o *leftRecursiveParser[R, T]) ParseSession(s *Session, in R) (T, error) {
+	start, _ := in.Seek(0, io.SeekCurrent)
+	key := seedKey{parser: o, scope: s.scope, offset: start}
+	if v, ok := s.Load(key); ok {
+		sd := v.(*seed[T])
+		if !sd.grown {
+			var t T
+			return t, NewError(in, errors.ErrNotMatched)
+		}
+		_, _ = in.Seek(sd.end, io.SeekStart)
+		return sd.result, nil
+	}
+
+	sd := &seed[T]{end: start}
+	s.Store(key, sd)
+	defer s.Delete(key)
+
+	for {
+		_, _ = in.Seek(start, io.SeekStart)
+		r, err := o.parser.ParseIn(s, in)
+		if err != nil {
+			if !sd.grown || errors.IsFatal(err) {
+				_, _ = in.Seek(start, io.SeekStart)
+				return r, err
+			}
+			break
+		}
+		end, _ := in.Seek(0, io.SeekCurrent)
+		if sd.grown && end <= sd.end {
+			break
+		}
+		sd.result, sd.end, sd.grown = r, end, true
+	}
+
+	_, _ = in.Seek(sd.end, io.SeekStart)
+	return sd.result, nil
+}
+
+func (o *leftRecursiveParser[R, T]) ParseBytesSession(s *Session, in []byte) (T, []byte, error) {
+	key := seedKey{parser: o, scope: s.scope, offset: s.Offset(in)}
+	if v, ok := s.Load(key); ok {
+		sd := v.(*seed[T])
+		if !sd.grown {
+			var t T
+			return t, in, NewBytesError(in, errors.ErrNotMatched)
+		}
+		return sd.result, in[int64(len(in))-sd.end:], nil
+	}
+
+	sd := &seed[T]{end: int64(len(in))}
+	s.Store(key, sd)
+	defer s.Delete(key)
+
+	for {
+		r, out, err := o.parser.ParseBytesIn(s, in)
+		if err != nil {
+			if !sd.grown || errors.IsFatal(err) {
+				return r, in, err
+			}
+			break
+		}
+		if sd.grown && int64(len(out)) >= sd.end {
+			break
+		}
+		sd.result, sd.end, sd.grown = r, int64(len(out)), true
+	}
+
+	return sd.result, in[int64(len(in))-sd.end:], nil
+}
+
+func (o *leftRecursiveParser[R, T]) Describe() Grammar {
+	return NewGrammar(GrammarSequence, o.parser)
+}
+
+func (o *leftRecursiveParser[R, T]) Expected() []string {
+	return ExpectedOf(o.parser)
+}
+
+// LeftRecursive allows a rule to refer to itself at the start of its own definition, such as
+// `expr = expr '+' term | term`, which would otherwise recurse until the stack overflows. The rule is normally
+// referenced through a Pointer to the LeftRecursive parser.
+//
+// When the rule is re-entered at the same position it fails, so the non-recursive alternatives provide an initial
+// match. The rule is then parsed again, with the re-entry returning the previous match, until the match stops getting
+// longer (Warth et al., "Packrat Parsers Can Support Left Recursion"). Indirect left recursion is supported when
+// every cycle passes through the same LeftRecursive parser. Parsers inside the cycle shouldn't be memoized, as their
+// results change as the match grows.
+func LeftRecursive[R Reader, T any](p Parser[R, T]) Parser[R, T] {
+	return &leftRecursiveParser[R, T]{parser: NewChild(p)}
+}
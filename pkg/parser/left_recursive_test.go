@@ -0,0 +1,118 @@
+package parser_test
+
+import (
+	"github.com/roblovelock/gobble/pkg/combinator/branch"
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/ascii"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+// expr = expr '-' num | num
+func subtraction() parser.Parser[parser.Reader, int64] {
+	var expr parser.Parser[parser.Reader, int64]
+	expr = parser.LeftRecursive(branch.Alt(
+		modifier.Map(
+			sequence.Pair(parser.Pointer(&expr), sequence.Preceded(bytes.Byte('-'), ascii.Int64())),
+			func(p parser.Pair[int64, int64]) (int64, error) {
+				return p.First - p.Second, nil
+			},
+		),
+		ascii.Int64(),
+	))
+	return expr
+}
+
+// expr = term '+' num | num
+// term = expr
+func indirect() parser.Parser[parser.Reader, int64] {
+	var expr parser.Parser[parser.Reader, int64]
+	term := parser.Pointer(&expr)
+	expr = parser.LeftRecursive(branch.Alt(
+		modifier.Map(
+			sequence.Pair(term, sequence.Preceded(bytes.Byte('+'), ascii.Int64())),
+			func(p parser.Pair[int64, int64]) (int64, error) {
+				return p.First + p.Second, nil
+			},
+		),
+		ascii.Int64(),
+	))
+	return expr
+}
+
+func TestLeftRecursive(t *testing.T) {
+	tests := []struct {
+		name       string
+		parser     parser.Parser[parser.Reader, int64]
+		input      string
+		wantMatch  int64
+		wantRemain string
+		wantErr    error
+	}{
+		{
+			name:    "empty input => no match",
+			parser:  subtraction(),
+			input:   "",
+			wantErr: errors.ErrNotMatched,
+		},
+		{
+			name:       "no match => no match",
+			parser:     subtraction(),
+			input:      "x",
+			wantRemain: "x",
+			wantErr:    errors.ErrNotMatched,
+		},
+		{
+			name:      "seed only => match",
+			parser:    subtraction(),
+			input:     "10",
+			wantMatch: 10,
+		},
+		{
+			name:      "left recursion => left associative",
+			parser:    subtraction(),
+			input:     "10-3-2",
+			wantMatch: 5,
+		},
+		{
+			name:       "partial growth => longest match",
+			parser:     subtraction(),
+			input:      "10-3-",
+			wantMatch:  7,
+			wantRemain: "-",
+		},
+		{
+			name:       "indirect left recursion => match",
+			parser:     indirect(),
+			input:      "1+2+3;",
+			wantMatch:  6,
+			wantRemain: ";",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := strings.NewReader(tt.input)
+			s, err := tt.parser.Parse(input)
+
+			assert.Equal(t, tt.wantMatch, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			remain, err := io.ReadAll(input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRemain, string(remain))
+
+			s, out, err := tt.parser.ParseBytes([]byte(tt.input))
+
+			assert.Equal(t, tt.wantMatch, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantRemain, string(out))
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package parser
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"io"
+)
+
+type (
+	// Limits are the resource limits of a parse, which protect against untrusted input. A zero value is unlimited.
+	Limits struct {
+		// MaxDepth is the maximum number of nested Pointer calls.
+		MaxDepth int
+		// MaxBytes is the maximum number of bytes consumed. It is checked by Pointer and the repetition combinators,
+		// and when the parse returns.
+		MaxBytes int64
+		// MaxRepetitions is the maximum number of times a repetition combinator, such as multi.Many0, applies its
+		// parser in one call.
+		MaxRepetitions int
+	}
+
+	// Limiter checks the Limits of a parse. A nil Limiter has no limits.
+	Limiter struct {
+		limits  Limits
+		session *Session
+		start   int64
+		depth   int
+	}
+
+	// Repetition guards the loop of a repetition combinator. It fails if the repeated parser succeeds without
+	// consuming any input, which would otherwise loop forever, if the Limits of the parse are exceeded, or if the
+	// context bound by ParseContext is cancelled.
+	Repetition struct {
+		limiter   *Limiter
+		canceller Canceller
+		count     int
+		offset    int64
+	}
+
+	limitedParser[R Reader, T any] struct {
+		parser Child[R, T]
+		limits Limits
+	}
+)
+
+// Enter records a nested call, such as a recursive rule. Leave must be called when the call returns, unless it fails.
+func (l *Limiter) Enter() error {
+	if l == nil {
+		return nil
+	}
+	if l.limits.MaxDepth > 0 && l.depth >= l.limits.MaxDepth {
+		return errors.NewLimitError(errors.ErrMaxDepth)
+	}
+	l.depth++
+	return nil
+}
+
+// Leave records the return of a nested call.
+func (l *Limiter) Leave() {
+	if l != nil {
+		l.depth--
+	}
+}
+
+// Check fails if more than the maximum number of bytes has been consumed from the reader.
+func (l *Limiter) Check(in Reader) error {
+	if l == nil || l.limits.MaxBytes <= 0 {
+		return nil
+	}
+	offset, _ := in.Seek(0, io.SeekCurrent)
+	if _, ok := in.(BitReader); ok {
+		offset /= 8
+	}
+	return l.checkOffset(offset)
+}
+
+// CheckBytes fails if more than the maximum number of bytes has been consumed, when the remaining input is in.
+func (l *Limiter) CheckBytes(in []byte) error {
+	if l == nil || l.limits.MaxBytes <= 0 {
+		return nil
+	}
+	return l.checkOffset(l.session.Offset(in))
+}
+
+func (l *Limiter) checkOffset(offset int64) error {
+	if offset-l.start > l.limits.MaxBytes {
+		return errors.NewLimitError(errors.ErrMaxBytes)
+	}
+	return nil
+}
+
+// NewRepetition starts guarding a repetition combinator at the current position of the reader.
+func NewRepetition(s *Session, in Reader) Repetition {
+	offset, _ := in.Seek(0, io.SeekCurrent)
+	return Repetition{limiter: s.limiter, canceller: s.Canceller(), offset: offset}
+}
+
+// NewBytesRepetition starts guarding a repetition combinator called by ParseBytesSession.
+func NewBytesRepetition(s *Session, in []byte) Repetition {
+	return Repetition{limiter: s.limiter, canceller: s.Canceller(), offset: int64(len(in))}
+}
+
+// Next records a successful repetition of the parser. It is Progress followed by Count.
+//   - If the parser didn't consume any input, it will return errors.ErrLimitExceeded wrapping errors.ErrNoProgress
+//   - If a limit is exceeded, it will return errors.ErrLimitExceeded
+func (r *Repetition) Next(in Reader) error {
+	if err := r.Progress(in); err != nil {
+		return err
+	}
+	return r.Count()
+}
+
+// NextBytes is Next for ParseBytes, where out is the remaining input.
+func (r *Repetition) NextBytes(out []byte) error {
+	if err := r.ProgressBytes(out); err != nil {
+		return err
+	}
+	return r.Count()
+}
+
+// Progress checks the reader has moved on since the last call, and the maximum bytes haven't been consumed. It is used
+// when one repetition is made of several parsers, such as an element and a separator.
+//   - If the reader hasn't moved, it will return errors.ErrLimitExceeded wrapping errors.ErrNoProgress
+//   - If a limit is exceeded, it will return errors.ErrLimitExceeded
+func (r *Repetition) Progress(in Reader) error {
+	offset, _ := in.Seek(0, io.SeekCurrent)
+	if offset == r.offset {
+		return errors.NewLimitError(errors.ErrNoProgress)
+	}
+	r.offset = offset
+	return r.limiter.Check(in)
+}
+
+// ProgressBytes is Progress for ParseBytes, where out is the remaining input.
+func (r *Repetition) ProgressBytes(out []byte) error {
+	if int64(len(out)) == r.offset {
+		return errors.NewLimitError(errors.ErrNoProgress)
+	}
+	r.offset = int64(len(out))
+	return r.limiter.CheckBytes(out)
+}
+
+// Count records a repetition of the parser.
+//   - If there are more than the maximum repetitions, it will return errors.ErrLimitExceeded
+//   - If the context is cancelled, it will return ctx.Err() wrapped as a fatal error
+func (r *Repetition) Count() error {
+	if err := r.canceller.Check(); err != nil {
+		return err
+	}
+	r.count++
+	if r.limiter != nil && r.limiter.limits.MaxRepetitions > 0 && r.count > r.limiter.limits.MaxRepetitions {
+		return errors.NewLimitError(errors.ErrMaxRepetitions)
+	}
+	return nil
+}
+
+func (o *limitedParser[R, T]) Parse(in R) (T, error) {
+	return o.ParseSession(NewSession(), in)
+}
+
+func (o *limitedParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
+	return o.ParseBytesSession(NewBytesSession(in), in)
+}
+
+func (o *limitedParser[R, T]) ParseSession(s *Session, in R) (T, error) {
+	start, _ := in.Seek(0, io.SeekCurrent)
+	startBytes := start
+	if _, ok := any(in).(BitReader); ok {
+		startBytes /= 8
+	}
+	l := o.enter(s, startBytes)
+	defer o.leave(s, l)
+
+	r, err := o.parser.ParseIn(s, in)
+	if err != nil {
+		return r, err
+	}
+	if err := s.limiter.Check(in); err != nil {
+		_, _ = in.Seek(start, io.SeekStart)
+		var t T
+		return t, err
+	}
+	return r, nil
+}
+
+func (o *limitedParser[R, T]) ParseBytesSession(s *Session, in []byte) (T, []byte, error) {
+	l := o.enter(s, s.Offset(in))
+	defer o.leave(s, l)
+
+	r, out, err := o.parser.ParseBytesIn(s, in)
+	if err != nil {
+		return r, in, err
+	}
+	if err := s.limiter.CheckBytes(out); err != nil {
+		var t T
+		return t, in, err
+	}
+	return r, out, nil
+}
+
+func (o *limitedParser[R, T]) Describe() Grammar {
+	return NewGrammar(GrammarSequence, o.parser)
+}
+
+func (o *limitedParser[R, T]) Expected() []string {
+	return ExpectedOf(o.parser)
+}
+
+// enter replaces the Limiter of the session, and returns the previous one.
+func (o *limitedParser[R, T]) enter(s *Session, start int64) *Limiter {
+	prev := s.limiter
+	s.limiter = &Limiter{limits: o.limits, session: s, start: start}
+	return prev
+}
+
+func (o *limitedParser[R, T]) leave(s *Session, prev *Limiter) {
+	s.limiter = prev
+}
+
+// Limited applies the resource limits to the parse, so untrusted input can't exhaust the stack or loop forever. The
+// limits are checked by Pointer and the repetition combinators, which fail with the fatal errors.ErrLimitExceeded.
+//   - If a limit is exceeded, it will return errors.ErrLimitExceeded, wrapping the limit, such as errors.ErrMaxDepth
+func Limited[R Reader, T any](p Parser[R, T], limits Limits) Parser[R, T] {
+	return &limitedParser[R, T]{parser: NewChild(p), limits: limits}
+}
@@ -0,0 +1,176 @@
+package parser_test
+
+import (
+	"github.com/roblovelock/gobble/pkg/combinator/branch"
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/combinator/multi"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+// list = '[' list* ']'
+func nestedLists() parser.Parser[parser.Reader, int] {
+	var list parser.Parser[parser.Reader, int]
+	list = modifier.Map(
+		sequence.Delimited(bytes.Byte('['), multi.Many0(parser.Pointer(&list)), bytes.Byte(']')),
+		func(items []int) (int, error) {
+			depth := 0
+			for _, d := range items {
+				if d > depth {
+					depth = d
+				}
+			}
+			return depth + 1, nil
+		},
+	)
+	return list
+}
+
+func TestLimited(t *testing.T) {
+	tests := []struct {
+		name       string
+		parser     parser.Parser[parser.Reader, int]
+		limits     parser.Limits
+		input      string
+		want       int
+		wantRemain string
+		wantErr    error
+	}{
+		{
+			name:   "unlimited => match",
+			parser: nestedLists(),
+			input:  "[[[]][]]",
+			want:   3,
+		},
+		{
+			name:       "within depth => match",
+			parser:     nestedLists(),
+			limits:     parser.Limits{MaxDepth: 3},
+			input:      "[[[]][]]x",
+			want:       3,
+			wantRemain: "x",
+		},
+		{
+			name:       "depth exceeded => error",
+			parser:     nestedLists(),
+			limits:     parser.Limits{MaxDepth: 2},
+			input:      "[[[]][]]x",
+			wantRemain: "[[[]][]]x",
+			wantErr:    errors.ErrMaxDepth,
+		},
+		{
+			name:       "bytes exceeded => error",
+			parser:     nestedLists(),
+			limits:     parser.Limits{MaxBytes: 4},
+			input:      "[[[]][]]x",
+			wantRemain: "[[[]][]]x",
+			wantErr:    errors.ErrMaxBytes,
+		},
+		{
+			name:       "bytes exceeded at end => error",
+			parser:     modifier.Count[parser.Reader, byte, []byte](multi.Many0(bytes.Byte('a'))),
+			limits:     parser.Limits{MaxBytes: 2},
+			input:      "aaa",
+			wantRemain: "aaa",
+			wantErr:    errors.ErrMaxBytes,
+		},
+		{
+			name:       "repetitions exceeded => error",
+			parser:     multi.Separated0Count(bytes.Byte('a'), bytes.Byte(',')),
+			limits:     parser.Limits{MaxRepetitions: 2},
+			input:      "a,a,a",
+			wantRemain: "a,a,a",
+			wantErr:    errors.ErrMaxRepetitions,
+		},
+		{
+			name:   "within repetitions => match",
+			parser: multi.Separated0Count(bytes.Byte('a'), bytes.Byte(',')),
+			limits: parser.Limits{MaxRepetitions: 3},
+			input:  "a,a,a",
+			want:   3,
+		},
+		{
+			name:       "many1 repetitions exceeded => first match counted",
+			parser:     modifier.Count[parser.Reader, byte, []byte](multi.Many1(bytes.Byte('a'))),
+			limits:     parser.Limits{MaxRepetitions: 2},
+			input:      "aaa",
+			wantRemain: "aaa",
+			wantErr:    errors.ErrMaxRepetitions,
+		},
+		{
+			name:   "many1 within repetitions => match",
+			parser: modifier.Count[parser.Reader, byte, []byte](multi.Many1(bytes.Byte('a'))),
+			limits: parser.Limits{MaxRepetitions: 3},
+			input:  "aaa",
+			want:   3,
+		},
+		{
+			name:       "no progress => error",
+			parser:     multi.Separated0Count(modifier.Optional(bytes.Byte('a')), modifier.Optional(bytes.Byte(','))),
+			input:      "a,a;",
+			wantRemain: "a,a;",
+			wantErr:    errors.ErrNoProgress,
+		},
+		{
+			name: "fatal in repetition => error",
+			parser: branch.Alt(
+				modifier.Count[parser.Reader, []byte, [][]byte](multi.Many0(modifier.Cut(bytes.Tag([]byte("ab"))))),
+				modifier.Value[parser.Reader, byte](bytes.Byte('a'), 0),
+			),
+			input:      "aba",
+			wantRemain: "aba",
+			wantErr:    io.EOF,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parser.Limited(tt.parser, tt.limits)
+
+			input := strings.NewReader(tt.input)
+			s, err := p.Parse(input)
+
+			assert.Equal(t, tt.want, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				assert.True(t, errors.IsFatal(err))
+			}
+
+			remain, err := io.ReadAll(input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRemain, string(remain))
+
+			s, out, err := p.ParseBytes([]byte(tt.input))
+
+			assert.Equal(t, tt.want, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantRemain, string(out))
+		})
+	}
+}
+
+func TestLimited_errorMessage(t *testing.T) {
+	p := parser.Limited(nestedLists(), parser.Limits{MaxDepth: 1})
+
+	_, err := p.Parse(strings.NewReader("[[]]"))
+	assert.ErrorIs(t, err, errors.ErrLimitExceeded)
+	assert.EqualError(t, err, "limit exceeded: maximum depth at offset 2")
+}
+
+func TestMany0_noProgress(t *testing.T) {
+	p := multi.Many0(modifier.Optional(bytes.Byte('a')))
+
+	_, err := p.Parse(strings.NewReader("aab"))
+	assert.ErrorIs(t, err, errors.ErrLimitExceeded)
+	assert.ErrorIs(t, err, errors.ErrNoProgress)
+
+	_, out, err := p.ParseBytes([]byte("aab"))
+	assert.ErrorIs(t, err, errors.ErrNoProgress)
+	assert.Equal(t, "aab", string(out))
+}
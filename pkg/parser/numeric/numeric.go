@@ -3,6 +3,7 @@ package numeric
 
 import (
 	"encoding/binary"
+	"github.com/roblovelock/gobble/pkg/errors"
 	"github.com/roblovelock/gobble/pkg/parser"
 	"io"
 	"math"
@@ -19,24 +20,35 @@ type (
 )
 
 func (o *endianParser[T]) Parse(in parser.Reader) (result T, err error) {
-	currentOffset, _ := in.Seek(0, io.SeekCurrent)
-	err = binary.Read(in, o.byteOrder, &result)
-	if err == io.ErrUnexpectedEOF {
-		_, _ = in.Seek(currentOffset, io.SeekStart)
-		err = io.EOF
+	b := make([]byte, intDataSize(&result))
+	n, err := io.ReadFull(in, b)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		_, _ = in.Seek(-int64(n), io.SeekCurrent)
+		return result, parser.NewError(in, errors.NewEOFError(len(b)-n))
 	}
-	return result, err
+	if err != nil {
+		return result, parser.NewError(in, err)
+	}
+	_, _ = readNumeric(b, o.byteOrder, &result)
+	return result, nil
 }
 
 func (o *endianParser[T]) ParseBytes(in []byte) (result T, out []byte, err error) {
 	out, err = readNumeric(in, o.byteOrder, &result)
+	if err != nil {
+		err = parser.NewBytesError(in, err)
+	}
 	return
 }
 
+func (o *endianParser[T]) Print(w io.Writer, v T) error {
+	return binary.Write(w, o.byteOrder, v)
+}
+
 func readNumeric(in []byte, order binary.ByteOrder, data any) ([]byte, error) {
 	size := intDataSize(data)
 	if len(in) < size {
-		return in, io.EOF
+		return in, errors.NewEOFError(size - len(in))
 	}
 
 	switch data := data.(type) {
@@ -28,7 +28,7 @@ func ExampleUInt8_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: %v", match, err, remainder)
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: []
+	// Match: 0, Error: 'EOF at offset 0', Remainder: []
 }
 
 func ExampleInt8_match() {
@@ -52,7 +52,7 @@ func ExampleInt8_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: %v", match, err, remainder)
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: []
+	// Match: 0, Error: 'EOF at offset 0', Remainder: []
 }
 
 func ExampleUInt16LE_match() {
@@ -76,7 +76,7 @@ func ExampleUInt16LE_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: %v", match, err, remainder)
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: []
+	// Match: 0, Error: 'EOF at offset 0', Remainder: []
 }
 
 func ExampleInt16LE_match() {
@@ -100,7 +100,7 @@ func ExampleInt16LE_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: %v", match, err, remainder)
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: []
+	// Match: 0, Error: 'EOF at offset 0', Remainder: []
 }
 
 func ExampleUInt16BE_match() {
@@ -124,7 +124,7 @@ func ExampleUInt16BE_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: %v", match, err, remainder)
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: []
+	// Match: 0, Error: 'EOF at offset 0', Remainder: []
 }
 
 func ExampleInt16BE_match() {
@@ -148,7 +148,7 @@ func ExampleInt16BE_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: %v", match, err, remainder)
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: []
+	// Match: 0, Error: 'EOF at offset 0', Remainder: []
 }
 
 func ExampleUInt32LE_match() {
@@ -172,7 +172,7 @@ func ExampleUInt32LE_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: %v", match, err, remainder)
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: []
+	// Match: 0, Error: 'EOF at offset 0', Remainder: []
 }
 
 func ExampleInt32LE_match() {
@@ -196,7 +196,7 @@ func ExampleInt32LE_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: %v", match, err, remainder)
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: []
+	// Match: 0, Error: 'EOF at offset 0', Remainder: []
 }
 
 func ExampleUInt32BE_match() {
@@ -220,7 +220,7 @@ func ExampleUInt32BE_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: %v", match, err, remainder)
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: []
+	// Match: 0, Error: 'EOF at offset 0', Remainder: []
 }
 
 func ExampleInt32BE_match() {
@@ -244,7 +244,7 @@ func ExampleInt32BE_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: %v", match, err, remainder)
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: []
+	// Match: 0, Error: 'EOF at offset 0', Remainder: []
 }
 
 func ExampleUInt64LE_match() {
@@ -268,7 +268,7 @@ func ExampleUInt64LE_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: %v", match, err, remainder)
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: []
+	// Match: 0, Error: 'EOF at offset 0', Remainder: []
 }
 
 func ExampleInt64LE_match() {
@@ -292,7 +292,7 @@ func ExampleInt64LE_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: %v", match, err, remainder)
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: []
+	// Match: 0, Error: 'EOF at offset 0', Remainder: []
 }
 
 func ExampleUInt64BE_match() {
@@ -316,7 +316,7 @@ func ExampleUInt64BE_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: %v", match, err, remainder)
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: []
+	// Match: 0, Error: 'EOF at offset 0', Remainder: []
 }
 
 func ExampleInt64BE_match() {
@@ -340,5 +340,5 @@ func ExampleInt64BE_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: %v", match, err, remainder)
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: []
+	// Match: 0, Error: 'EOF at offset 0', Remainder: []
 }
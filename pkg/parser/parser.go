@@ -22,6 +22,12 @@ type (
 		ParseBytes(in []byte) (T, []byte, error)
 	}
 
+	// Expecter is implemented by parsers that can describe the input they expect to find. The descriptions are used in
+	// error messages, e.g. "expected '[' or digit".
+	Expecter interface {
+		Expected() []string
+	}
+
 	Empty                 *struct{}
 	Predicate[T any]      func(T) bool
 	MapFunc[T, V any]     func(T) (V, error)
@@ -30,4 +36,74 @@ type (
 		First  A
 		Second B
 	}
+
+	// Tuple3 to Tuple8 hold the results of parsers of different types, in the order they were applied.
+	Tuple3[A, B, C any] struct {
+		First  A
+		Second B
+		Third  C
+	}
+
+	Tuple4[A, B, C, D any] struct {
+		First  A
+		Second B
+		Third  C
+		Fourth D
+	}
+
+	Tuple5[A, B, C, D, E any] struct {
+		First  A
+		Second B
+		Third  C
+		Fourth D
+		Fifth  E
+	}
+
+	Tuple6[A, B, C, D, E, F any] struct {
+		First  A
+		Second B
+		Third  C
+		Fourth D
+		Fifth  E
+		Sixth  F
+	}
+
+	Tuple7[A, B, C, D, E, F, G any] struct {
+		First   A
+		Second  B
+		Third   C
+		Fourth  D
+		Fifth   E
+		Sixth   F
+		Seventh G
+	}
+
+	Tuple8[A, B, C, D, E, F, G, H any] struct {
+		First   A
+		Second  B
+		Third   C
+		Fourth  D
+		Fifth   E
+		Sixth   F
+		Seventh G
+		Eighth  H
+	}
+
+	// Spanned is a value with the offsets of the input it was parsed from. End is the offset after the last byte.
+	Spanned[T any] struct {
+		Value      T
+		Start, End int64
+	}
+
+	// Diagnosed is a value with the errors which were recovered from while parsing it.
+	Diagnosed[T any] struct {
+		Value  T
+		Errors []error
+	}
+
+	// Position is an offset in the input with its 1 based line and column. Columns are counted in runes.
+	Position struct {
+		Offset       int64
+		Line, Column int
+	}
 )
@@ -7,13 +7,58 @@ type (
 )
 
 func (o *pointerParser[R, T]) Parse(in R) (T, error) {
-	return (*o.parser).Parse(in)
+	return o.ParseSession(NewSession(), in)
 }
 
 func (o *pointerParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
-	return (*o.parser).ParseBytes(in)
+	return o.ParseBytesSession(NewBytesSession(in), in)
 }
 
+func (o *pointerParser[R, T]) ParseSession(s *Session, in R) (T, error) {
+	l := s.limiter
+	if l == nil {
+		return NewChild(*o.parser).ParseIn(s, in)
+	}
+	if err := l.Enter(); err != nil {
+		var t T
+		return t, NewError(in, err)
+	}
+	defer l.Leave()
+	if err := l.Check(in); err != nil {
+		var t T
+		return t, NewError(in, err)
+	}
+	return NewChild(*o.parser).ParseIn(s, in)
+}
+
+func (o *pointerParser[R, T]) ParseBytesSession(s *Session, in []byte) (T, []byte, error) {
+	l := s.limiter
+	if l == nil {
+		return NewChild(*o.parser).ParseBytesIn(s, in)
+	}
+	if err := l.Enter(); err != nil {
+		var t T
+		return t, in, NewBytesError(in, err)
+	}
+	defer l.Leave()
+	if err := l.CheckBytes(in); err != nil {
+		var t T
+		return t, in, NewBytesError(in, err)
+	}
+	return NewChild(*o.parser).ParseBytesIn(s, in)
+}
+
+func (o *pointerParser[R, T]) Describe() Grammar {
+	return NewGrammar(GrammarSequence, *o.parser)
+}
+
+func (o *pointerParser[R, T]) Expected() []string {
+	return ExpectedOf(*o.parser)
+}
+
+// Pointer calls the parser the pointer refers to when it is used, so recursive grammars can refer to a rule before it
+// is defined. The nesting of Pointer calls is limited by Limits.MaxDepth when the parse is Limited.
+//   - If a limit is exceeded, it will return errors.ErrLimitExceeded
 func Pointer[R Reader, T any](p *Parser[R, T]) Parser[R, T] {
 	return &pointerParser[R, T]{parser: p}
 }
@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"compress/gzip"
+	"io"
+	"sort"
+)
+
+// protoBuffer encodes the fields of a protocol buffer message.
+type protoBuffer []byte
+
+func (b *protoBuffer) varint(v uint64) {
+	for v >= 0x80 {
+		*b = append(*b, byte(v)|0x80)
+		v >>= 7
+	}
+	*b = append(*b, byte(v))
+}
+
+func (b *protoBuffer) uint64(field int, v uint64) {
+	b.varint(uint64(field) << 3)
+	b.varint(v)
+}
+
+func (b *protoBuffer) bytes(field int, v []byte) {
+	b.varint(uint64(field)<<3 | 2)
+	b.varint(uint64(len(v)))
+	*b = append(*b, v...)
+}
+
+func (b *protoBuffer) packed(field int, vs []uint64) {
+	var p protoBuffer
+	for _, v := range vs {
+		p.varint(v)
+	}
+	b.bytes(field, p)
+}
+
+// WritePprof writes the profile to w in the gzipped protocol buffer format read by "go tool pprof". Each rule is a
+// function, and each sample is a stack of rules with the number of calls and the time spent in the innermost rule.
+func (p *Profiler) WritePprof(w io.Writer) error {
+	p.mu.Lock()
+	samples := make([]*profileSample, 0, len(p.samples))
+	for _, s := range p.samples {
+		samples = append(samples, s)
+	}
+	p.mu.Unlock()
+	sort.Slice(samples, func(i, j int) bool {
+		return lessStack(samples[i].stack, samples[j].stack)
+	})
+
+	strs := []string{""}
+	index := map[string]uint64{"": 0}
+	str := func(s string) uint64 {
+		i, ok := index[s]
+		if !ok {
+			i = uint64(len(strs))
+			strs = append(strs, s)
+			index[s] = i
+		}
+		return i
+	}
+	valueType := func(typ, unit string) protoBuffer {
+		var v protoBuffer
+		v.uint64(1, str(typ))
+		v.uint64(2, str(unit))
+		return v
+	}
+
+	var out protoBuffer
+	out.bytes(1, valueType("calls", "count"))
+	out.bytes(1, valueType("time", "nanoseconds"))
+
+	ids := map[string]uint64{}
+	var rules []string
+	for _, s := range samples {
+		locations := make([]uint64, len(s.stack))
+		for i, rule := range s.stack {
+			id, ok := ids[rule]
+			if !ok {
+				id = uint64(len(ids) + 1)
+				ids[rule] = id
+				rules = append(rules, rule)
+			}
+			// the innermost rule is first
+			locations[len(s.stack)-1-i] = id
+		}
+
+		var sample protoBuffer
+		sample.packed(1, locations)
+		sample.packed(2, []uint64{uint64(s.calls), uint64(s.time.Nanoseconds())})
+		out.bytes(2, sample)
+	}
+
+	for i, rule := range rules {
+		id := uint64(i + 1)
+		var line protoBuffer
+		line.uint64(1, id)
+
+		var location protoBuffer
+		location.uint64(1, id)
+		location.bytes(4, line)
+		out.bytes(4, location)
+
+		var function protoBuffer
+		function.uint64(1, id)
+		function.uint64(2, str(rule))
+		function.uint64(3, str(rule))
+		out.bytes(5, function)
+	}
+
+	out.bytes(11, valueType("time", "nanoseconds"))
+	for _, s := range strs {
+		out.bytes(6, []byte(s))
+	}
+
+	gz := gzip.NewWriter(w)
+	if _, err := gz.Write(out); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func lessStack(a, b []string) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
@@ -0,0 +1,47 @@
+package parser
+
+import (
+	"bytes"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"io"
+)
+
+type (
+	// Printer is implemented by parsers which can also encode a value, so a grammar can be used to write the input it
+	// reads. Printing a value and parsing the output returns the same value. Parsers which match a constant, such as a
+	// tag, print the constant when given the zero value, so they can be printed as part of a sequence without a value.
+	// If a value can't be produced by the parser, the printer returns errors.ErrNotMatched.
+	Printer[T any] interface {
+		Print(w io.Writer, v T) error
+	}
+
+	// Codec is a parser which is also a Printer.
+	Codec[R Reader, T any] interface {
+		Parser[R, T]
+		Printer[T]
+	}
+
+	// BitWriter is passed to the printers of bit parsers. Bits are written most significant first.
+	BitWriter interface {
+		io.Writer
+		WriteBits(v uint64, n uint8) error
+	}
+)
+
+// Print encodes v to w with p. If p, or one of the parsers it's built from, isn't a Printer, it returns
+// errors.ErrNotSupported.
+func Print[R Reader, T any](p Parser[R, T], w io.Writer, v T) error {
+	if printer, ok := p.(Printer[T]); ok {
+		return printer.Print(w, v)
+	}
+	return errors.ErrNotSupported
+}
+
+// PrintBytes encodes v with p, and returns the output.
+func PrintBytes[R Reader, T any](p Parser[R, T], v T) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := Print(p, &buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
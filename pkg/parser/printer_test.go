@@ -0,0 +1,158 @@
+package parser_test
+
+import (
+	"github.com/roblovelock/gobble/pkg/combinator/branch"
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/combinator/multi"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/bits"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/roblovelock/gobble/pkg/parser/numeric"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"testing"
+)
+
+type header struct {
+	width, height uint32
+	flags         parser.Tuple3[uint8, uint8, uint8]
+	names         [][]byte
+	compressed    bool
+}
+
+func headerCodec() parser.Parser[parser.Reader, header] {
+	name := sequence.Preceded(bytes.Byte('"'), sequence.Terminated(bytes.Take(3), bytes.Byte('"')))
+	return modifier.Bimap(
+		sequence.Preceded(
+			bytes.Tag([]byte("HDR")),
+			sequence.Tuple5(
+				numeric.Uint32BE(),
+				numeric.Uint32LE(),
+				bits.Bits(sequence.Tuple3(bits.Take[uint8](1), bits.Take[uint8](3), bits.Take[uint8](4))),
+				sequence.Delimited(bytes.Byte('['), multi.Separated0(name, bytes.Byte(',')), bytes.Byte(']')),
+				branch.Alt(
+					modifier.Value(bytes.Tag([]byte("yes")), true),
+					modifier.Value(bytes.Tag([]byte("no")), false),
+				),
+			),
+		),
+		func(t parser.Tuple5[uint32, uint32, parser.Tuple3[uint8, uint8, uint8], [][]byte, bool]) (header, error) {
+			return header{width: t.First, height: t.Second, flags: t.Third, names: t.Fourth, compressed: t.Fifth}, nil
+		},
+		func(h header) (parser.Tuple5[uint32, uint32, parser.Tuple3[uint8, uint8, uint8], [][]byte, bool], error) {
+			return parser.Tuple5[uint32, uint32, parser.Tuple3[uint8, uint8, uint8], [][]byte, bool]{
+				First: h.width, Second: h.height, Third: h.flags, Fourth: h.names, Fifth: h.compressed,
+			}, nil
+		},
+	)
+}
+
+func TestPrint_roundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		value header
+		want  string
+	}{
+		{
+			name: "all fields => encoded",
+			value: header{
+				width:      256,
+				height:     2,
+				flags:      parser.Tuple3[uint8, uint8, uint8]{First: 1, Second: 5, Third: 12},
+				names:      [][]byte{[]byte("abc"), []byte("def")},
+				compressed: true,
+			},
+			want: "HDR\x00\x00\x01\x00\x02\x00\x00\x00\xdc[\"abc\",\"def\"]yes",
+		},
+		{
+			name:  "empty list => encoded",
+			value: header{names: [][]byte{}},
+			want:  "HDR\x00\x00\x00\x00\x00\x00\x00\x00\x00[]no",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := headerCodec()
+
+			out, err := parser.PrintBytes(p, tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(out))
+
+			v, remain, err := p.ParseBytes(out)
+			require.NoError(t, err)
+			assert.Empty(t, remain)
+			assert.Equal(t, tt.value, v)
+		})
+	}
+}
+
+func TestPrint_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		print   func() ([]byte, error)
+		wantErr error
+	}{
+		{
+			name: "map => not supported",
+			print: func() ([]byte, error) {
+				return parser.PrintBytes(modifier.Map(bytes.Take(1), func(b []byte) (int, error) {
+					return strconv.Atoi(string(b))
+				}), 1)
+			},
+			wantErr: errors.ErrNotSupported,
+		},
+		{
+			name: "wrong length => not matched",
+			print: func() ([]byte, error) {
+				return parser.PrintBytes(bytes.Take(2), []byte("a"))
+			},
+			wantErr: errors.ErrNotMatched,
+		},
+		{
+			name: "wrong constant => not matched",
+			print: func() ([]byte, error) {
+				return parser.PrintBytes(bytes.Byte('a'), 'b')
+			},
+			wantErr: errors.ErrNotMatched,
+		},
+		{
+			name: "alternative matched by an earlier one => not matched",
+			print: func() ([]byte, error) {
+				return parser.PrintBytes(branch.Alt(bytes.Tag([]byte("a")), bytes.Tag([]byte("ab"))), []byte("ab"))
+			},
+			wantErr: errors.ErrNotMatched,
+		},
+		{
+			name: "value too large for bits => overflow",
+			print: func() ([]byte, error) {
+				return parser.PrintBytes(bits.Bits(sequence.Pair(bits.Take[uint8](4), bits.Take[uint8](4))),
+					parser.Pair[uint8, uint8]{First: 16})
+			},
+			wantErr: bits.ErrBitsOverflow,
+		},
+		{
+			name: "unaligned bits => remaining bits",
+			print: func() ([]byte, error) {
+				return parser.PrintBytes(bits.Bits(bits.Take[uint8](4)), 1)
+			},
+			wantErr: bits.ErrRemainingBits,
+		},
+		{
+			name: "no matching alternative => not matched",
+			print: func() ([]byte, error) {
+				return parser.PrintBytes(branch.Alt(bytes.Byte('a'), bytes.Byte('b')), 'c')
+			},
+			wantErr: errors.ErrNotMatched,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.print()
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+type (
+	// RuleProfile is the work done by a Rule in the parses recorded by a Profiler.
+	RuleProfile struct {
+		Rule      string
+		Calls     int
+		Successes int
+		Failures  int
+		// Bytes is the number of bytes consumed by successful calls.
+		Bytes int64
+		// Reparsed is the number of bytes consumed by successful calls which had already been consumed before the
+		// call, because the parser rewound the input to try an alternative.
+		Reparsed int64
+		// Time is the time taken by the calls, including the rules they called.
+		Time time.Duration
+		// SelfTime is the time taken by the calls, excluding the rules they called.
+		SelfTime time.Duration
+	}
+
+	// Profiler records a RuleProfile for each Rule called by a parse. Its Trace method is passed to Traced. A Profiler
+	// can be used for several parses, but only one at a time.
+	Profiler struct {
+		mu       sync.Mutex
+		rules    map[string]*RuleProfile
+		samples  map[string]*profileSample
+		stack    []profileFrame
+		furthest int64
+	}
+
+	// profileFrame is a call in progress.
+	profileFrame struct {
+		rule     string
+		furthest int64
+		children time.Duration
+	}
+
+	// profileSample is the work done by calls with the same stack of rules, outermost first.
+	profileSample struct {
+		stack []string
+		calls int64
+		time  time.Duration
+	}
+)
+
+// NewProfiler returns an empty Profiler.
+func NewProfiler() *Profiler {
+	return &Profiler{rules: map[string]*RuleProfile{}, samples: map[string]*profileSample{}}
+}
+
+// Trace records the event. It is a TraceFunc.
+func (p *Profiler) Trace(e TraceEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch e.Kind {
+	case TraceBegin:
+		// Only input consumed earlier in the same parse can be reparsed, however many top level rules it calls.
+		if len(p.stack) == 0 {
+			p.furthest = e.Start
+		}
+		return
+	case TraceEnter:
+		p.stack = append(p.stack, profileFrame{rule: e.Rule, furthest: p.furthest})
+		return
+	}
+	if len(p.stack) == 0 {
+		return
+	}
+
+	frame := p.stack[len(p.stack)-1]
+	p.stack = p.stack[:len(p.stack)-1]
+	self := e.Duration - frame.children
+	if len(p.stack) > 0 {
+		p.stack[len(p.stack)-1].children += e.Duration
+	}
+
+	r, ok := p.rules[e.Rule]
+	if !ok {
+		r = &RuleProfile{Rule: e.Rule}
+		p.rules[e.Rule] = r
+	}
+	r.add(e.End-e.Start, e.Duration, e.Err)
+	r.SelfTime += self
+	if e.Err == nil {
+		if reparsed := min64(e.End, frame.furthest) - e.Start; reparsed > 0 {
+			r.Reparsed += reparsed
+		}
+	}
+	if e.End > p.furthest {
+		p.furthest = e.End
+	}
+
+	stack := make([]string, 0, len(p.stack)+1)
+	for _, f := range p.stack {
+		stack = append(stack, f.rule)
+	}
+	stack = append(stack, e.Rule)
+	key := strings.Join(stack, "\x00")
+	s, ok := p.samples[key]
+	if !ok {
+		s = &profileSample{stack: stack}
+		p.samples[key] = s
+	}
+	s.calls++
+	s.time += self
+}
+
+// add records a call of the rule which consumed the bytes and took the time d.
+func (r *RuleProfile) add(consumed int64, d time.Duration, err error) {
+	r.Calls++
+	r.Time += d
+	if err != nil {
+		r.Failures++
+	} else {
+		r.Successes++
+		r.Bytes += consumed
+	}
+}
+
+// Rules returns the profile of each rule, sorted by the time spent in the rule itself, most first.
+func (p *Profiler) Rules() []RuleProfile {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	rules := make([]RuleProfile, 0, len(p.rules))
+	for _, r := range p.rules {
+		rules = append(rules, *r)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].SelfTime != rules[j].SelfTime {
+			return rules[i].SelfTime > rules[j].SelfTime
+		}
+		return rules[i].Rule < rules[j].Rule
+	})
+	return rules
+}
+
+// WriteTable writes the profile of each rule to w as a table, in the order of Rules.
+func (p *Profiler) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintln(tw, "rule\tcalls\tsuccesses\tfailures\tbytes\treparsed\ttime\tself\t")
+	for _, r := range p.Rules() {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%d\t%s\t%s\t\n",
+			r.Rule, r.Calls, r.Successes, r.Failures, r.Bytes, r.Reparsed, r.Time, r.SelfTime)
+	}
+	return tw.Flush()
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
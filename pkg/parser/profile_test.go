@@ -0,0 +1,157 @@
+package parser_test
+
+import (
+	stdbytes "bytes"
+	"compress/gzip"
+	"github.com/roblovelock/gobble/pkg/combinator/branch"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// stmt = word '!' | word
+func profiledStatement() parser.Parser[parser.Reader, []byte] {
+	word := parser.Rule("word", bytes.TakeWhile1(func(b byte) bool { return b >= 'a' && b <= 'z' }))
+	return parser.Rule("stmt", branch.Alt(sequence.Terminated(word, bytes.Byte('!')), word))
+}
+
+func TestProfiler(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []parser.RuleProfile
+	}{
+		{
+			name:  "backtracked => reparsed",
+			input: "abc?",
+			want: []parser.RuleProfile{
+				{Rule: "stmt", Calls: 1, Successes: 1, Bytes: 3},
+				{Rule: "word", Calls: 2, Successes: 2, Bytes: 6, Reparsed: 3},
+			},
+		},
+		{
+			name:  "not backtracked => not reparsed",
+			input: "abc!",
+			want: []parser.RuleProfile{
+				{Rule: "stmt", Calls: 1, Successes: 1, Bytes: 4},
+				{Rule: "word", Calls: 1, Successes: 1, Bytes: 3},
+			},
+		},
+		{
+			name:  "no match => failures",
+			input: "?",
+			want: []parser.RuleProfile{
+				{Rule: "stmt", Calls: 1, Failures: 1},
+				{Rule: "word", Calls: 2, Failures: 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profiler := parser.NewProfiler()
+			p := parser.Traced(profiledStatement(), profiler.Trace)
+
+			_, _ = p.Parse(strings.NewReader(tt.input))
+			assert.Equal(t, tt.want, withoutTimes(profiler.Rules()))
+
+			profiler = parser.NewProfiler()
+			p = parser.Traced(profiledStatement(), profiler.Trace)
+
+			_, _, _ = p.ParseBytes([]byte(tt.input))
+			assert.Equal(t, tt.want, withoutTimes(profiler.Rules()))
+		})
+	}
+}
+
+func withoutTimes(rules []parser.RuleProfile) []parser.RuleProfile {
+	for i := range rules {
+		rules[i].Time, rules[i].SelfTime = 0, 0
+	}
+	// sort by name, as the order by time isn't predictable
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Rule < rules[j].Rule })
+	return rules
+}
+
+func TestProfiler_topLevelRules(t *testing.T) {
+	// stmt = a | b, without a rule of its own, so the input b reparses was consumed by a sibling top level rule
+	word := parser.Rule("word", bytes.TakeWhile1(func(b byte) bool { return b >= 'a' && b <= 'z' }))
+	stmt := branch.Alt(parser.Rule("a", sequence.Terminated(word, bytes.Byte('!'))), parser.Rule("b", word))
+	want := []parser.RuleProfile{
+		{Rule: "a", Calls: 1, Failures: 1},
+		{Rule: "b", Calls: 1, Successes: 1, Bytes: 3, Reparsed: 3},
+		{Rule: "word", Calls: 2, Successes: 2, Bytes: 6, Reparsed: 3},
+	}
+
+	profiler := parser.NewProfiler()
+	_, err := parser.Traced(stmt, profiler.Trace).Parse(strings.NewReader("abc?"))
+	require.NoError(t, err)
+	assert.Equal(t, want, withoutTimes(profiler.Rules()))
+
+	profiler = parser.NewProfiler()
+	_, _, err = parser.Traced(stmt, profiler.Trace).ParseBytes([]byte("abc?"))
+	require.NoError(t, err)
+	assert.Equal(t, want, withoutTimes(profiler.Rules()))
+}
+
+func TestProfiler_severalParses(t *testing.T) {
+	profiler := parser.NewProfiler()
+	p := parser.Traced(profiledStatement(), profiler.Trace)
+	for i := 0; i < 2; i++ {
+		_, _, err := p.ParseBytes([]byte("abc!"))
+		require.NoError(t, err)
+	}
+
+	// each parse starts again at offset 0, which isn't input reparsed by the second parse
+	assert.Equal(t, []parser.RuleProfile{
+		{Rule: "stmt", Calls: 2, Successes: 2, Bytes: 8},
+		{Rule: "word", Calls: 2, Successes: 2, Bytes: 6},
+	}, withoutTimes(profiler.Rules()))
+}
+
+func TestProfiler_WriteTable(t *testing.T) {
+	profiler := parser.NewProfiler()
+	_, err := parser.Traced(profiledStatement(), profiler.Trace).Parse(strings.NewReader("abc?"))
+	require.NoError(t, err)
+
+	var out strings.Builder
+	require.NoError(t, profiler.WriteTable(&out))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, []string{"rule", "calls", "successes", "failures", "bytes", "reparsed", "time", "self"},
+		strings.Fields(lines[0]))
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case "word":
+			assert.Equal(t, []string{"2", "2", "0", "6", "3"}, fields[1:6])
+		case "stmt":
+			assert.Equal(t, []string{"1", "1", "0", "3", "0"}, fields[1:6])
+		default:
+			t.Errorf("unexpected rule %q", fields[0])
+		}
+	}
+}
+
+func TestProfiler_WritePprof(t *testing.T) {
+	profiler := parser.NewProfiler()
+	_, err := parser.Traced(profiledStatement(), profiler.Trace).Parse(strings.NewReader("abc?"))
+	require.NoError(t, err)
+
+	var out stdbytes.Buffer
+	require.NoError(t, profiler.WritePprof(&out))
+
+	gz, err := gzip.NewReader(&out)
+	require.NoError(t, err)
+	profile, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	for _, s := range []string{"calls", "count", "time", "nanoseconds", "stmt", "word"} {
+		assert.Contains(t, string(profile), s)
+	}
+}
@@ -15,10 +15,10 @@ var escapedStringParserInstance = &escapedStringParser{}
 
 func (o *escapedStringParser) Parse(in parser.Reader) (string, error) {
 	if b, err := in.ReadByte(); err != nil {
-		return "", err
+		return "", parser.NewError(in, err)
 	} else if b != '"' {
 		_, _ = in.Seek(-1, io.SeekCurrent)
-		return "", errors.ErrNotMatched
+		return "", parser.NewError(in, errors.ErrNotMatched)
 	}
 
 	var result []rune
@@ -28,7 +28,7 @@ func (o *escapedStringParser) Parse(in parser.Reader) (string, error) {
 		r, _, err := in.ReadRune()
 		if err != nil {
 			_, _ = in.Seek(startOffset-1, io.SeekStart)
-			return "", err
+			return "", parser.NewError(in, err)
 		}
 
 		if r == '"' {
@@ -40,7 +40,7 @@ func (o *escapedStringParser) Parse(in parser.Reader) (string, error) {
 			r, err = readSpecial(in)
 			if err != nil {
 				_, _ = in.Seek(startOffset-1, io.SeekStart)
-				return "", err
+				return "", parser.NewError(in, err)
 			}
 			result = append(result, r)
 			currentOffset, _ = in.Seek(0, io.SeekCurrent)
@@ -2,8 +2,8 @@
 package runes
 
 import (
+	"github.com/roblovelock/gobble/pkg/errors"
 	"github.com/roblovelock/gobble/pkg/parser"
-	"io"
 	"unicode/utf8"
 )
 
@@ -11,16 +11,22 @@ type (
 	oneParser struct{}
 )
 
-var oneParserInstance = &oneParser{}
+var (
+	oneParserInstance = &oneParser{}
+	oneExpected       = []string{"any character"}
+)
 
 func (o *oneParser) Parse(in parser.Reader) (rune, error) {
 	r, _, err := in.ReadRune()
-	return r, err
+	if err != nil {
+		return 0, parser.NewExpectedError(in, err, oneExpected)
+	}
+	return r, nil
 }
 
 func (o *oneParser) ParseBytes(in []byte) (rune, []byte, error) {
 	if len(in) == 0 {
-		return 0, in, io.EOF
+		return 0, in, parser.NewExpectedBytesError(in, errors.NewEOFError(1), oneExpected)
 	}
 
 	if c := in[0]; c < utf8.RuneSelf {
@@ -31,6 +37,10 @@ func (o *oneParser) ParseBytes(in []byte) (rune, []byte, error) {
 	return ch, in[size:], nil
 }
 
+func (o *oneParser) Expected() []string {
+	return oneExpected
+}
+
 // One reads a single rune
 //
 //   - If the input isn't empty, it will return a single rune.
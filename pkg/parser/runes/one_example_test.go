@@ -29,5 +29,5 @@ func ExampleOne_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: %v", match, err, remainder)
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: []
+	// Match: 0, Error: 'unexpected end of input, expected any character at offset 0', Remainder: []
 }
@@ -10,7 +10,8 @@ import (
 
 type (
 	oneOfParser struct {
-		runes map[rune]bool
+		runes    map[rune]bool
+		expected []string
 	}
 )
 
@@ -18,7 +19,7 @@ func (o *oneOfParser) Parse(in parser.Reader) (rune, error) {
 	r, i, err := in.ReadRune()
 	if err != nil {
 		_, _ = in.Seek(-int64(i), io.SeekCurrent)
-		return 0, err
+		return 0, parser.NewExpectedError(in, err, o.expected)
 	}
 
 	if o.runes[r] {
@@ -26,12 +27,12 @@ func (o *oneOfParser) Parse(in parser.Reader) (rune, error) {
 	}
 
 	_, _ = in.Seek(-int64(i), io.SeekCurrent)
-	return 0, errors.ErrNotMatched
+	return 0, parser.NewExpectedError(in, errors.ErrNotMatched, o.expected)
 }
 
 func (o *oneOfParser) ParseBytes(in []byte) (ch rune, out []byte, err error) {
 	if len(in) == 0 {
-		return 0, in, io.EOF
+		return 0, in, parser.NewExpectedBytesError(in, errors.NewEOFError(1), o.expected)
 	}
 
 	if c := in[0]; c < utf8.RuneSelf {
@@ -47,7 +48,11 @@ func (o *oneOfParser) ParseBytes(in []byte) (ch rune, out []byte, err error) {
 		return
 	}
 
-	return 0, in, errors.ErrNotMatched
+	return 0, in, parser.NewExpectedBytesError(in, errors.ErrNotMatched, o.expected)
+}
+
+func (o *oneOfParser) Expected() []string {
+	return o.expected
 }
 
 // OneOf matches one of the argument runes
@@ -55,7 +60,11 @@ func (o *oneOfParser) ParseBytes(in []byte) (ch rune, out []byte, err error) {
 //   - If the input is empty, it will return io.EOF
 //   - If the input doesn't match the argument, it will return errors.ErrNotMatched
 func OneOf(runes ...rune) parser.Parser[parser.Reader, rune] {
-	return &oneOfParser{runes: utils.NewLookupMap(runes)}
+	expected := make([]string, len(runes))
+	for i, r := range runes {
+		expected[i] = errors.Quote(r)
+	}
+	return &oneOfParser{runes: utils.NewLookupMap(runes), expected: expected}
 }
 
 // OneOf0 matches zero or more runes matching one of the argument runes
@@ -9,7 +9,8 @@ import (
 
 type (
 	runeParser struct {
-		r rune
+		r        rune
+		expected []string
 	}
 )
 
@@ -17,12 +18,12 @@ func (o *runeParser) Parse(in parser.Reader) (rune, error) {
 	b, i, err := in.ReadRune()
 	if err != nil {
 		_, _ = in.Seek(-int64(i), io.SeekCurrent)
-		return 0, err
+		return 0, parser.NewExpectedError(in, err, o.expected)
 	}
 
 	if b != o.r {
 		_, _ = in.Seek(-int64(i), io.SeekCurrent)
-		return 0, errors.ErrNotMatched
+		return 0, parser.NewExpectedError(in, errors.ErrNotMatched, o.expected)
 	}
 
 	return b, nil
@@ -30,7 +31,7 @@ func (o *runeParser) Parse(in parser.Reader) (rune, error) {
 
 func (o *runeParser) ParseBytes(in []byte) (ch rune, out []byte, err error) {
 	if len(in) == 0 {
-		return 0, in, io.EOF
+		return 0, in, parser.NewExpectedBytesError(in, errors.NewEOFError(1), o.expected)
 	}
 
 	if c := in[0]; c < utf8.RuneSelf {
@@ -46,7 +47,11 @@ func (o *runeParser) ParseBytes(in []byte) (ch rune, out []byte, err error) {
 		return
 	}
 
-	return 0, in, errors.ErrNotMatched
+	return 0, in, parser.NewExpectedBytesError(in, errors.ErrNotMatched, o.expected)
+}
+
+func (o *runeParser) Expected() []string {
+	return o.expected
 }
 
 // Rune matches a single rune
@@ -56,5 +61,5 @@ func (o *runeParser) ParseBytes(in []byte) (ch rune, out []byte, err error) {
 //   - If the input is empty, it will return io.EOF
 //   - If the input doesn't match the argument, it will return errors.ErrNotMatched
 func Rune(r rune) parser.Parser[parser.Reader, rune] {
-	return &runeParser{r: r}
+	return &runeParser{r: r, expected: []string{errors.Quote(r)}}
 }
@@ -28,7 +28,7 @@ func ExampleRune_noMatch() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'not matched', Remainder: '𒀀a𒀀'
+	// Match: 0, Error: 'expected '𒀁' at offset 0', Remainder: '𒀀a𒀀'
 }
 
 func ExampleRune_endOfFile() {
@@ -40,5 +40,5 @@ func ExampleRune_endOfFile() {
 	fmt.Printf("Match: %d, Error: '%v', Remainder: '%s'", match, err, string(remainder))
 
 	// Output:
-	// Match: 0, Error: 'EOF', Remainder: ''
+	// Match: 0, Error: 'unexpected end of input, expected '𒀁' at offset 0', Remainder: ''
 }
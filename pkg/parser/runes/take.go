@@ -22,7 +22,7 @@ func (o *takeParser) Parse(in parser.Reader) (string, error) {
 		r, _, err := in.ReadRune()
 		if err != nil {
 			_, _ = in.Seek(currentOffset, io.SeekStart)
-			return "", err
+			return "", parser.NewError(in, err)
 		}
 		_, _ = builder.WriteRune(r)
 	}
@@ -34,7 +34,7 @@ func (o *takeParser) ParseBytes(in []byte) (string, []byte, error) {
 	size := 0
 	for i := 0; i < o.n; i++ {
 		if len(in) < size {
-			return "", in, io.EOF
+			return "", in, parser.NewBytesError(in, errors.NewEOFError(0))
 		}
 		if c := in[size]; c < utf8.RuneSelf {
 			size++
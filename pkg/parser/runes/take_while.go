@@ -21,10 +21,23 @@ type (
 )
 
 func (o *takeWhileMinMaxParser) Parse(in parser.Reader) (string, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *takeWhileMinMaxParser) ParseBytes(in []byte) (string, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *takeWhileMinMaxParser) ParseSession(s *parser.Session, in parser.Reader) (string, error) {
 	builder := strings.Builder{}
 	builder.Grow(o.min)
+	canceller := s.Canceller()
 
 	for i := 0; i < o.max; i++ {
+		if err := canceller.Check(); err != nil {
+			_, _ = in.Seek(-int64(builder.Len()), io.SeekCurrent)
+			return "", parser.NewError(in, err)
+		}
 		r, n, err := in.ReadRune()
 		if err == nil && !o.predicate(r) {
 			_, _ = in.Seek(-int64(n), io.SeekCurrent)
@@ -33,7 +46,7 @@ func (o *takeWhileMinMaxParser) Parse(in parser.Reader) (string, error) {
 		if err != nil {
 			if builder.Len() < o.min {
 				_, _ = in.Seek(-int64(n), io.SeekCurrent)
-				return "", err
+				return "", parser.NewError(in, err)
 			}
 			break
 		}
@@ -43,10 +56,14 @@ func (o *takeWhileMinMaxParser) Parse(in parser.Reader) (string, error) {
 	return builder.String(), nil
 }
 
-func (o *takeWhileMinMaxParser) ParseBytes(in []byte) (string, []byte, error) {
+func (o *takeWhileMinMaxParser) ParseBytesSession(s *parser.Session, in []byte) (string, []byte, error) {
+	canceller := s.Canceller()
 	size := 0
 	i := 0
 	for ; i < o.max; i++ {
+		if err := canceller.Check(); err != nil {
+			return "", in, parser.NewBytesError(in, err)
+		}
 		if len(in) < size {
 			break
 		}
@@ -64,17 +81,30 @@ func (o *takeWhileMinMaxParser) ParseBytes(in []byte) (string, []byte, error) {
 
 	if i < o.min {
 		if len(in) < size {
-			return "", in, io.EOF
+			return "", in, parser.NewBytesError(in, errors.NewEOFError(0))
 		}
-		return "", in, errors.ErrNotMatched
+		return "", in, parser.NewBytesError(in, errors.ErrNotMatched)
 	}
 
 	return string(in[:size]), in[size:], nil
 }
 
 func (o *takeWhile) Parse(in parser.Reader) (string, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *takeWhile) ParseBytes(in []byte) (string, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *takeWhile) ParseSession(s *parser.Session, in parser.Reader) (string, error) {
 	builder := strings.Builder{}
+	canceller := s.Canceller()
 	for {
+		if err := canceller.Check(); err != nil {
+			_, _ = in.Seek(-int64(builder.Len()), io.SeekCurrent)
+			return "", parser.NewError(in, err)
+		}
 		r, i, err := in.ReadRune()
 		if err != nil || !o.predicate(r) {
 			_, _ = in.Seek(-int64(i), io.SeekCurrent)
@@ -86,9 +116,13 @@ func (o *takeWhile) Parse(in parser.Reader) (string, error) {
 	return builder.String(), nil
 }
 
-func (o *takeWhile) ParseBytes(in []byte) (string, []byte, error) {
+func (o *takeWhile) ParseBytesSession(s *parser.Session, in []byte) (string, []byte, error) {
+	canceller := s.Canceller()
 	size := 0
 	for {
+		if err := canceller.Check(); err != nil {
+			return "", in, parser.NewBytesError(in, err)
+		}
 		if len(in) < size {
 			break
 		}
@@ -109,6 +143,7 @@ func (o *takeWhile) ParseBytes(in []byte) (string, []byte, error) {
 
 // TakeWhile returns a string containing zero or more Returns a string containing that match the predicate.
 //   - If the input matches the predicate, it will return the matched runes.
+//   - If the context bound by parser.ParseContext is cancelled, it will return the fatal ctx.Err()
 //   - If the input is empty, it will return an empty string
 //   - If the input doesn't match the predicate, it will return an empty string
 func TakeWhile(predicate parser.Predicate[rune]) parser.Parser[parser.Reader, string] {
@@ -117,6 +152,7 @@ func TakeWhile(predicate parser.Predicate[rune]) parser.Parser[parser.Reader, st
 
 // TakeWhile1 returns a string containing one or more runes that match the predicate.
 //   - If the input matches the predicate, it will return the matched runes.
+//   - If the context bound by parser.ParseContext is cancelled, it will return the fatal ctx.Err()
 //   - If the input is empty, it will return io.EOF
 //   - If the input doesn't match the predicate, it will return errors.ErrNotMatched
 func TakeWhile1(predicate parser.Predicate[rune]) parser.Parser[parser.Reader, string] {
@@ -125,6 +161,7 @@ func TakeWhile1(predicate parser.Predicate[rune]) parser.Parser[parser.Reader, s
 
 // TakeWhileMinMax returns a string of length (m <= len <= n) containing runes that match the predicate.
 //   - If the input matches the predicate, it will return the matched runes.
+//   - If the context bound by parser.ParseContext is cancelled, it will return the fatal ctx.Err()
 //   - If the input is empty and m > 0, it will return io.EOF
 //   - If the number of matched bytes < m, it will return errors.ErrNotMatched
 func TakeWhileMinMax(min, max int, predicate parser.Predicate[rune]) parser.Parser[parser.Reader, string] {
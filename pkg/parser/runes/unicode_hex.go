@@ -16,26 +16,26 @@ var unicodeHexParserInstance = &unicodeHexParser{}
 func (o *unicodeHexParser) Parse(in parser.Reader) (rune, error) {
 	unicodeBuffer := make([]byte, 6)
 	if n, err := in.Read(unicodeBuffer[:4]); err != nil {
-		return 0, err
+		return 0, parser.NewError(in, err)
 	} else if n != 4 {
-		return 0, io.EOF
+		return 0, parser.NewError(in, io.EOF)
 	}
 	r, err := unicodeToRune(unicodeBuffer[:4])
 	if err != nil {
-		return 0, err
+		return 0, parser.NewError(in, err)
 	}
 	if utf16.IsSurrogate(r) {
 		if n, err := in.Read(unicodeBuffer); err != nil {
-			return 0, err
+			return 0, parser.NewError(in, err)
 		} else if n != 6 {
-			return 0, io.EOF
+			return 0, parser.NewError(in, io.EOF)
 		}
 		if unicodeBuffer[0] != '\\' || unicodeBuffer[1] != 'u' {
-			return 0, errors.ErrNotMatched
+			return 0, parser.NewError(in, errors.ErrNotMatched)
 		}
 		r2, err := unicodeToRune(unicodeBuffer[2:])
 		if err != nil {
-			return 0, err
+			return 0, parser.NewError(in, err)
 		}
 		r = utf16.DecodeRune(r, r2)
 	}
@@ -45,23 +45,23 @@ func (o *unicodeHexParser) Parse(in parser.Reader) (rune, error) {
 
 func (o *unicodeHexParser) ParseBytes(in []byte) (rune, []byte, error) {
 	if len(in) < 4 {
-		return 0, in, io.EOF
+		return 0, in, parser.NewBytesError(in, io.EOF)
 	}
 	r, err := unicodeToRune(in[:4])
 	if err != nil {
-		return 0, in, err
+		return 0, in, parser.NewBytesError(in, err)
 	}
 	out := in[:4]
 	if utf16.IsSurrogate(r) {
 		if len(out) < 6 {
-			return 0, in, io.EOF
+			return 0, in, parser.NewBytesError(in, io.EOF)
 		}
 		if out[0] != '\\' || out[1] != 'u' {
-			return 0, in, errors.ErrNotMatched
+			return 0, in, parser.NewBytesError(in, errors.ErrNotMatched)
 		}
 		r2, err := unicodeToRune(out[2:6])
 		if err != nil {
-			return 0, in, err
+			return 0, in, parser.NewBytesError(in, err)
 		}
 		r = utf16.DecodeRune(r, r2)
 		out = out[:6]
@@ -0,0 +1,208 @@
+package parser
+
+import (
+	"context"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"io"
+)
+
+type (
+	// Session holds the values shared by the parsers of one parse, such as memo tables, the Limits, the context of the
+	// parse and whether it's streaming. Parse and ParseBytes start a new session, which the combinators pass on to the parsers they call, so
+	// separate parses never share a session, even when they run at the same time on parts of the same buffer.
+	//
+	// A session is only used by one parse at a time, so it isn't safe for concurrent use.
+	Session struct {
+		size    int64
+		values  map[any]any
+		limiter *Limiter
+		ctx     context.Context
+		tracer  *tracer
+		// streaming converts the end of input errors of the parsers called within the session into IncompleteErrors
+		streaming bool
+		// scope identifies the input the offsets of the parsers called within the session are relative to
+		scope any
+	}
+
+	// SessionParser is implemented by parsers which call other parsers, so the session of the parse is passed on to
+	// them. ParseSession and ParseBytesSession are Parse and ParseBytes within the session. Parsers which call other
+	// parsers without passing on the session start a new session for each call, so the parsers they call don't share
+	// values, such as memo tables or the Limits, with the rest of the parse.
+	SessionParser[R Reader, T any] interface {
+		Parser[R, T]
+		ParseSession(s *Session, in R) (T, error)
+		ParseBytesSession(s *Session, in []byte) (T, []byte, error)
+	}
+
+	// Child is a parser called by a combinator. It passes the session on to the parser if it's a SessionParser, and
+	// calls Parse or ParseBytes otherwise. Whether the parser is a SessionParser is checked once, when the Child is made.
+	Child[R Reader, T any] struct {
+		Parser[R, T]
+		session SessionParser[R, T]
+	}
+)
+
+// NewSession starts the session of a parse with Parse.
+func NewSession() *Session {
+	return &Session{}
+}
+
+// NewBytesSession starts the session of a parse with ParseBytes, where in is the whole input.
+func NewBytesSession(in []byte) *Session {
+	return &Session{size: int64(len(in))}
+}
+
+// Load returns the value stored for key.
+func (s *Session) Load(key any) (any, bool) {
+	v, ok := s.values[key]
+	return v, ok
+}
+
+// Store sets the value for key.
+func (s *Session) Store(key, value any) {
+	if s.values == nil {
+		s.values = map[any]any{}
+	}
+	s.values[key] = value
+}
+
+// Delete removes the value for key.
+func (s *Session) Delete(key any) {
+	delete(s.values, key)
+}
+
+// LoadOrStore returns the existing value for key if present. Otherwise, it stores and returns the given value. The
+// loaded result is true if the value was loaded.
+func (s *Session) LoadOrStore(key, value any) (actual any, loaded bool) {
+	if v, ok := s.values[key]; ok {
+		return v, true
+	}
+	s.Store(key, value)
+	return value, false
+}
+
+// Offset returns the offset of the remaining input passed to ParseBytesSession, relative to the start of the input the
+// session was started with.
+func (s *Session) Offset(in []byte) int64 {
+	return s.size - int64(len(in))
+}
+
+// Limiter returns the Limiter of the session, or nil if the parse isn't Limited.
+func (s *Session) Limiter() *Limiter {
+	return s.limiter
+}
+
+// Context returns the context bound to the session by ParseContext, or nil if there isn't one.
+func (s *Session) Context() context.Context {
+	return s.ctx
+}
+
+// Canceller returns a Canceller for the context bound to the session.
+func (s *Session) Canceller() Canceller {
+	return Canceller{ctx: s.ctx}
+}
+
+// Streaming reports whether the parse is in streaming mode, where running out of input returns an
+// errors.IncompleteError.
+func (s *Session) Streaming() bool {
+	return s.streaming
+}
+
+// SetStreaming switches streaming mode on or off for the parsers called within the session, and returns the previous
+// mode so it can be restored.
+func (s *Session) SetStreaming(streaming bool) bool {
+	prev := s.streaming
+	s.streaming = streaming
+	return prev
+}
+
+// Scope identifies the input the offsets of the current parser are relative to. It is nil for the input the session was
+// started with. Values keyed by offset, such as memo entries, should include the scope in their keys.
+func (s *Session) Scope() any {
+	return s.scope
+}
+
+// SetScope sets the scope for the parsers called within the session, and returns the previous scope so it can be
+// restored. Parsers which parse a different reader within the session, such as the bit reader of bits.Bits, set a new
+// comparable scope, so values keyed by offset aren't shared between the readers.
+func (s *Session) SetScope(scope any) any {
+	prev := s.scope
+	s.scope = scope
+	return prev
+}
+
+// NewChild returns the parser as a Child of a combinator.
+func NewChild[R Reader, T any](p Parser[R, T]) Child[R, T] {
+	c := Child[R, T]{Parser: p}
+	c.session, _ = p.(SessionParser[R, T])
+	return c
+}
+
+// NewChildren returns the parsers as the children of a combinator.
+func NewChildren[R Reader, T any](parsers []Parser[R, T]) []Child[R, T] {
+	children := make([]Child[R, T], len(parsers))
+	for i, p := range parsers {
+		children[i] = NewChild(p)
+	}
+	return children
+}
+
+// ParseIn calls the parser within the session. In streaming mode, running out of input is converted into an
+// errors.IncompleteError, which is fatal, so the combinator doesn't try to recover from it.
+func (c Child[R, T]) ParseIn(s *Session, in R) (r T, err error) {
+	if c.session == nil {
+		r, err = c.Parser.Parse(in)
+	} else {
+		r, err = c.session.ParseSession(s, in)
+	}
+	if err != nil && s.streaming {
+		err = incomplete(err)
+	}
+	return r, err
+}
+
+// ParseBytesIn calls the parser within the session. In streaming mode, running out of input is converted into an
+// errors.IncompleteError.
+func (c Child[R, T]) ParseBytesIn(s *Session, in []byte) (r T, out []byte, err error) {
+	if c.session == nil {
+		r, out, err = c.Parser.ParseBytes(in)
+	} else {
+		r, out, err = c.session.ParseBytesSession(s, in)
+	}
+	if err != nil && s.streaming {
+		err = incomplete(err)
+	}
+	return r, out, err
+}
+
+func (c Child[R, T]) Describe() Grammar {
+	return DescribeOf(c.Parser)
+}
+
+func (c Child[R, T]) Expected() []string {
+	return ExpectedOf(c.Parser)
+}
+
+func (c Child[R, T]) Print(w io.Writer, v T) error {
+	return Print(c.Parser, w, v)
+}
+
+func (c Child[R, T]) parser() any {
+	return c.Parser
+}
+
+// unwrapChild returns the parser of a Child, so grammars refer to the parsers themselves.
+func unwrapChild(p any) any {
+	if c, ok := p.(interface{ parser() any }); ok {
+		return c.parser()
+	}
+	return p
+}
+
+// incomplete converts an end of input error into an errors.IncompleteError.
+func incomplete(err error) error {
+	if _, ok := errors.Needed(err); !ok || errors.Is(err, errors.ErrIncomplete) {
+		return err
+	}
+	return errors.NewIncompleteError(err)
+}
@@ -0,0 +1,298 @@
+// Package state threads a user defined state value through a parse, such as the previous pixels of an image or the
+// indentation of a block, so grammars don't need to capture mutable context in closures.
+//
+// The state is created by With for each parse, and held in the parser.Session of the parse, so a grammar can be
+// reused and run concurrently, even on parts of the same buffer. Parsers which read or change the state fail with
+// ErrNoState outside With.
+//
+// Changing the state isn't undone by the combinators which backtrack, such as branch.Alt or multi.Many0, because they
+// only rewind the input. Parsers which change the state and may fail after doing so should be wrapped with Atomic,
+// which restores the state when the parser fails. Atomic copies the state value, so states should be values rather
+// than pointers or maps when they need to be rolled back.
+package state
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"io"
+)
+
+var (
+	ErrNoState = errors.NewFatalError(errors.Error("state: parser used outside state.With"))
+)
+
+type (
+	// stateKey is the session key of the state. Each state type has its own key, so separate states can be nested.
+	stateKey[S any] struct{}
+
+	cell[S any] struct {
+		value S
+	}
+
+	withParser[R parser.Reader, T, S any] struct {
+		parser parser.Child[R, T]
+		init   func() S
+	}
+
+	getParser[R parser.Reader, S any] struct{}
+
+	modifyParser[R parser.Reader, S any] struct {
+		modify func(S) S
+	}
+
+	updateParser[R parser.Reader, T, S, V any] struct {
+		parser parser.Child[R, T]
+		update func(S, T) (S, V, error)
+	}
+
+	atomicParser[R parser.Reader, T, S any] struct {
+		parser parser.Child[R, T]
+	}
+)
+
+func load[S any](s *parser.Session) *cell[S] {
+	v, ok := s.Load(stateKey[S]{})
+	if !ok {
+		return nil
+	}
+	return v.(*cell[S])
+}
+
+func (o *withParser[R, T, S]) enter(s *parser.Session) func() {
+	prev, ok := s.Load(stateKey[S]{})
+	s.Store(stateKey[S]{}, &cell[S]{value: o.init()})
+	return func() {
+		if ok {
+			s.Store(stateKey[S]{}, prev)
+		} else {
+			s.Delete(stateKey[S]{})
+		}
+	}
+}
+
+func (o *withParser[R, T, S]) Parse(in R) (T, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *withParser[R, T, S]) ParseBytes(in []byte) (T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *withParser[R, T, S]) ParseSession(s *parser.Session, in R) (T, error) {
+	defer o.enter(s)()
+	return o.parser.ParseIn(s, in)
+}
+
+func (o *withParser[R, T, S]) ParseBytesSession(s *parser.Session, in []byte) (T, []byte, error) {
+	defer o.enter(s)()
+	return o.parser.ParseBytesIn(s, in)
+}
+
+func (o *withParser[R, T, S]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.parser)
+}
+
+func (o *withParser[R, T, S]) Expected() []string {
+	return parser.ExpectedOf(o.parser)
+}
+
+func (o *getParser[R, S]) Parse(in R) (S, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *getParser[R, S]) ParseBytes(in []byte) (S, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *getParser[R, S]) ParseSession(s *parser.Session, in R) (S, error) {
+	c := load[S](s)
+	if c == nil {
+		var v S
+		return v, ErrNoState
+	}
+	return c.value, nil
+}
+
+func (o *getParser[R, S]) ParseBytesSession(s *parser.Session, in []byte) (S, []byte, error) {
+	c := load[S](s)
+	if c == nil {
+		var v S
+		return v, in, ErrNoState
+	}
+	return c.value, in, nil
+}
+
+func (o *getParser[R, S]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarEmpty)
+}
+
+func (o *modifyParser[R, S]) Parse(in R) (S, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *modifyParser[R, S]) ParseBytes(in []byte) (S, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *modifyParser[R, S]) ParseSession(s *parser.Session, in R) (S, error) {
+	c := load[S](s)
+	if c == nil {
+		var v S
+		return v, ErrNoState
+	}
+	c.value = o.modify(c.value)
+	return c.value, nil
+}
+
+func (o *modifyParser[R, S]) ParseBytesSession(s *parser.Session, in []byte) (S, []byte, error) {
+	c := load[S](s)
+	if c == nil {
+		var v S
+		return v, in, ErrNoState
+	}
+	c.value = o.modify(c.value)
+	return c.value, in, nil
+}
+
+func (o *modifyParser[R, S]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarEmpty)
+}
+
+func (o *updateParser[R, T, S, V]) Parse(in R) (V, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *updateParser[R, T, S, V]) ParseBytes(in []byte) (V, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *updateParser[R, T, S, V]) ParseSession(s *parser.Session, in R) (V, error) {
+	c := load[S](s)
+	if c == nil {
+		var v V
+		return v, ErrNoState
+	}
+
+	currentOffset, _ := in.Seek(0, io.SeekCurrent)
+	r, err := o.parser.ParseIn(s, in)
+	if err != nil {
+		var v V
+		return v, err
+	}
+	next, v, err := o.update(c.value, r)
+	if err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		return v, parser.NewError(in, err)
+	}
+	c.value = next
+	return v, nil
+}
+
+func (o *updateParser[R, T, S, V]) ParseBytesSession(s *parser.Session, in []byte) (V, []byte, error) {
+	c := load[S](s)
+	if c == nil {
+		var v V
+		return v, in, ErrNoState
+	}
+
+	r, out, err := o.parser.ParseBytesIn(s, in)
+	if err != nil {
+		var v V
+		return v, in, err
+	}
+	next, v, err := o.update(c.value, r)
+	if err != nil {
+		return v, in, parser.NewBytesError(in, err)
+	}
+	c.value = next
+	return v, out, nil
+}
+
+func (o *updateParser[R, T, S, V]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.parser)
+}
+
+func (o *updateParser[R, T, S, V]) Expected() []string {
+	return parser.ExpectedOf(o.parser)
+}
+
+func (o *atomicParser[R, T, S]) Parse(in R) (T, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *atomicParser[R, T, S]) ParseBytes(in []byte) (T, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *atomicParser[R, T, S]) ParseSession(s *parser.Session, in R) (T, error) {
+	c := load[S](s)
+	if c == nil {
+		var t T
+		return t, ErrNoState
+	}
+
+	saved := c.value
+	r, err := o.parser.ParseIn(s, in)
+	if err != nil {
+		c.value = saved
+	}
+	return r, err
+}
+
+func (o *atomicParser[R, T, S]) ParseBytesSession(s *parser.Session, in []byte) (T, []byte, error) {
+	c := load[S](s)
+	if c == nil {
+		var t T
+		return t, in, ErrNoState
+	}
+
+	saved := c.value
+	r, out, err := o.parser.ParseBytesIn(s, in)
+	if err != nil {
+		c.value = saved
+	}
+	return r, out, err
+}
+
+func (o *atomicParser[R, T, S]) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.parser)
+}
+
+func (o *atomicParser[R, T, S]) Expected() []string {
+	return parser.ExpectedOf(o.parser)
+}
+
+// With runs the parser with the state returned by init, which is stored in the session of the enclosing parse. The
+// state is discarded when the parser returns. If a state of the same type is already in use, it is hidden until the
+// parser returns.
+func With[R parser.Reader, T, S any](p parser.Parser[R, T], init func() S) parser.Parser[R, T] {
+	return &withParser[R, T, S]{parser: parser.NewChild(p), init: init}
+}
+
+// Get returns the current state. It doesn't consume any input.
+//   - If it isn't called inside With, it will return ErrNoState
+func Get[R parser.Reader, S any]() parser.Parser[R, S] {
+	return &getParser[R, S]{}
+}
+
+// Modify replaces the state with the result of fn, and returns the new state. It doesn't consume any input.
+//   - If it isn't called inside With, it will return ErrNoState
+func Modify[R parser.Reader, S any](fn func(S) S) parser.Parser[R, S] {
+	return &modifyParser[R, S]{modify: fn}
+}
+
+// Update applies the parser, then passes the state and the result to fn, which returns the new state and the result.
+// The state is only changed if both the parser and fn succeed.
+//   - If it isn't called inside With, it will return ErrNoState
+//   - If the parser fails, it will return the error
+//   - If fn fails, it will return the error and no input will be consumed
+func Update[R parser.Reader, T, S, V any](p parser.Parser[R, T], fn func(S, T) (S, V, error)) parser.Parser[R, V] {
+	return &updateParser[R, T, S, V]{parser: parser.NewChild(p), update: fn}
+}
+
+// Atomic restores the state if the parser fails, so alternatives which change the state can be backtracked safely.
+//   - If it isn't called inside With, it will return ErrNoState
+//   - If the parser fails, it will return the error
+func Atomic[R parser.Reader, T, S any](p parser.Parser[R, T]) parser.Parser[R, T] {
+	return &atomicParser[R, T, S]{parser: parser.NewChild(p)}
+}
@@ -0,0 +1,49 @@
+package state_test
+
+import (
+	"fmt"
+	"github.com/roblovelock/gobble/pkg/combinator/branch"
+	"github.com/roblovelock/gobble/pkg/combinator/multi"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/roblovelock/gobble/pkg/parser/numeric"
+	"github.com/roblovelock/gobble/pkg/parser/state"
+	"strings"
+)
+
+func ExampleUpdate() {
+	// each byte is the difference from the previous value
+	value := state.Update(numeric.Int8(), func(prev int, delta int8) (int, int, error) {
+		v := prev + int(delta)
+		return v, v, nil
+	})
+	p := state.With(multi.Many0(value), func() int { return 100 })
+
+	result, err := p.Parse(strings.NewReader("\x01\x02\xfd"))
+	fmt.Printf("Result: %v, Error: %v\n", result, err)
+
+	// Output:
+	// Result: [101 103 100], Error: <nil>
+}
+
+func ExampleAtomic() {
+	open := sequence.Preceded(state.Modify[parser.Reader](func(depth int) int { return depth + 1 }), bytes.Byte('{'))
+	p := state.With(
+		sequence.Preceded(
+			branch.Alt(
+				// the depth is restored when there isn't a '{'
+				state.Atomic[parser.Reader, byte, int](open),
+				bytes.Byte('x'),
+			),
+			state.Get[parser.Reader, int](),
+		),
+		func() int { return 0 },
+	)
+
+	result, err := p.Parse(strings.NewReader("x"))
+	fmt.Printf("Depth: %v, Error: %v\n", result, err)
+
+	// Output:
+	// Depth: 0, Error: <nil>
+}
@@ -0,0 +1,224 @@
+package state_test
+
+import (
+	"github.com/roblovelock/gobble/pkg/combinator/branch"
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/combinator/multi"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/bits"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/roblovelock/gobble/pkg/parser/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// counted counts the bytes matched by the parser.
+func counted(p parser.Parser[parser.Reader, byte]) parser.Parser[parser.Reader, byte] {
+	return state.Update(p, func(n int, b byte) (int, byte, error) {
+		return n + 1, b, nil
+	})
+}
+
+func total() parser.Parser[parser.Reader, int] {
+	return state.Get[parser.Reader, int]()
+}
+
+func TestWith(t *testing.T) {
+	tests := []struct {
+		name       string
+		parser     parser.Parser[parser.Reader, int]
+		input      string
+		want       int
+		wantRemain string
+		wantErr    error
+	}{
+		{
+			name: "update => state changed",
+			parser: sequence.Preceded(
+				multi.Many0(counted(bytes.Byte('a'))),
+				total(),
+			),
+			input:      "aaab",
+			want:       3,
+			wantRemain: "b",
+		},
+		{
+			name: "modify => state changed",
+			parser: sequence.Preceded(
+				state.Modify[parser.Reader](func(n int) int { return n + 10 }),
+				total(),
+			),
+			input:      "a",
+			want:       10,
+			wantRemain: "a",
+		},
+		{
+			name: "backtracked => state not restored",
+			parser: sequence.Preceded(
+				branch.Alt(
+					sequence.Preceded(counted(bytes.Byte('a')), bytes.Byte('b')),
+					bytes.Byte('a'),
+				),
+				total(),
+			),
+			input:      "ac",
+			want:       1,
+			wantRemain: "c",
+		},
+		{
+			name: "atomic backtracked => state restored",
+			parser: sequence.Preceded(
+				branch.Alt(
+					state.Atomic[parser.Reader, byte, int](
+						sequence.Preceded(counted(bytes.Byte('a')), bytes.Byte('b')),
+					),
+					bytes.Byte('a'),
+				),
+				total(),
+			),
+			input:      "ac",
+			want:       0,
+			wantRemain: "c",
+		},
+		{
+			name: "update fails => state not changed",
+			parser: sequence.Preceded(
+				modifier.Optional(state.Update(bytes.Byte('a'), func(n int, b byte) (int, byte, error) {
+					return n + 1, b, errors.ErrNotMatched
+				})),
+				total(),
+			),
+			input:      "a",
+			want:       0,
+			wantRemain: "a",
+		},
+		{
+			name: "bits => state shared",
+			parser: sequence.Preceded(
+				bits.Bits(state.Update(bits.Take[uint8](8), func(n int, b uint8) (int, uint8, error) {
+					return n + int(b), b, nil
+				})),
+				total(),
+			),
+			input: "\x05",
+			want:  5,
+		},
+		{
+			name:       "parser fails => error",
+			parser:     sequence.Preceded(counted(bytes.Byte('a')), total()),
+			input:      "b",
+			wantRemain: "b",
+			wantErr:    errors.ErrNotMatched,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := state.With(tt.parser, func() int { return 0 })
+
+			input := strings.NewReader(tt.input)
+			s, err := p.Parse(input)
+
+			assert.Equal(t, tt.want, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			remain, err := io.ReadAll(input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRemain, string(remain))
+
+			s, out, err := p.ParseBytes([]byte(tt.input))
+
+			assert.Equal(t, tt.want, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantRemain, string(out))
+		})
+	}
+}
+
+func TestWith_separateParses(t *testing.T) {
+	p := state.With(sequence.Preceded(multi.Many0(counted(bytes.Byte('a'))), total()), func() int { return 0 })
+
+	s, err := p.Parse(strings.NewReader("aa"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, s)
+
+	s, _, err = p.ParseBytes([]byte("aaa"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, s)
+}
+
+func TestWith_concurrentSubSlices(t *testing.T) {
+	p := state.With(sequence.Preceded(multi.Many0(counted(bytes.Byte('a'))), total()), func() int { return 0 })
+	buf := []byte(strings.Repeat("a", 1000) + strings.Repeat("a", 2000) + strings.Repeat("a", 3000))
+	inputs := [][]byte{buf[:1000], buf[1000:3000], buf[3000:]}
+
+	var wg sync.WaitGroup
+	counts := make([][]int, len(inputs))
+	for i, in := range inputs {
+		wg.Add(1)
+		go func(i int, in []byte) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				n, _, err := p.ParseBytes(in)
+				if err != nil {
+					return
+				}
+				counts[i] = append(counts[i], n)
+			}
+		}(i, in)
+	}
+	wg.Wait()
+
+	for i, in := range inputs {
+		require.Len(t, counts[i], 100)
+		for _, n := range counts[i] {
+			assert.Equal(t, len(in), n)
+		}
+	}
+}
+
+func TestWith_nested(t *testing.T) {
+	inner := state.With(sequence.Preceded(counted(bytes.Byte('b')), total()), func() int { return 10 })
+	p := state.With(
+		sequence.Preceded(
+			sequence.Tuple(parser.Untyped(counted(bytes.Byte('a'))), parser.Untyped(inner)),
+			total(),
+		),
+		func() int { return 0 },
+	)
+
+	s, err := p.Parse(strings.NewReader("ab"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, s)
+}
+
+func TestNoState(t *testing.T) {
+	tests := []struct {
+		name   string
+		parser parser.Parser[parser.Reader, int]
+	}{
+		{name: "get", parser: total()},
+		{name: "modify", parser: state.Modify[parser.Reader](func(n int) int { return n })},
+		{name: "update", parser: state.Update(bytes.Byte('a'), func(n int, b byte) (int, int, error) {
+			return n, n, nil
+		})},
+		{name: "atomic", parser: state.Atomic[parser.Reader, int, int](total())},
+		{name: "other type", parser: state.With(total(), func() string { return "" })},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.parser.Parse(strings.NewReader("a"))
+			assert.ErrorIs(t, err, state.ErrNoState)
+			assert.True(t, errors.IsFatal(err))
+
+			_, out, err := tt.parser.ParseBytes([]byte("a"))
+			assert.ErrorIs(t, err, state.ErrNoState)
+			assert.Equal(t, "a", string(out))
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package stream
+
+import (
+	"github.com/roblovelock/gobble/pkg/parser"
+)
+
+type (
+	// Releaser is implemented by readers which can discard the input before the current offset.
+	Releaser interface {
+		Release()
+	}
+
+	commitParser struct{}
+)
+
+var commitParserInstance = &commitParser{}
+
+func (o *commitParser) Parse(in parser.Reader) (parser.Empty, error) {
+	if r, ok := in.(Releaser); ok {
+		r.Release()
+	}
+	return nil, nil
+}
+
+func (o *commitParser) ParseBytes(in []byte) (parser.Empty, []byte, error) {
+	return nil, in, nil
+}
+
+// Commit marks a point in the input that parsers can no longer backtrack past. If the reader is a Releaser, such as a
+// BufferedReader, the input before the current offset is discarded. Rewinding past a commit point causes the next read
+// to return the fatal ErrReleased. It doesn't consume any input.
+func Commit() parser.Parser[parser.Reader, parser.Empty] {
+	return commitParserInstance
+}
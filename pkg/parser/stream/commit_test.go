@@ -0,0 +1,92 @@
+package stream_test
+
+import (
+	"github.com/roblovelock/gobble/pkg/combinator/branch"
+	"github.com/roblovelock/gobble/pkg/combinator/multi"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/ascii"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/roblovelock/gobble/pkg/parser/stream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestCommit(t *testing.T) {
+	type args struct {
+		input parser.Reader
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantMatch  []byte
+		wantRemain string
+		wantErr    error
+		wantFatal  bool
+	}{
+		{
+			name: "backtrack without release => second alternative",
+			args: args{
+				input: strings.NewReader("abc"),
+			},
+			wantMatch: []byte("abc"),
+		},
+		{
+			name: "backtrack past commit => fatal",
+			args: args{
+				input: stream.NewBufferedReader(strings.NewReader("abc")),
+			},
+			wantErr:   stream.ErrReleased,
+			wantFatal: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := branch.Alt(
+				sequence.Preceded(bytes.Tag([]byte("ab")), sequence.Preceded(stream.Commit(), bytes.Tag([]byte("x")))),
+				bytes.Tag([]byte("abc")),
+			)
+
+			s, err := p.Parse(tt.args.input)
+
+			assert.Equal(t, tt.wantMatch, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantFatal, errors.IsFatal(err))
+		})
+	}
+}
+
+func TestCommit_releasesInput(t *testing.T) {
+	input := strings.Repeat("line\n", 100)
+	in := stream.NewBufferedReaderSize(iotest.OneByteReader(strings.NewReader(input)), 1)
+	line := sequence.Terminated(bytes.Tag([]byte("line")), sequence.Pair(ascii.LineEnding(), stream.Commit()))
+
+	maxBuffered := 0
+	count := 0
+	for {
+		if _, err := line.Parse(in); err != nil {
+			require.ErrorIs(t, err, io.EOF)
+			break
+		}
+		count++
+		if in.Buffered() > maxBuffered {
+			maxBuffered = in.Buffered()
+		}
+	}
+
+	assert.Equal(t, 100, count)
+	assert.Equal(t, 0, maxBuffered)
+}
+
+func TestCommit_bytes(t *testing.T) {
+	s, out, err := multi.Many0(sequence.Terminated(bytes.Byte('a'), stream.Commit())).ParseBytes([]byte("aab"))
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte("aa"), s)
+	assert.Equal(t, []byte("b"), out)
+}
@@ -10,7 +10,10 @@ type (
 	eofParser struct{}
 )
 
-var eofParserInstance = &eofParser{}
+var (
+	eofParserInstance = &eofParser{}
+	eofExpected       = []string{"end of input"}
+)
 
 func (o *eofParser) Parse(in parser.Reader) (parser.Empty, error) {
 	_, err := in.ReadByte()
@@ -18,14 +21,18 @@ func (o *eofParser) Parse(in parser.Reader) (parser.Empty, error) {
 		return nil, nil
 	}
 	_, _ = in.Seek(-1, io.SeekCurrent)
-	return nil, errors.ErrNotMatched
+	return nil, parser.NewExpectedError(in, errors.ErrNotMatched, eofExpected)
 }
 
 func (o *eofParser) ParseBytes(in []byte) (parser.Empty, []byte, error) {
 	if len(in) == 0 {
 		return nil, in, nil
 	}
-	return nil, in, errors.ErrNotMatched
+	return nil, in, parser.NewExpectedBytesError(in, errors.ErrNotMatched, eofExpected)
+}
+
+func (o *eofParser) Expected() []string {
+	return eofExpected
 }
 
 // EOF Returns successfully if it is at the end of input data
@@ -0,0 +1,46 @@
+package stream
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"io"
+)
+
+type (
+	positionParser struct{}
+)
+
+var positionParserInstance = &positionParser{}
+
+func (o *positionParser) Parse(in parser.Reader) (parser.Position, error) {
+	if p, ok := in.(Positioner); ok {
+		return p.Position()
+	}
+
+	offset, err := in.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return parser.Position{}, err
+	}
+	if _, err := in.Seek(0, io.SeekStart); err != nil {
+		_, _ = in.Seek(offset, io.SeekStart)
+		return parser.Position{}, err
+	}
+	input := make([]byte, offset)
+	n, _ := io.ReadFull(in, input)
+	_, _ = in.Seek(offset, io.SeekStart)
+
+	line, column := errors.LineColumn(input[:n], offset)
+	return parser.Position{Offset: offset, Line: line, Column: column}, nil
+}
+
+func (o *positionParser) ParseBytes(in []byte) (parser.Position, []byte, error) {
+	return parser.Position{}, in, errors.ErrNotSupported
+}
+
+// Position Returns the offset, line and column of the current stream position. It doesn't consume any input.
+// If the reader is a Positioner, such as a PositionReader, the position is taken from the reader. Otherwise, the input
+// is read again from the start to count the lines, which fails once the start of the input has been released, for
+// example by a Commit over a BufferedReader. Wrap the reader in a PositionReader to find positions after a Commit.
+func Position() parser.Parser[parser.Reader, parser.Position] {
+	return positionParserInstance
+}
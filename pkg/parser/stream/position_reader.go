@@ -0,0 +1,273 @@
+package stream
+
+import (
+	"github.com/roblovelock/gobble/pkg/parser"
+	"io"
+	"sort"
+	"unicode/utf8"
+)
+
+const scanBufferSize = 512
+
+type (
+	// Positioner is implemented by readers which track the line and column of the current offset.
+	Positioner interface {
+		Position() (parser.Position, error)
+	}
+
+	// PositionReader wraps a parser.Reader and records the line breaks and multibyte runes in the input as it is read,
+	// so the line and column of any offset that has been read can be found without rescanning the input. Seeking is
+	// passed to the wrapped reader, and the position is always derived from the current offset, so it stays correct
+	// when parsers backtrack.
+	//
+	// Lines are terminated by '\n', so both `\n` and `\r\n` line endings are supported. Columns are counted in runes,
+	// with each byte of invalid UTF-8 counted as a column, matching errors.LineColumn.
+	PositionReader struct {
+		r        parser.Reader
+		pos      int64   // offset of the next byte to read
+		scanned  int64   // offset after the last byte recorded
+		released int64   // offset of the first byte which hasn't been released
+		cr       bool    // the last byte recorded was '\r'
+		lineBase int     // number of line starts released from lines
+		lines    []int64 // offsets of the line starts after the first line
+		crlf     []int64 // offsets of the '\r' bytes followed by '\n'
+		wide     []int64 // offsets of the continuation bytes of multibyte runes
+		rune     []byte  // the bytes recorded of a multibyte rune which hasn't been completed
+	}
+)
+
+// NewPositionReader returns a PositionReader which reads from r. If r isn't at the start of the input, the input before
+// its current offset is read to find the line breaks.
+func NewPositionReader(r parser.Reader) *PositionReader {
+	pos, _ := r.Seek(0, io.SeekCurrent)
+	pr := &PositionReader{r: r, pos: pos}
+	_ = pr.scanTo(pos)
+	return pr
+}
+
+func (r *PositionReader) Read(p []byte) (int, error) {
+	if err := r.scanTo(r.pos); err != nil {
+		return 0, err
+	}
+	n, err := r.r.Read(p)
+	r.record(r.pos, p[:n])
+	r.pos += int64(n)
+	return n, err
+}
+
+func (r *PositionReader) ReadByte() (byte, error) {
+	if err := r.scanTo(r.pos); err != nil {
+		return 0, err
+	}
+	c, err := r.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if r.pos == r.scanned {
+		r.scanByte(c)
+	}
+	r.pos++
+	return c, nil
+}
+
+func (r *PositionReader) ReadRune() (rune, int, error) {
+	if err := r.scanTo(r.pos); err != nil {
+		return 0, 0, err
+	}
+	c, size, err := r.r.ReadRune()
+	if err != nil {
+		return 0, 0, err
+	}
+	if c == utf8.RuneError && size == 1 {
+		// the invalid byte can't be told from the rune, so it's read again to record it
+		r.pos++
+		if err := r.scanTo(r.pos); err != nil {
+			return 0, 0, err
+		}
+		return c, size, nil
+	}
+	var buf [utf8.UTFMax]byte
+	utf8.EncodeRune(buf[:], c)
+	r.record(r.pos, buf[:size])
+	r.pos += int64(size)
+	return c, size, nil
+}
+
+// Seek sets the offset for the next read of the wrapped reader.
+func (r *PositionReader) Seek(offset int64, whence int) (int64, error) {
+	pos, err := r.r.Seek(offset, whence)
+	r.pos = pos
+	return pos, err
+}
+
+// Release records the input up to the current offset, and then discards it if the wrapped reader is a Releaser. The
+// line breaks and multibyte runes recorded before the line of the current offset are discarded with it.
+func (r *PositionReader) Release() {
+	if err := r.scanTo(r.pos); err != nil {
+		return
+	}
+	rel, ok := r.r.(Releaser)
+	if !ok {
+		return
+	}
+	rel.Release()
+	r.released = r.pos
+
+	n := count(r.lines, r.pos+1)
+	if n == 0 {
+		// the current offset is on the first line, which is still needed to count its columns
+		return
+	}
+	// keep the start of the current line, so the columns of the offsets after it can still be counted
+	r.lines = append(r.lines[:0], r.lines[n-1:]...)
+	r.lineBase += n - 1
+	r.crlf = trim(r.crlf, r.lines[0])
+	r.wide = trim(r.wide, r.lines[0])
+}
+
+// Position returns the line and column of the current offset. If the reader was seeked past the input that has been
+// read, the skipped input is read to find the line breaks. It returns ErrReleased if the current offset has been
+// released.
+func (r *PositionReader) Position() (parser.Position, error) {
+	if r.pos < r.released {
+		return parser.Position{}, ErrReleased
+	}
+	if err := r.scanTo(r.pos); err != nil {
+		return parser.Position{}, err
+	}
+	if len(r.rune) > 0 && r.pos > r.scanned-int64(len(r.rune)) {
+		r.completeRune()
+	}
+	if r.cr && r.pos == r.scanned {
+		// a '\r' at the current offset is only part of the line ending if it's followed by '\n'
+		if _, err := r.ReadByte(); err == nil {
+			_, _ = r.Seek(-1, io.SeekCurrent)
+		}
+	}
+
+	offset := r.pos
+	if offset > r.scanned {
+		offset = r.scanned
+	}
+	line := sort.Search(len(r.lines), func(i int) bool { return r.lines[i] > offset })
+	var lineStart int64
+	if line > 0 {
+		lineStart = r.lines[line-1]
+	}
+	line += r.lineBase
+	column := int(offset-lineStart) - (count(r.wide, offset) - count(r.wide, lineStart)) + 1
+	if offset > lineStart && count(r.crlf, offset) > count(r.crlf, offset-1) {
+		column--
+	}
+	return parser.Position{Offset: r.pos, Line: line + 1, Column: column}, nil
+}
+
+// scanTo records the input before offset which hasn't been read yet. The wrapped reader is left at the current offset.
+func (r *PositionReader) scanTo(offset int64) error {
+	if offset <= r.scanned {
+		return nil
+	}
+	if _, err := r.r.Seek(r.scanned, io.SeekStart); err != nil {
+		return err
+	}
+	buf := make([]byte, scanBufferSize)
+	for r.scanned < offset {
+		if n := offset - r.scanned; n < int64(len(buf)) {
+			buf = buf[:n]
+		}
+		n, err := r.r.Read(buf)
+		r.record(r.scanned, buf[:n])
+		if err != nil || n == 0 {
+			break
+		}
+	}
+	_, err := r.r.Seek(r.pos, io.SeekStart)
+	return err
+}
+
+// record records the bytes read at offset which haven't been recorded yet. Bytes read after a gap in the recorded
+// input, such as a read after seeking past the end of the input, aren't recorded.
+func (r *PositionReader) record(offset int64, b []byte) {
+	if offset > r.scanned {
+		return
+	}
+	if skip := r.scanned - offset; skip < int64(len(b)) {
+		for _, c := range b[skip:] {
+			r.scanByte(c)
+		}
+	}
+}
+
+func (r *PositionReader) scanByte(c byte) {
+	r.scan(r.scanned, c)
+	r.scanned++
+}
+
+// scan records the byte at offset. The continuation bytes of a multibyte rune are recorded as they are read, and
+// discarded if the rune turns out to be invalid, so each of its bytes is a column, as in errors.LineColumn.
+func (r *PositionReader) scan(offset int64, c byte) {
+	if len(r.rune) > 0 {
+		r.rune = append(r.rune, c)
+		if !utf8.FullRune(r.rune) {
+			r.wide = append(r.wide, offset)
+			return
+		}
+		if _, size := utf8.DecodeRune(r.rune); size == len(r.rune) {
+			r.wide = append(r.wide, offset)
+			r.rune = r.rune[:0]
+			return
+		}
+
+		// the lead byte is an invalid rune, so the bytes after it are scanned again
+		var rest [utf8.UTFMax]byte
+		n := copy(rest[:], r.rune[1:])
+		r.wide = r.wide[:len(r.wide)-(n-1)]
+		r.rune = r.rune[:0]
+		for i, b := range rest[:n] {
+			r.scan(offset-int64(n-1-i), b)
+		}
+		return
+	}
+
+	switch {
+	case c == '\n':
+		if r.cr {
+			r.crlf = append(r.crlf, offset-1)
+		}
+		r.lines = append(r.lines, offset+1)
+	case c >= utf8.RuneSelf:
+		if r.rune = append(r.rune, c); utf8.FullRune(r.rune) {
+			r.rune = r.rune[:0] // a continuation or invalid byte is a column on its own
+		}
+	}
+	r.cr = c == '\r'
+}
+
+// completeRune reads the rest of a multibyte rune which has only partly been recorded, to find whether it's valid. If
+// the input ends first, each of its bytes is a column.
+func (r *PositionReader) completeRune() {
+	_, _ = r.r.Seek(r.scanned, io.SeekStart)
+	for len(r.rune) > 0 {
+		c, err := r.r.ReadByte()
+		if err != nil {
+			r.wide = r.wide[:len(r.wide)-(len(r.rune)-1)]
+			r.rune = r.rune[:0]
+			break
+		}
+		r.scanByte(c)
+	}
+	_, _ = r.r.Seek(r.pos, io.SeekStart)
+}
+
+// count returns the number of offsets before offset.
+func count(offsets []int64, offset int64) int {
+	return sort.Search(len(offsets), func(i int) bool { return offsets[i] >= offset })
+}
+
+// trim removes the offsets before offset.
+func trim(offsets []int64, offset int64) []int64 {
+	if n := count(offsets, offset); n > 0 {
+		return append(offsets[:0], offsets[n:]...)
+	}
+	return offsets
+}
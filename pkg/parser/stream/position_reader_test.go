@@ -0,0 +1,208 @@
+package stream_test
+
+import (
+	"fmt"
+	"github.com/roblovelock/gobble/pkg/combinator/branch"
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/roblovelock/gobble/pkg/parser/runes"
+	"github.com/roblovelock/gobble/pkg/parser/stream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+const positionInput = "ab\r\ncé😀d\n\n\rx\r\n"
+
+func wantPosition(offset int64) parser.Position {
+	line, column := errors.LineColumn([]byte(positionInput), offset)
+	return parser.Position{Offset: offset, Line: line, Column: column}
+}
+
+func TestPositionReader(t *testing.T) {
+	tests := []struct {
+		name string
+		read func(r *stream.PositionReader) error
+	}{
+		{
+			name: "read bytes",
+			read: func(r *stream.PositionReader) error {
+				_, err := r.ReadByte()
+				return err
+			},
+		},
+		{
+			name: "read runes",
+			read: func(r *stream.PositionReader) error {
+				_, _, err := r.ReadRune()
+				return err
+			},
+		},
+		{
+			name: "read slices",
+			read: func(r *stream.PositionReader) error {
+				_, err := r.Read(make([]byte, 3))
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := stream.NewPositionReader(strings.NewReader(positionInput))
+			for {
+				pos, err := r.Position()
+				require.NoError(t, err)
+				assert.Equal(t, wantPosition(pos.Offset), pos)
+				if tt.read(r) == io.EOF {
+					break
+				}
+			}
+
+			for offset := int64(len(positionInput)); offset >= 0; offset-- {
+				_, err := r.Seek(offset, io.SeekStart)
+				require.NoError(t, err)
+				pos, err := r.Position()
+				require.NoError(t, err)
+				assert.Equal(t, wantPosition(offset), pos)
+			}
+		})
+	}
+}
+
+func TestPositionReader_seekForward(t *testing.T) {
+	r := stream.NewPositionReader(stream.NewBufferedReader(iotest.OneByteReader(strings.NewReader(positionInput))))
+
+	for _, offset := range []int64{11, 3, 15, 2} {
+		_, err := r.Seek(offset, io.SeekStart)
+		require.NoError(t, err)
+		pos, err := r.Position()
+		require.NoError(t, err)
+		assert.Equal(t, wantPosition(offset), pos)
+	}
+
+	_, err := r.Seek(0, io.SeekEnd)
+	require.NoError(t, err)
+	pos, err := r.Position()
+	require.NoError(t, err)
+	assert.Equal(t, wantPosition(int64(len(positionInput))), pos)
+}
+
+func TestPositionReader_release(t *testing.T) {
+	r := stream.NewPositionReader(stream.NewBufferedReader(strings.NewReader(positionInput)))
+	_, err := r.Seek(12, io.SeekStart)
+	require.NoError(t, err)
+	r.Release()
+
+	_, err = r.Seek(0, io.SeekStart)
+	assert.ErrorIs(t, err, stream.ErrReleased)
+
+	_, err = r.Seek(13, io.SeekStart)
+	require.NoError(t, err)
+	pos, err := r.Position()
+	require.NoError(t, err)
+	assert.Equal(t, wantPosition(13), pos)
+}
+
+func TestPositionReader_readPastEnd(t *testing.T) {
+	r := stream.NewPositionReader(stream.NewBufferedReader(strings.NewReader(positionInput)))
+	_, err := r.Seek(int64(len(positionInput))+2, io.SeekStart)
+	require.NoError(t, err)
+
+	n, err := r.Read(make([]byte, 3))
+	assert.Equal(t, 0, n)
+	assert.ErrorIs(t, err, io.EOF)
+
+	_, err = r.Seek(5, io.SeekStart)
+	require.NoError(t, err)
+	pos, err := r.Position()
+	require.NoError(t, err)
+	assert.Equal(t, wantPosition(5), pos)
+}
+
+func TestPositionReader_releaseLines(t *testing.T) {
+	input := strings.Repeat("é\r\n", 1000) + positionInput
+	r := stream.NewPositionReader(stream.NewBufferedReader(strings.NewReader(input)))
+	for offset := int64(0); offset <= int64(len(input)); offset += 7 {
+		_, err := r.Seek(offset, io.SeekStart)
+		require.NoError(t, err)
+		r.Release()
+
+		for _, o := range []int64{offset, offset + 3} {
+			if o > int64(len(input)) {
+				continue
+			}
+			_, err = r.Seek(o, io.SeekStart)
+			require.NoError(t, err)
+			pos, err := r.Position()
+			require.NoError(t, err)
+			line, column := errors.LineColumn([]byte(input), o)
+			assert.Equal(t, parser.Position{Offset: o, Line: line, Column: column}, pos)
+		}
+	}
+
+	_, err := r.Seek(0, io.SeekStart)
+	assert.ErrorIs(t, err, stream.ErrReleased)
+	_, err = r.Position()
+	assert.ErrorIs(t, err, stream.ErrReleased)
+}
+
+func TestPositionReader_invalidUTF8(t *testing.T) {
+	reads := map[string]func(r *stream.PositionReader) error{
+		"read bytes": func(r *stream.PositionReader) error {
+			_, err := r.ReadByte()
+			return err
+		},
+		"read runes": func(r *stream.PositionReader) error {
+			_, _, err := r.ReadRune()
+			return err
+		},
+		"read slices": func(r *stream.PositionReader) error {
+			_, err := r.Read(make([]byte, 2))
+			return err
+		},
+	}
+	inputs := []string{"\x80\x80a", "😀a\n\xf0\x9fb\xf0\x9f", "\xe2\x82\n\xc0\xafé"}
+	for _, input := range inputs {
+		for name, read := range reads {
+			for _, start := range []int64{0, 1} {
+				t.Run(fmt.Sprintf("%q %s from %d", input, name, start), func(t *testing.T) {
+					r := stream.NewPositionReader(strings.NewReader(input))
+					_, err := r.Seek(start, io.SeekStart)
+					require.NoError(t, err)
+					for read(r) != io.EOF {
+					}
+
+					for offset := int64(len(input)); offset >= 0; offset-- {
+						_, err := r.Seek(offset, io.SeekStart)
+						require.NoError(t, err)
+						pos, err := r.Position()
+						require.NoError(t, err)
+						line, column := errors.LineColumn([]byte(input), offset)
+						assert.Equal(t, parser.Position{Offset: offset, Line: line, Column: column}, pos)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestPositionReader_releaseAfterBacktrack(t *testing.T) {
+	p := branch.Alt(
+		modifier.Value(bytes.Tag([]byte("😀a\nX")), parser.Position{}),
+		sequence.Preceded(runes.Rune('😀'), sequence.Preceded(stream.Commit(), stream.Position())),
+	)
+
+	pos, err := p.Parse(stream.NewPositionReader(stream.NewBufferedReader(strings.NewReader("😀a\nb"))))
+	require.NoError(t, err)
+	assert.Equal(t, parser.Position{Offset: 4, Line: 1, Column: 2}, pos)
+
+	pos, err = p.Parse(strings.NewReader("😀a\nb"))
+	require.NoError(t, err)
+	assert.Equal(t, parser.Position{Offset: 4, Line: 1, Column: 2}, pos)
+}
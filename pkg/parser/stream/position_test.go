@@ -0,0 +1,86 @@
+package stream_test
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/stream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestPosition(t *testing.T) {
+	type args struct {
+		readBytes int
+		input     parser.Reader
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantRemain string
+		want       parser.Position
+	}{
+		{
+			name: "empty input => 1:1",
+			args: args{input: strings.NewReader("")},
+			want: parser.Position{Line: 1, Column: 1},
+		},
+		{
+			name:       "same line => column",
+			args:       args{readBytes: 2, input: strings.NewReader("ab\nc")},
+			wantRemain: "\nc",
+			want:       parser.Position{Offset: 2, Line: 1, Column: 3},
+		},
+		{
+			name:       "next line => line",
+			args:       args{readBytes: 3, input: strings.NewReader("ab\nc")},
+			wantRemain: "c",
+			want:       parser.Position{Offset: 3, Line: 2, Column: 1},
+		},
+		{
+			name:       "position reader => line",
+			args:       args{readBytes: 5, input: stream.NewPositionReader(strings.NewReader("ab\nc😀d"))},
+			wantRemain: "d",
+			want:       parser.Position{Offset: 8, Line: 2, Column: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := stream.Position()
+			for i := 0; i < tt.args.readBytes; i++ {
+				_, _, _ = tt.args.input.ReadRune()
+			}
+			s, err := p.Parse(tt.args.input)
+
+			assert.Equal(t, tt.want, s)
+			assert.NoError(t, err)
+
+			remain, err := io.ReadAll(tt.args.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRemain, string(remain))
+		})
+	}
+}
+
+func TestPosition_bytes(t *testing.T) {
+	_, out, err := stream.Position().ParseBytes([]byte("a"))
+	assert.ErrorIs(t, err, errors.ErrNotSupported)
+	assert.Equal(t, []byte("a"), out)
+}
+
+func TestPosition_released(t *testing.T) {
+	in := stream.NewBufferedReader(strings.NewReader("ab\ncd"))
+	_, err := in.Read(make([]byte, 3))
+	require.NoError(t, err)
+	_, err = stream.Commit().Parse(in)
+	require.NoError(t, err)
+
+	_, err = stream.Position().Parse(in)
+	assert.ErrorIs(t, err, stream.ErrReleased)
+
+	remain, err := io.ReadAll(in)
+	require.NoError(t, err)
+	assert.Equal(t, "cd", string(remain))
+}
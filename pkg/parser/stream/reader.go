@@ -0,0 +1,178 @@
+package stream
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"io"
+	"unicode/utf8"
+)
+
+const defaultBufferSize = 4096
+
+var (
+	// ErrReleased is returned when seeking back past a commit point. It is fatal, as the input needed to try another
+	// alternative has been discarded.
+	ErrReleased      = errors.NewFatalError(errors.Error("stream.BufferedReader.Seek: offset has been released"))
+	ErrInvalidWhence = errors.Error("stream.BufferedReader.Seek: invalid whence")
+	ErrNegativeSeek  = errors.Error("stream.BufferedReader.Seek: negative position")
+)
+
+type (
+	// BufferedReader adapts an io.Reader, such as a network connection or os.Stdin, into a parser.Reader. Data read
+	// from the source is kept in a buffer so parsers can seek back to an earlier offset, until it is released by a
+	// commit point. Offsets are absolute from the start of the source, and aren't changed by releasing data.
+	//
+	// Combinators ignore the error from rewinding, so a seek into released data still moves the offset, and every read
+	// returns ErrReleased until the reader is seeked to a valid offset. This stops parsing continuing from the wrong
+	// position.
+	BufferedReader struct {
+		src   io.Reader
+		err   error  // sticky error from src
+		buf   []byte // data from offset start onwards
+		start int64  // offset of buf[0]
+		pos   int64  // offset of the next byte to read
+		size  int    // minimum read from src
+	}
+)
+
+// NewBufferedReader returns a BufferedReader which reads from r.
+func NewBufferedReader(r io.Reader) *BufferedReader {
+	return NewBufferedReaderSize(r, defaultBufferSize)
+}
+
+// NewBufferedReaderSize returns a BufferedReader which reads at least size bytes at a time from r.
+func NewBufferedReaderSize(r io.Reader, size int) *BufferedReader {
+	if size <= 0 {
+		size = defaultBufferSize
+	}
+	return &BufferedReader{src: r, size: size}
+}
+
+// Read reads up to len(p) bytes into p. Like bytes.Reader, it only returns fewer than len(p) bytes at the end of the
+// source, which the parsers rely on to detect the end of input.
+func (r *BufferedReader) Read(p []byte) (int, error) {
+	if r.pos < r.start {
+		return 0, ErrReleased
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if err := r.fill(r.pos + int64(len(p))); err != nil && r.pos >= r.end() {
+		return 0, err
+	}
+	n := copy(p, r.buf[r.pos-r.start:])
+	r.pos += int64(n)
+	return n, nil
+}
+
+func (r *BufferedReader) ReadByte() (byte, error) {
+	if r.pos < r.start {
+		return 0, ErrReleased
+	}
+	if err := r.fill(r.pos + 1); err != nil {
+		return 0, err
+	}
+	b := r.buf[r.pos-r.start]
+	r.pos++
+	return b, nil
+}
+
+func (r *BufferedReader) ReadRune() (rune, int, error) {
+	if r.pos < r.start {
+		return 0, 0, ErrReleased
+	}
+	if err := r.fill(r.pos + 1); err != nil {
+		return 0, 0, err
+	}
+	if c := r.buf[r.pos-r.start]; c < utf8.RuneSelf {
+		r.pos++
+		return rune(c), 1, nil
+	}
+	for !utf8.FullRune(r.buf[r.pos-r.start:]) {
+		if err := r.fill(r.end() + 1); err != nil {
+			break
+		}
+	}
+	c, size := utf8.DecodeRune(r.buf[r.pos-r.start:])
+	r.pos += int64(size)
+	return c, size, nil
+}
+
+// Seek sets the offset for the next read. Seeking before data that has been released returns ErrReleased. Seeking
+// relative to io.SeekEnd reads the rest of the source into the buffer.
+func (r *BufferedReader) Seek(offset int64, whence int) (int64, error) {
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = r.pos + offset
+	case io.SeekEnd:
+		for r.err == nil {
+			_ = r.fill(r.end() + int64(r.size))
+		}
+		abs = r.end() + offset
+	default:
+		return r.pos, ErrInvalidWhence
+	}
+
+	if abs < 0 {
+		return r.pos, ErrNegativeSeek
+	}
+	r.pos = abs
+	if abs < r.start {
+		return abs, ErrReleased
+	}
+	return abs, nil
+}
+
+// Release discards the buffered data before the current offset. Seeking back before this offset returns ErrReleased.
+// It is called by the Commit parser.
+func (r *BufferedReader) Release() {
+	n := r.pos - r.start
+	if n <= 0 {
+		return
+	}
+	if n >= int64(len(r.buf)) {
+		r.buf = r.buf[:0]
+	} else {
+		r.buf = append(r.buf[:0], r.buf[n:]...)
+	}
+	r.start = r.pos
+}
+
+// Buffered returns the number of bytes held in the buffer.
+func (r *BufferedReader) Buffered() int {
+	return len(r.buf)
+}
+
+// Len returns the number of unread bytes held in the buffer, which can be read without reading from the source.
+func (r *BufferedReader) Len() int {
+	if n := r.end() - r.pos; n > 0 && r.pos >= r.start {
+		return int(n)
+	}
+	return 0
+}
+
+// end returns the offset after the last buffered byte.
+func (r *BufferedReader) end() int64 {
+	return r.start + int64(len(r.buf))
+}
+
+// fill reads from the source until the buffer holds the data before offset. It returns the source error if it ends
+// before offset.
+func (r *BufferedReader) fill(offset int64) error {
+	for r.end() < offset {
+		if r.err != nil {
+			return r.err
+		}
+		if cap(r.buf)-len(r.buf) < r.size {
+			buf := make([]byte, len(r.buf), 2*cap(r.buf)+r.size)
+			copy(buf, r.buf)
+			r.buf = buf
+		}
+		n, err := r.src.Read(r.buf[len(r.buf):cap(r.buf)])
+		r.buf = r.buf[:len(r.buf)+n]
+		r.err = err
+	}
+	return nil
+}
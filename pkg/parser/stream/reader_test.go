@@ -0,0 +1,196 @@
+package stream_test
+
+import (
+	"github.com/roblovelock/gobble/pkg/combinator/branch"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/roblovelock/gobble/pkg/parser/runes"
+	"github.com/roblovelock/gobble/pkg/parser/stream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestBufferedReader_parse(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantMatch  string
+		wantRemain string
+		wantErr    error
+	}{
+		{
+			name:    "empty input => EOF",
+			input:   "",
+			wantErr: io.EOF,
+		},
+		{
+			name:       "first alternative => match",
+			input:      "abde",
+			wantMatch:  "abd",
+			wantRemain: "e",
+		},
+		{
+			name:       "backtrack to second alternative => match",
+			input:      "abce",
+			wantMatch:  "abc",
+			wantRemain: "e",
+		},
+		{
+			name:       "no alternative => rewound",
+			input:      "abxe",
+			wantErr:    errors.ErrNotMatched,
+			wantRemain: "abxe",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := stream.NewBufferedReaderSize(iotest.OneByteReader(strings.NewReader(tt.input)), 1)
+			p := sequence.Recognize(branch.Alt(bytes.Tag([]byte("abd")), bytes.Tag([]byte("abc"))))
+
+			s, err := p.Parse(in)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantMatch, string(s))
+
+			remain, err := io.ReadAll(in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRemain, string(remain))
+		})
+	}
+}
+
+func TestBufferedReader_readRune(t *testing.T) {
+	in := stream.NewBufferedReaderSize(iotest.OneByteReader(strings.NewReader("a😀\xf0")), 1)
+
+	s, err := sequence.Pair(runes.Rune('a'), runes.Rune('😀')).Parse(in)
+	require.NoError(t, err)
+	assert.Equal(t, 'a', s.First)
+	assert.Equal(t, '😀', s.Second)
+
+	r, size, err := in.ReadRune()
+	require.NoError(t, err)
+	assert.Equal(t, '�', r)
+	assert.Equal(t, 1, size)
+
+	_, _, err = in.ReadRune()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestBufferedReader_Seek(t *testing.T) {
+	type args struct {
+		release bool
+		offset  int64
+		whence  int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantOffset  int64
+		wantRemain  string
+		wantErr     error
+		wantReadErr error
+	}{
+		{
+			name:       "start => absolute",
+			args:       args{offset: 1, whence: io.SeekStart},
+			wantOffset: 1,
+			wantRemain: "bcdef",
+		},
+		{
+			name:       "current => relative",
+			args:       args{offset: -1, whence: io.SeekCurrent},
+			wantOffset: 2,
+			wantRemain: "cdef",
+		},
+		{
+			name:       "forward past buffer => reads source",
+			args:       args{offset: 5, whence: io.SeekStart},
+			wantOffset: 5,
+			wantRemain: "f",
+		},
+		{
+			name:       "end => relative to end of source",
+			args:       args{offset: -2, whence: io.SeekEnd},
+			wantOffset: 4,
+			wantRemain: "ef",
+		},
+		{
+			name:       "past end => EOF on read",
+			args:       args{offset: 10, whence: io.SeekStart},
+			wantOffset: 10,
+			wantRemain: "",
+		},
+		{
+			name:       "negative => error",
+			args:       args{offset: -1, whence: io.SeekStart},
+			wantOffset: 3,
+			wantRemain: "def",
+			wantErr:    stream.ErrNegativeSeek,
+		},
+		{
+			name:       "invalid whence => error",
+			args:       args{offset: 0, whence: 3},
+			wantOffset: 3,
+			wantRemain: "def",
+			wantErr:    stream.ErrInvalidWhence,
+		},
+		{
+			name:        "released => fatal error on read",
+			args:        args{release: true, offset: 2, whence: io.SeekStart},
+			wantOffset:  2,
+			wantErr:     stream.ErrReleased,
+			wantReadErr: stream.ErrReleased,
+		},
+		{
+			name:       "after release => seek allowed",
+			args:       args{release: true, offset: 3, whence: io.SeekStart},
+			wantOffset: 3,
+			wantRemain: "def",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := stream.NewBufferedReaderSize(iotest.OneByteReader(strings.NewReader("abcdef")), 1)
+			_, err := bytes.Take(3).Parse(in)
+			require.NoError(t, err)
+			if tt.args.release {
+				in.Release()
+			}
+
+			offset, err := in.Seek(tt.args.offset, tt.args.whence)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantOffset, offset)
+
+			remain, err := io.ReadAll(in)
+			require.ErrorIs(t, err, tt.wantReadErr)
+			assert.Equal(t, tt.wantRemain, string(remain))
+		})
+	}
+}
+
+func TestBufferedReader_Release(t *testing.T) {
+	in := stream.NewBufferedReaderSize(iotest.OneByteReader(strings.NewReader("abcdef")), 1)
+
+	_, err := bytes.Take(3).Parse(in)
+	require.NoError(t, err)
+	assert.Equal(t, 3, in.Buffered())
+
+	in.Release()
+	assert.Equal(t, 0, in.Buffered())
+
+	offset, err := in.Seek(0, io.SeekCurrent)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), offset)
+
+	remain, err := io.ReadAll(in)
+	require.NoError(t, err)
+	assert.Equal(t, "def", string(remain))
+	assert.Equal(t, 3, in.Buffered())
+
+	in.Release()
+	assert.Equal(t, 0, in.Buffered())
+}
@@ -0,0 +1,453 @@
+// Package structs provides parsers for decoding binary records into Go structs described by field tags
+package structs
+
+import (
+	stdbytes "bytes"
+	"fmt"
+	"github.com/roblovelock/gobble/pkg/combinator"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/bits"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+const (
+	// ErrInvalidTag is returned by a struct parser, as a fatal error, when a gobble field tag can't be used.
+	ErrInvalidTag = errors.Error("structs: invalid tag")
+	// ErrInvalidLength is returned by a struct parser when a len field holds a length that can't be used.
+	ErrInvalidLength = errors.Error("structs: invalid length")
+)
+
+// maxPrealloc limits the memory allocated for a slice or block of bytes before its input has been read, so a corrupt
+// length fails with io.EOF rather than allocating a large buffer.
+const maxPrealloc = 4096
+
+type (
+	structParser[T any] struct {
+		decoder *structDecoder
+	}
+
+	// structDecoder decodes the tagged fields of a struct type in order.
+	structDecoder struct {
+		typ    reflect.Type
+		fields []fieldDecoder
+	}
+
+	// fieldDecoder decodes the next value from the input into the struct v.
+	fieldDecoder func(in parser.Reader, v reflect.Value) error
+)
+
+func (o *structParser[T]) Parse(in parser.Reader) (T, error) {
+	currentOffset, _ := in.Seek(0, io.SeekCurrent)
+	v := reflect.New(o.decoder.typ).Elem()
+	if err := o.decoder.decode(in, v); err != nil {
+		_, _ = in.Seek(currentOffset, io.SeekStart)
+		var t T
+		return t, err
+	}
+	return v.Interface().(T), nil
+}
+
+func (o *structParser[T]) ParseBytes(in []byte) (T, []byte, error) {
+	reader := stdbytes.NewReader(in)
+	result, err := o.Parse(reader)
+	if err != nil {
+		return result, in, bytesError(in, err)
+	}
+	currentOffset, _ := reader.Seek(0, io.SeekCurrent)
+	return result, in[currentOffset:], nil
+}
+
+// bytesError converts the offset of an error from reading in with a reader to the position used by ParseBytes.
+func bytesError(in []byte, err error) error {
+	e, ok := errors.AsParseError(err)
+	if !ok || e.Whence != io.SeekStart {
+		return parser.NewBytesError(in, err)
+	}
+	converted := errors.NewExpectedError(e.Err, e.Offset-int64(len(in)), io.SeekEnd, e.Expected)
+	if errors.IsFatal(err) && !errors.IsFatal(converted) {
+		return errors.NewFatalError(converted)
+	}
+	return converted
+}
+
+func (d *structDecoder) decode(in parser.Reader, v reflect.Value) error {
+	for _, f := range d.fields {
+		if err := f(in, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newStructDecoder(typ reflect.Type) (*structDecoder, error) {
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s isn't a struct", typ)
+	}
+
+	d := &structDecoder{typ: typ}
+	var bitFields []int
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		s, ok := field.Tag.Lookup(tagName)
+		if !ok || s == "-" {
+			continue
+		}
+		t, err := parseTag(s)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if !field.IsExported() && t.tag == "" {
+			// only a tag can be matched without storing it in the field
+			return nil, fmt.Errorf("field %s: unexported fields can't be decoded", field.Name)
+		}
+
+		if t.bits > 0 {
+			if err := checkBitField(field, t); err != nil {
+				return nil, fmt.Errorf("field %s: %w", field.Name, err)
+			}
+			bitFields = append(bitFields, i)
+			continue
+		}
+		if len(bitFields) > 0 {
+			f, err := newBitsDecoder(typ, bitFields)
+			if err != nil {
+				return nil, err
+			}
+			d.fields = append(d.fields, f)
+			bitFields = nil
+		}
+
+		f, err := newFieldDecoder(typ, i, t)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		d.fields = append(d.fields, f)
+	}
+	if len(bitFields) > 0 {
+		f, err := newBitsDecoder(typ, bitFields)
+		if err != nil {
+			return nil, err
+		}
+		d.fields = append(d.fields, f)
+	}
+	return d, nil
+}
+
+func newFieldDecoder(typ reflect.Type, index int, t fieldTag) (fieldDecoder, error) {
+	field := typ.Field(index)
+	switch {
+	case t.tag != "":
+		return newTagDecoder(field, t)
+	case t.kind == "struct":
+		return newNestedDecoder(field)
+	case t.kind == "bytes" || t.kind == "string":
+		return newBytesDecoder(typ, field, t)
+	}
+
+	s, ok := scalars[t.kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %q", t.kind)
+	}
+	if t.length == "" && field.Type.Kind() != reflect.Array {
+		if field.Type.Kind() != s.kind {
+			return nil, fmt.Errorf("%s can't be stored in %s", t.kind, field.Type)
+		}
+		return func(in parser.Reader, v reflect.Value) error {
+			r, err := s.parser.Parse(in)
+			if err != nil {
+				return err
+			}
+			set(v.Field(index), reflect.ValueOf(r))
+			return nil
+		}, nil
+	}
+
+	if k := field.Type.Kind(); k != reflect.Slice && k != reflect.Array || field.Type.Elem().Kind() != s.kind {
+		return nil, fmt.Errorf("%s can't be stored in %s", t.kind, field.Type)
+	}
+	length, err := newLength(typ, field, t)
+	if err != nil {
+		return nil, err
+	}
+	return func(in parser.Reader, v reflect.Value) error {
+		n, err := length(in, v)
+		if err != nil {
+			return err
+		}
+		f := v.Field(index)
+		if f.Kind() == reflect.Array {
+			for i := 0; i < n; i++ {
+				r, err := s.parser.Parse(in)
+				if err != nil {
+					return err
+				}
+				set(f.Index(i), reflect.ValueOf(r))
+			}
+			return nil
+		}
+
+		// the slice grows as the values are read, so a corrupt length runs out of input before it allocates much
+		elems := reflect.MakeSlice(f.Type(), 0, minInt(n, maxPrealloc))
+		for i := 0; i < n; i++ {
+			r, err := s.parser.Parse(in)
+			if err != nil {
+				return err
+			}
+			elems = reflect.Append(elems, reflect.ValueOf(r).Convert(f.Type().Elem()))
+		}
+		f.Set(elems)
+		return nil
+	}, nil
+}
+
+// newBytesDecoder decodes a block of bytes into a []byte, [n]byte or string field.
+func newBytesDecoder(typ reflect.Type, field reflect.StructField, t fieldTag) (fieldDecoder, error) {
+	if !isBytes(field.Type) {
+		return nil, fmt.Errorf("%s can't be stored in %s", t.kind, field.Type)
+	}
+	length, err := newLength(typ, field, t)
+	if err != nil {
+		return nil, err
+	}
+	return func(in parser.Reader, v reflect.Value) error {
+		n, err := length(in, v)
+		if err != nil {
+			return err
+		}
+		r, err := take(in, n)
+		if err != nil {
+			return err
+		}
+		setBytes(v.Field(field.Index[0]), r)
+		return nil
+	}, nil
+}
+
+// take reads n bytes, in blocks of maxPrealloc bytes, so a corrupt length runs out of input before it allocates much.
+func take(in parser.Reader, n int) ([]byte, error) {
+	if n <= maxPrealloc {
+		return bytes.Take(uint(n)).Parse(in)
+	}
+	b := make([]byte, 0, maxPrealloc)
+	for len(b) < n {
+		r, err := bytes.Take(uint(minInt(n-len(b), maxPrealloc))).Parse(in)
+		if err != nil {
+			_, _ = in.Seek(-int64(len(b)), io.SeekCurrent)
+			if errors.Is(err, io.EOF) {
+				return nil, parser.NewError(in, io.EOF)
+			}
+			return nil, err
+		}
+		b = append(b, r...)
+	}
+	return b, nil
+}
+
+// newTagDecoder matches a fixed sequence of bytes. The tag is stored in the field if it can hold bytes, which allows
+// it to be a blank field.
+func newTagDecoder(field reflect.StructField, t fieldTag) (fieldDecoder, error) {
+	if t.kind != "" || t.length != "" {
+		return nil, fmt.Errorf("tag can't be combined with other options")
+	}
+	store := field.IsExported() && isBytes(field.Type)
+	if store && field.Type.Kind() == reflect.Array && field.Type.Len() != len(t.tag) {
+		return nil, fmt.Errorf("tag %q doesn't fit in %s", t.tag, field.Type)
+	}
+	p := bytes.Tag([]byte(t.tag))
+	return func(in parser.Reader, v reflect.Value) error {
+		r, err := p.Parse(in)
+		if err != nil {
+			return err
+		}
+		if store {
+			setBytes(v.Field(field.Index[0]), r)
+		}
+		return nil
+	}, nil
+}
+
+func newNestedDecoder(field reflect.StructField) (fieldDecoder, error) {
+	d, err := newStructDecoder(field.Type)
+	if err != nil {
+		return nil, err
+	}
+	return func(in parser.Reader, v reflect.Value) error {
+		return d.decode(in, v.Field(field.Index[0]))
+	}, nil
+}
+
+// newBitsDecoder reads a run of consecutive bit fields, which must fill a whole number of bytes.
+func newBitsDecoder(typ reflect.Type, indexes []int) (fieldDecoder, error) {
+	var total int
+	parsers := make([]parser.Parser[parser.BitReader, uint64], len(indexes))
+	widths := make([]int, len(indexes))
+	for i, index := range indexes {
+		t, _ := parseTag(typ.Field(index).Tag.Get(tagName))
+		parsers[i] = bits.Take[uint64](uint8(t.bits))
+		widths[i] = t.bits
+		total += t.bits
+	}
+	if total%bits.BitsInByte != 0 {
+		return nil, fmt.Errorf("field %s: bit fields end after %d bits, not on a byte boundary",
+			typ.Field(indexes[len(indexes)-1]).Name, total)
+	}
+
+	p := bits.Bits(sequence.Tuple(parsers...))
+	return func(in parser.Reader, v reflect.Value) error {
+		r, err := p.Parse(in)
+		if err != nil {
+			return err
+		}
+		for i, index := range indexes {
+			f := v.Field(index)
+			switch f.Kind() {
+			case reflect.Bool:
+				f.SetBool(r[i] != 0)
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				// the bits are two's complement, so the top bit is the sign
+				shift := 64 - widths[i]
+				f.SetInt(int64(r[i]<<shift) >> shift)
+			default:
+				f.SetUint(r[i])
+			}
+		}
+		return nil
+	}, nil
+}
+
+func checkBitField(field reflect.StructField, t fieldTag) error {
+	if t.kind != "" || t.length != "" || t.tag != "" {
+		return fmt.Errorf("bits can't be combined with other options")
+	}
+	switch field.Type.Kind() {
+	case reflect.Bool:
+		if t.bits != 1 {
+			return fmt.Errorf("bits=%d can't be stored in %s", t.bits, field.Type)
+		}
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if t.bits > field.Type.Bits() {
+			return fmt.Errorf("bits=%d can't be stored in %s", t.bits, field.Type)
+		}
+		return nil
+	}
+	return fmt.Errorf("bits=%d can't be stored in %s", t.bits, field.Type)
+}
+
+// newLength returns a function which gives the number of elements to read for the field. It is either the len option,
+// which is a number or the name of an earlier integer field, or the length of an array. A len field which holds a
+// length that doesn't fit in an int fails with ErrInvalidLength.
+func newLength(typ reflect.Type, field reflect.StructField, t fieldTag) (
+	func(in parser.Reader, v reflect.Value) (int, error), error,
+) {
+	if t.length == "" {
+		if field.Type.Kind() != reflect.Array {
+			return nil, fmt.Errorf("%s needs a len option", field.Type)
+		}
+		n := field.Type.Len()
+		return func(parser.Reader, reflect.Value) (int, error) { return n, nil }, nil
+	}
+	if field.Type.Kind() == reflect.Array {
+		return nil, fmt.Errorf("len can't be used with %s", field.Type)
+	}
+
+	if n, err := strconv.Atoi(t.length); err == nil {
+		if n < 0 {
+			return nil, fmt.Errorf("invalid len %d", n)
+		}
+		return func(parser.Reader, reflect.Value) (int, error) { return n, nil }, nil
+	}
+
+	ref, ok := typ.FieldByName(t.length)
+	if !ok || len(ref.Index) != 1 || ref.Index[0] >= field.Index[0] {
+		return nil, fmt.Errorf("len field %s isn't an earlier field", t.length)
+	}
+	index := ref.Index[0]
+	switch ref.Type.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(in parser.Reader, v reflect.Value) (int, error) {
+			n := v.Field(index).Int()
+			if n > math.MaxInt {
+				return 0, parser.NewError(in, ErrInvalidLength)
+			}
+			if n > 0 {
+				return int(n), nil
+			}
+			return 0, nil
+		}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return func(in parser.Reader, v reflect.Value) (int, error) {
+			n := v.Field(index).Uint()
+			if n > math.MaxInt {
+				return 0, parser.NewError(in, ErrInvalidLength)
+			}
+			return int(n), nil
+		}, nil
+	}
+	return nil, fmt.Errorf("len field %s isn't an integer", t.length)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func isBytes(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.String:
+		return true
+	case reflect.Slice, reflect.Array:
+		return typ.Elem().Kind() == reflect.Uint8
+	}
+	return false
+}
+
+// set stores r in f, converting it to named types such as `type Version uint8`.
+func set(f, r reflect.Value) {
+	f.Set(r.Convert(f.Type()))
+}
+
+func setBytes(f reflect.Value, b []byte) {
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(string(b))
+	case reflect.Array:
+		reflect.Copy(f, reflect.ValueOf(b))
+	default:
+		f.SetBytes(append([]byte(nil), b...))
+	}
+}
+
+// Struct decodes a binary record into a struct of type T, reading the tagged fields in order. Fields without a gobble
+// tag, or with the tag "-", are left unset. The tag gives the type of the value followed by its options:
+//   - `gobble:"u8"`, `gobble:"i16le"`, `gobble:"u32be"`, `gobble:"f64le"` or `gobble:"bool"` read a number, which can be
+//     stored in a field of the same kind, including named types.
+//   - `gobble:"u16be,len=Count"` reads a slice of numbers. The len option is a number or the name of an earlier integer
+//     field. Array fields, such as [4]uint16, are read without a len option.
+//   - `gobble:"bytes,len=Length"` and `gobble:"string,len=8"` read a block of bytes into a []byte, [n]byte or string.
+//   - `gobble:"bits=3"` reads bits, most significant first, into an integer field, or a bool field for a single bit.
+//     A signed field holds the bits as a two's complement number. Consecutive bit fields must add up to whole bytes.
+//   - `gobble:"tag=qoif"` matches the bytes of the tag, and stores them if the field can hold bytes. It can be used on
+//     a blank field, and is the only tag allowed on an unexported field.
+//   - `gobble:"struct"` decodes a nested struct.
+//
+// If the tags can't be used, the parser always fails with the fatal ErrInvalidTag.
+//   - If the input is too short, it will return io.EOF
+//   - If a tag doesn't match the input, it will return errors.ErrNotMatched
+//   - If a len field holds a length that doesn't fit in an int, it will return ErrInvalidLength
+func Struct[T any]() parser.Parser[parser.Reader, T] {
+	d, err := newStructDecoder(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return combinator.Fail[parser.Reader, T](errors.NewFatalError(ErrInvalidTag.Wrap(err)))
+	}
+	return &structParser[T]{decoder: d}
+}
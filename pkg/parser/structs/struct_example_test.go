@@ -0,0 +1,23 @@
+package structs_test
+
+import (
+	"fmt"
+	"github.com/roblovelock/gobble/pkg/parser/structs"
+)
+
+func ExampleStruct() {
+	type header struct {
+		_          struct{} `gobble:"tag=qoif"`
+		Width      uint32   `gobble:"u32be"`
+		Height     uint32   `gobble:"u32be"`
+		Channels   uint8    `gobble:"u8"`
+		ColorSpace uint8    `gobble:"u8"`
+	}
+
+	input := []byte("qoif\x00\x00\x01\x00\x00\x00\x00\x80\x04\x00rest")
+	result, remainder, err := structs.Struct[header]().ParseBytes(input)
+	fmt.Printf("Match: %+v, Error: %v, Remainder: '%s'", result, err, string(remainder))
+
+	// Output:
+	// Match: {_:{} Width:256 Height:128 Channels:4 ColorSpace:0}, Error: <nil>, Remainder: 'rest'
+}
@@ -0,0 +1,295 @@
+package structs_test
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/structs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+type (
+	channels uint8
+
+	qoiHeader struct {
+		Magic      [4]byte  `gobble:"tag=qoif"`
+		Width      uint32   `gobble:"u32be"`
+		Height     uint32   `gobble:"u32be"`
+		Channels   channels `gobble:"u8"`
+		ColorSpace uint8    `gobble:"u8"`
+	}
+
+	record struct {
+		_       struct{} `gobble:"tag=R"`
+		Count   uint8    `gobble:"u8"`
+		Values  []int16  `gobble:"i16le,len=Count"`
+		Length  uint16   `gobble:"u16be"`
+		Name    string   `gobble:"bytes,len=Length"`
+		Flags   flags    `gobble:"struct"`
+		Size    int      `gobble:"-"`
+		Padding [2]byte  `gobble:"bytes"`
+	}
+
+	flags struct {
+		Compressed bool  `gobble:"bits=1"`
+		Version    uint8 `gobble:"bits=3"`
+		Level      int   `gobble:"bits=4"`
+	}
+)
+
+func TestStruct(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantMatch  record
+		wantRemain string
+		wantErr    error
+	}{
+		{
+			name:    "empty input => EOF",
+			input:   "",
+			wantErr: io.EOF,
+		},
+		{
+			name:       "tag mismatch => no match",
+			input:      "X\x00\x00\x00\xa5..",
+			wantRemain: "X\x00\x00\x00\xa5..",
+			wantErr:    errors.ErrNotMatched,
+		},
+		{
+			name:       "too short => EOF",
+			input:      "R\x02\x01\x00\xff\xff\x00\x03ab",
+			wantRemain: "R\x02\x01\x00\xff\xff\x00\x03ab",
+			wantErr:    io.EOF,
+		},
+		{
+			name:  "record => fields",
+			input: "R\x02\x01\x00\xff\xff\x00\x03abc\xa5..!",
+			wantMatch: record{
+				Count:   2,
+				Values:  []int16{1, -1},
+				Length:  3,
+				Name:    "abc",
+				Flags:   flags{Compressed: true, Version: 2, Level: 5},
+				Padding: [2]byte{'.', '.'},
+			},
+			wantRemain: "!",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := structs.Struct[record]()
+
+			input := strings.NewReader(tt.input)
+			s, err := p.Parse(input)
+
+			assert.Equal(t, tt.wantMatch, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			remain, err := io.ReadAll(input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRemain, string(remain))
+
+			s, out, err := p.ParseBytes([]byte(tt.input))
+
+			assert.Equal(t, tt.wantMatch, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantRemain, string(out))
+		})
+	}
+}
+
+func TestStruct_errorOffset(t *testing.T) {
+	input := []byte("qoif\x00\x00\x00\x01\x00")
+
+	_, _, err := structs.Struct[qoiHeader]().ParseBytes(input)
+
+	require.ErrorIs(t, err, io.EOF)
+	e, ok := errors.AsParseError(err)
+	require.True(t, ok)
+	assert.Equal(t, int64(8), e.StartOffset(int64(len(input))))
+}
+
+func TestStruct_length(t *testing.T) {
+	type (
+		values struct {
+			Count  uint64   `gobble:"u64be"`
+			Values []uint16 `gobble:"u16be,len=Count"`
+		}
+		block struct {
+			Length uint32 `gobble:"u32be"`
+			Data   []byte `gobble:"bytes,len=Length"`
+		}
+	)
+	tests := []struct {
+		name    string
+		parser  parser.Parser[parser.Reader, struct{}]
+		input   string
+		wantErr error
+	}{
+		{
+			name:    "length overflows int => invalid length",
+			parser:  parse[values](),
+			input:   "\xff\xff\xff\xff\xff\xff\xff\xff\x00\x01",
+			wantErr: structs.ErrInvalidLength,
+		},
+		{
+			name:    "values longer than input => EOF",
+			parser:  parse[values](),
+			input:   "\x00\x00\x00\x00\x7f\xff\xff\xff\x00\x01",
+			wantErr: io.EOF,
+		},
+		{
+			name:    "bytes longer than input => EOF",
+			parser:  parse[block](),
+			input:   "\xff\xff\xff\xff" + strings.Repeat("a", 5000),
+			wantErr: io.EOF,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := strings.NewReader(tt.input)
+			_, err := tt.parser.Parse(input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.False(t, errors.IsFatal(err))
+			remain, err := io.ReadAll(input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.input, string(remain))
+
+			_, out, err := tt.parser.ParseBytes([]byte(tt.input))
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.input, string(out))
+		})
+	}
+}
+
+func TestStruct_longBytes(t *testing.T) {
+	type block struct {
+		Length uint16 `gobble:"u16be"`
+		Data   []byte `gobble:"bytes,len=Length"`
+	}
+	data := strings.Repeat("abc", 3000)
+
+	s, out, err := structs.Struct[block]().ParseBytes([]byte("\x23\x28" + data + "!"))
+
+	require.NoError(t, err)
+	assert.Equal(t, block{Length: 9000, Data: []byte(data)}, s)
+	assert.Equal(t, []byte("!"), out)
+}
+
+func TestStruct_invalidTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		parser  parser.Parser[parser.Reader, struct{}]
+		wantMsg string
+	}{
+		{
+			name: "unknown type => invalid",
+			parser: parse[struct {
+				A uint32 `gobble:"u33"`
+			}](),
+			wantMsg: `field A: unknown type "u33"`,
+		},
+		{
+			name: "wrong field type => invalid",
+			parser: parse[struct {
+				A uint16 `gobble:"u32be"`
+			}](),
+			wantMsg: "field A: u32be can't be stored in uint16",
+		},
+		{
+			name: "len field after => invalid",
+			parser: parse[struct {
+				A []byte `gobble:"bytes,len=B"`
+				B uint8  `gobble:"u8"`
+			}](),
+			wantMsg: "field A: len field B isn't an earlier field",
+		},
+		{
+			name: "bytes without length => invalid",
+			parser: parse[struct {
+				A []byte `gobble:"bytes"`
+			}](),
+			wantMsg: "field A: []uint8 needs a len option",
+		},
+		{
+			name: "len on scalar => invalid",
+			parser: parse[struct {
+				A uint8 `gobble:"u8,len=2"`
+			}](),
+			wantMsg: "field A: u8 can't be stored in uint8",
+		},
+		{
+			name: "unaligned bits => invalid",
+			parser: parse[struct {
+				A uint8 `gobble:"bits=3"`
+				B uint8 `gobble:"u8"`
+			}](),
+			wantMsg: "field A: bit fields end after 3 bits, not on a byte boundary",
+		},
+		{
+			name: "unexported field => invalid",
+			parser: parse[struct {
+				a uint8 `gobble:"u8"`
+			}](),
+			wantMsg: "field a: unexported fields can't be decoded",
+		},
+		{
+			name: "too many bits => invalid",
+			parser: parse[struct {
+				A uint8 `gobble:"bits=9"`
+			}](),
+			wantMsg: "field A: bits=9 can't be stored in uint8",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.parser.Parse(strings.NewReader("abcd"))
+
+			assert.ErrorIs(t, err, structs.ErrInvalidTag)
+			assert.True(t, errors.IsFatal(err))
+			assert.EqualError(t, err, "structs: invalid tag: "+tt.wantMsg)
+		})
+	}
+}
+
+// parse erases the type of a struct parser, so parsers for different struct types can be listed in a table.
+func parse[T any]() parser.Parser[parser.Reader, struct{}] {
+	return &erasedParser[T]{parser: structs.Struct[T]()}
+}
+
+type erasedParser[T any] struct {
+	parser parser.Parser[parser.Reader, T]
+}
+
+func (e *erasedParser[T]) Parse(in parser.Reader) (struct{}, error) {
+	_, err := e.parser.Parse(in)
+	return struct{}{}, err
+}
+
+func (e *erasedParser[T]) ParseBytes(in []byte) (struct{}, []byte, error) {
+	_, out, err := e.parser.ParseBytes(in)
+	return struct{}{}, out, err
+}
+
+func TestStruct_signedBits(t *testing.T) {
+	type nibbles struct {
+		High int8  `gobble:"bits=4"`
+		Low  int16 `gobble:"bits=4"`
+	}
+	p := structs.Struct[nibbles]()
+
+	s, out, err := p.ParseBytes([]byte{0xf7})
+	require.NoError(t, err)
+	assert.Empty(t, out)
+	assert.Equal(t, nibbles{High: -1, Low: 7}, s)
+
+	s, err = p.Parse(strings.NewReader("\x8f"))
+	require.NoError(t, err)
+	assert.Equal(t, nibbles{High: -8, Low: -1}, s)
+}
@@ -0,0 +1,76 @@
+package structs
+
+import (
+	"fmt"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/numeric"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const tagName = "gobble"
+
+type (
+	// fieldTag is the parsed gobble tag of a struct field, e.g. `gobble:"u16be,len=Count"`.
+	fieldTag struct {
+		kind   string // value type, e.g. u32be, bytes, string or struct
+		length string // len option, either a number or the name of an earlier integer field
+		bits   int    // bits option
+		tag    string // tag option
+	}
+
+	// scalar is a fixed size value type which can be used in a tag.
+	scalar struct {
+		parser parser.Parser[parser.Reader, interface{}]
+		kind   reflect.Kind
+	}
+)
+
+var scalars = map[string]scalar{
+	"bool":  {parser: parser.Untyped(numeric.Bool()), kind: reflect.Bool},
+	"u8":    {parser: parser.Untyped(numeric.UInt8()), kind: reflect.Uint8},
+	"i8":    {parser: parser.Untyped(numeric.Int8()), kind: reflect.Int8},
+	"u16be": {parser: parser.Untyped(numeric.Uint16BE()), kind: reflect.Uint16},
+	"u16le": {parser: parser.Untyped(numeric.Uint16LE()), kind: reflect.Uint16},
+	"i16be": {parser: parser.Untyped(numeric.Int16BE()), kind: reflect.Int16},
+	"i16le": {parser: parser.Untyped(numeric.Int16LE()), kind: reflect.Int16},
+	"u32be": {parser: parser.Untyped(numeric.Uint32BE()), kind: reflect.Uint32},
+	"u32le": {parser: parser.Untyped(numeric.Uint32LE()), kind: reflect.Uint32},
+	"i32be": {parser: parser.Untyped(numeric.Int32BE()), kind: reflect.Int32},
+	"i32le": {parser: parser.Untyped(numeric.Int32LE()), kind: reflect.Int32},
+	"u64be": {parser: parser.Untyped(numeric.Uint64BE()), kind: reflect.Uint64},
+	"u64le": {parser: parser.Untyped(numeric.UInt64LE()), kind: reflect.Uint64},
+	"i64be": {parser: parser.Untyped(numeric.Int64BE()), kind: reflect.Int64},
+	"i64le": {parser: parser.Untyped(numeric.Int64LE()), kind: reflect.Int64},
+	"f32be": {parser: parser.Untyped(numeric.Float32BE()), kind: reflect.Float32},
+	"f32le": {parser: parser.Untyped(numeric.Float32LE()), kind: reflect.Float32},
+	"f64be": {parser: parser.Untyped(numeric.Float64BE()), kind: reflect.Float64},
+	"f64le": {parser: parser.Untyped(numeric.Float64LE()), kind: reflect.Float64},
+}
+
+// parseTag parses the options of a gobble tag. The first option without a value is the kind.
+func parseTag(s string) (fieldTag, error) {
+	var t fieldTag
+	for _, option := range strings.Split(s, ",") {
+		option = strings.TrimSpace(option)
+		key, value, hasValue := strings.Cut(option, "=")
+		switch {
+		case !hasValue && t.kind == "":
+			t.kind = key
+		case key == "len" && value != "":
+			t.length = value
+		case key == "bits":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > 64 {
+				return t, fmt.Errorf("invalid bits %q", value)
+			}
+			t.bits = n
+		case key == "tag" && value != "":
+			t.tag = value
+		default:
+			return t, fmt.Errorf("unknown option %q", option)
+		}
+	}
+	return t, nil
+}
@@ -0,0 +1,37 @@
+package token
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+)
+
+type (
+	anyParser[T any] struct{}
+)
+
+var anyExpected = []string{"any token"}
+
+func (o *anyParser[T]) Parse(in Reader[T]) (T, error) {
+	t, err := in.ReadToken()
+	if err != nil {
+		return t, parser.NewExpectedError(in, err, anyExpected)
+	}
+	return t, nil
+}
+
+func (o *anyParser[T]) ParseBytes(in []byte) (T, []byte, error) {
+	var t T
+	return t, in, errors.ErrNotSupported
+}
+
+func (o *anyParser[T]) Expected() []string {
+	return anyExpected
+}
+
+// Any reads a single token
+//
+//   - If the input isn't empty, it will return a single token.
+//   - If the input is empty, it will return io.EOF
+func Any[T any]() parser.Parser[Reader[T], T] {
+	return &anyParser[T]{}
+}
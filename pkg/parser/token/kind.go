@@ -0,0 +1,19 @@
+package token
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+)
+
+// Kind matches a single token of the given kind
+//
+// The type parameters can't be inferred from the kind alone, e.g. token.Kind[SQLKind, SQLToken](Select).
+//   - If the token is of the kind, it will return the token.
+//   - If the input is empty, it will return io.EOF
+//   - If the token is of a different kind, it will return errors.ErrNotMatched
+func Kind[K comparable, T Token[K]](kind K) parser.Parser[Reader[T], T] {
+	return &satisfyParser[T]{
+		predicate: func(t T) bool { return t.Kind() == kind },
+		expected:  []string{errors.Quote(kind)},
+	}
+}
@@ -0,0 +1,64 @@
+package token_test
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser/token"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func TestKind(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      []sqlToken
+		wantMatch  sqlToken
+		wantRemain []sqlToken
+		wantErr    error
+	}{
+		{
+			name:    "empty input => EOF",
+			wantErr: io.EOF,
+		},
+		{
+			name:       "kind mismatch => no match",
+			input:      []sqlToken{{kind: keyword, value: "SELECT"}},
+			wantRemain: []sqlToken{{kind: keyword, value: "SELECT"}},
+			wantErr:    errors.ErrNotMatched,
+		},
+		{
+			name:       "kind match => token",
+			input:      []sqlToken{{kind: identifier, value: "a"}, {kind: comma}},
+			wantMatch:  sqlToken{kind: identifier, value: "a"},
+			wantRemain: []sqlToken{{kind: comma}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := token.Kind[kind, sqlToken](identifier)
+			input := token.NewSliceReader(tt.input)
+
+			s, err := p.Parse(input)
+
+			assert.Equal(t, tt.wantMatch, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantRemain, remaining[sqlToken](t, input))
+		})
+	}
+}
+
+func TestKind_error(t *testing.T) {
+	input := token.NewSliceReader([]sqlToken{{kind: identifier, value: "a"}, {kind: keyword, value: "FROM"}})
+	_, _ = input.ReadToken()
+
+	_, err := token.Kind[kind, sqlToken](comma).Parse(input)
+
+	assert.EqualError(t, err, "expected ',' at offset 1")
+}
+
+func TestKind_bytes(t *testing.T) {
+	_, out, err := token.Kind[kind, sqlToken](comma).ParseBytes([]byte(","))
+
+	assert.ErrorIs(t, err, errors.ErrNotSupported)
+	assert.Equal(t, []byte(","), out)
+}
@@ -0,0 +1,85 @@
+// Package token provides parsers for recognizing tokens produced by a separate lexer
+package token
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"io"
+)
+
+var (
+	ErrInvalidWhence = errors.Error("token.SliceReader.Seek: invalid whence")
+	ErrNegativeSeek  = errors.Error("token.SliceReader.Seek: negative position")
+)
+
+type (
+	// Reader is a stream of tokens. It is a parser.Reader, so the combinators can run over it, but offsets count tokens
+	// rather than bytes. Byte parsers can't be used with it, as reading bytes returns errors.ErrNotSupported.
+	Reader[T any] interface {
+		parser.Reader
+		ReadToken() (T, error)
+	}
+
+	// Token is implemented by tokens which have a kind, such as a keyword, identifier or operator.
+	Token[K comparable] interface {
+		Kind() K
+	}
+
+	// SliceReader is a Reader over a slice of tokens.
+	SliceReader[T any] struct {
+		tokens []T
+		pos    int64
+	}
+)
+
+// NewSliceReader returns a Reader over the tokens.
+func NewSliceReader[T any](tokens []T) *SliceReader[T] {
+	return &SliceReader[T]{tokens: tokens}
+}
+
+// ReadToken returns the next token. At the end of the tokens it returns io.EOF.
+func (r *SliceReader[T]) ReadToken() (T, error) {
+	if r.pos >= int64(len(r.tokens)) {
+		var t T
+		return t, io.EOF
+	}
+	t := r.tokens[r.pos]
+	r.pos++
+	return t, nil
+}
+
+// Seek sets the index of the next token to read.
+func (r *SliceReader[T]) Seek(offset int64, whence int) (int64, error) {
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = r.pos + offset
+	case io.SeekEnd:
+		abs = int64(len(r.tokens)) + offset
+	default:
+		return r.pos, ErrInvalidWhence
+	}
+
+	if abs < 0 {
+		return r.pos, ErrNegativeSeek
+	}
+	r.pos = abs
+	return abs, nil
+}
+
+// Read isn't supported by a token stream.
+func (r *SliceReader[T]) Read([]byte) (int, error) {
+	return 0, errors.ErrNotSupported
+}
+
+// ReadByte isn't supported by a token stream.
+func (r *SliceReader[T]) ReadByte() (byte, error) {
+	return 0, errors.ErrNotSupported
+}
+
+// ReadRune isn't supported by a token stream.
+func (r *SliceReader[T]) ReadRune() (rune, int, error) {
+	return 0, 0, errors.ErrNotSupported
+}
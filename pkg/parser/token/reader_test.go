@@ -0,0 +1,117 @@
+package token_test
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"testing"
+)
+
+const (
+	keyword kind = iota
+	identifier
+	comma
+)
+
+type (
+	kind int
+
+	sqlToken struct {
+		kind  kind
+		value string
+	}
+)
+
+func (k kind) String() string {
+	return [...]string{"keyword", "identifier", "','"}[k]
+}
+
+func (t sqlToken) Kind() kind {
+	return t.kind
+}
+
+func remaining[T any](t *testing.T, r token.Reader[T]) []T {
+	var tokens []T
+	for {
+		tok, err := r.ReadToken()
+		if err == io.EOF {
+			return tokens
+		}
+		require.NoError(t, err)
+		tokens = append(tokens, tok)
+	}
+}
+
+func TestSliceReader_Seek(t *testing.T) {
+	tests := []struct {
+		name       string
+		offset     int64
+		whence     int
+		wantOffset int64
+		wantRemain []int
+		wantErr    error
+	}{
+		{
+			name:       "seek start => token index",
+			offset:     1,
+			whence:     io.SeekStart,
+			wantOffset: 1,
+			wantRemain: []int{2, 3},
+		},
+		{
+			name:       "seek current => relative to current token",
+			offset:     -1,
+			whence:     io.SeekCurrent,
+			wantOffset: 0,
+			wantRemain: []int{1, 2, 3},
+		},
+		{
+			name:       "seek end => relative to end",
+			offset:     -1,
+			whence:     io.SeekEnd,
+			wantOffset: 2,
+			wantRemain: []int{3},
+		},
+		{
+			name:       "negative => error",
+			offset:     -2,
+			whence:     io.SeekCurrent,
+			wantOffset: 1,
+			wantRemain: []int{2, 3},
+			wantErr:    token.ErrNegativeSeek,
+		},
+		{
+			name:       "invalid whence => error",
+			whence:     3,
+			wantOffset: 1,
+			wantRemain: []int{2, 3},
+			wantErr:    token.ErrInvalidWhence,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := token.NewSliceReader([]int{1, 2, 3})
+			_, _ = r.ReadToken()
+
+			offset, err := r.Seek(tt.offset, tt.whence)
+
+			assert.Equal(t, tt.wantOffset, offset)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantRemain, remaining[int](t, r))
+		})
+	}
+}
+
+func TestSliceReader_bytes(t *testing.T) {
+	r := token.NewSliceReader([]int{1})
+
+	_, err := r.ReadByte()
+	assert.ErrorIs(t, err, errors.ErrNotSupported)
+	_, _, err = r.ReadRune()
+	assert.ErrorIs(t, err, errors.ErrNotSupported)
+	_, err = r.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, errors.ErrNotSupported)
+	assert.Equal(t, []int{1}, remaining[int](t, r))
+}
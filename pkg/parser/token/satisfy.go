@@ -0,0 +1,47 @@
+package token
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"io"
+)
+
+type (
+	satisfyParser[T any] struct {
+		predicate parser.Predicate[T]
+		expected  []string
+	}
+)
+
+func (o *satisfyParser[T]) Parse(in Reader[T]) (T, error) {
+	t, err := in.ReadToken()
+	if err != nil {
+		return t, parser.NewExpectedError(in, err, o.expected)
+	}
+
+	if !o.predicate(t) {
+		_, _ = in.Seek(-1, io.SeekCurrent)
+		var zero T
+		return zero, parser.NewExpectedError(in, errors.ErrNotMatched, o.expected)
+	}
+
+	return t, nil
+}
+
+func (o *satisfyParser[T]) ParseBytes(in []byte) (T, []byte, error) {
+	var t T
+	return t, in, errors.ErrNotSupported
+}
+
+func (o *satisfyParser[T]) Expected() []string {
+	return o.expected
+}
+
+// Satisfy matches a single token using a predicate
+//
+//   - If the predicate returns true, it will return the token.
+//   - If the input is empty, it will return io.EOF
+//   - If the predicate returns false, it will return errors.ErrNotMatched
+func Satisfy[T any](predicate parser.Predicate[T]) parser.Parser[Reader[T], T] {
+	return &satisfyParser[T]{predicate: predicate}
+}
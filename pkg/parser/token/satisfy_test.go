@@ -0,0 +1,81 @@
+package token_test
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser/token"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func TestSatisfy(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      []int
+		wantMatch  int
+		wantRemain []int
+		wantErr    error
+	}{
+		{
+			name:    "empty input => EOF",
+			wantErr: io.EOF,
+		},
+		{
+			name:       "predicate false => no match",
+			input:      []int{1, 2},
+			wantRemain: []int{1, 2},
+			wantErr:    errors.ErrNotMatched,
+		},
+		{
+			name:       "predicate true => token",
+			input:      []int{2, 1},
+			wantMatch:  2,
+			wantRemain: []int{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := token.Satisfy(func(i int) bool { return i%2 == 0 })
+			input := token.NewSliceReader(tt.input)
+
+			s, err := p.Parse(input)
+
+			assert.Equal(t, tt.wantMatch, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantRemain, remaining[int](t, input))
+		})
+	}
+}
+
+func TestAny(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      []int
+		wantMatch  int
+		wantRemain []int
+		wantErr    error
+	}{
+		{
+			name:    "empty input => EOF",
+			wantErr: io.EOF,
+		},
+		{
+			name:       "token => token",
+			input:      []int{1, 2},
+			wantMatch:  1,
+			wantRemain: []int{2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := token.Any[int]()
+			input := token.NewSliceReader(tt.input)
+
+			s, err := p.Parse(input)
+
+			assert.Equal(t, tt.wantMatch, s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantRemain, remaining[int](t, input))
+		})
+	}
+}
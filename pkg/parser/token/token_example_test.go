@@ -0,0 +1,33 @@
+package token_test
+
+import (
+	"fmt"
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/combinator/multi"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/parser/token"
+)
+
+func ExampleKind() {
+	column := modifier.Map(token.Kind[kind, sqlToken](identifier), func(t sqlToken) (string, error) {
+		return t.value, nil
+	})
+	selectParser := sequence.Preceded(
+		token.Satisfy(func(t sqlToken) bool { return t.kind == keyword && t.value == "SELECT" }),
+		multi.Separated1(column, token.Kind[kind, sqlToken](comma)),
+	)
+
+	input := token.NewSliceReader([]sqlToken{
+		{kind: keyword, value: "SELECT"},
+		{kind: identifier, value: "a"},
+		{kind: comma},
+		{kind: identifier, value: "b"},
+		{kind: keyword, value: "FROM"},
+	})
+	result, err := selectParser.Parse(input)
+	next, _ := input.ReadToken()
+	fmt.Printf("Result: %v, Error: %v, Next: %s\n", result, err, next.value)
+
+	// Output:
+	// Result: [a b], Error: <nil>, Next: FROM
+}
@@ -0,0 +1,232 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// traceSnippetLen is the maximum number of bytes of input included in a TraceEvent.
+const traceSnippetLen = 16
+
+const (
+	TraceEnter TraceEventKind = iota // a rule was called
+	TraceExit                        // a rule returned
+	TraceBegin                       // a Traced parser was called, before any of its rules
+)
+
+type (
+	// TraceEventKind is whether a TraceEvent is the call or return of a rule, or the start of a traced parse.
+	TraceEventKind int
+
+	// TraceEvent is a call or return of a Rule in a traced parse. A TraceBegin event only has its Depth and Start set.
+	TraceEvent struct {
+		Kind TraceEventKind
+		// Rule is the name of the rule.
+		Rule string
+		// Depth is the number of enclosing rules.
+		Depth int
+		// Start is the offset the rule was called at. For ParseBytes it is relative to the start of the traced input.
+		Start int64
+		// End is the offset after the rule returned. It is only set by TraceExit.
+		End int64
+		// Input is the start of the input at Start. For Parse, it's only taken from input the reader holds in memory, such
+		// as the unread input of a bytes.Reader or the buffer of a stream.BufferedReader, so tracing never waits for
+		// more input to arrive.
+		Input []byte
+		// Result is the result of the rule. It is only set by TraceExit.
+		Result any
+		// Err is the error returned by the rule. It is only set by TraceExit.
+		Err error
+		// Duration is the time taken by the rule, including enclosed rules. It is only set by TraceExit.
+		Duration time.Duration
+	}
+
+	// TraceFunc receives the events of a traced parse.
+	TraceFunc func(TraceEvent)
+
+	ruleParser[R Reader, T any] struct {
+		parser Child[R, T]
+		name   string
+	}
+
+	tracedParser[R Reader, T any] struct {
+		parser Child[R, T]
+		trace  TraceFunc
+	}
+
+	tracer struct {
+		trace TraceFunc
+		depth int
+	}
+
+	// inMemory is implemented by readers which report how much of their unread input can be read without blocking,
+	// such as bytes.Reader, strings.Reader and stream.BufferedReader.
+	inMemory interface {
+		Len() int
+	}
+)
+
+func (o *ruleParser[R, T]) Parse(in R) (T, error) {
+	return o.ParseSession(NewSession(), in)
+}
+
+func (o *ruleParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
+	return o.ParseBytesSession(NewBytesSession(in), in)
+}
+
+func (o *ruleParser[R, T]) ParseSession(s *Session, in R) (T, error) {
+	t := s.tracer
+	if t == nil {
+		return o.parser.ParseIn(s, in)
+	}
+
+	start, _ := in.Seek(0, io.SeekCurrent)
+	e := TraceEvent{Kind: TraceEnter, Rule: o.name, Depth: t.depth, Start: start, Input: snippet(in, start)}
+	t.trace(e)
+	t.depth++
+	startTime := time.Now()
+	r, err := o.parser.ParseIn(s, in)
+	e.Duration = time.Since(startTime)
+	t.depth--
+
+	e.Kind, e.Result, e.Err = TraceExit, r, err
+	e.End, _ = in.Seek(0, io.SeekCurrent)
+	t.trace(e)
+	return r, err
+}
+
+func (o *ruleParser[R, T]) ParseBytesSession(s *Session, in []byte) (T, []byte, error) {
+	t := s.tracer
+	if t == nil {
+		return o.parser.ParseBytesIn(s, in)
+	}
+
+	snippet := in
+	if len(snippet) > traceSnippetLen {
+		snippet = snippet[:traceSnippetLen]
+	}
+
+	e := TraceEvent{Kind: TraceEnter, Rule: o.name, Depth: t.depth, Start: s.Offset(in), Input: snippet}
+	t.trace(e)
+	t.depth++
+	startTime := time.Now()
+	r, out, err := o.parser.ParseBytesIn(s, in)
+	e.Duration = time.Since(startTime)
+	t.depth--
+
+	e.Kind, e.Result, e.Err = TraceExit, r, err
+	e.End = s.Offset(out)
+	t.trace(e)
+	return r, out, err
+}
+
+func (o *ruleParser[R, T]) Describe() Grammar {
+	return Grammar{Kind: GrammarRule, Name: o.name, Children: []any{o.parser}}
+}
+
+func (o *ruleParser[R, T]) Expected() []string {
+	return ExpectedOf(o.parser)
+}
+
+func (o *tracedParser[R, T]) Parse(in R) (T, error) {
+	return o.ParseSession(NewSession(), in)
+}
+
+func (o *tracedParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
+	return o.ParseBytesSession(NewBytesSession(in), in)
+}
+
+func (o *tracedParser[R, T]) ParseSession(s *Session, in R) (T, error) {
+	start, _ := in.Seek(0, io.SeekCurrent)
+	defer o.enter(s, start)()
+	return o.parser.ParseIn(s, in)
+}
+
+func (o *tracedParser[R, T]) ParseBytesSession(s *Session, in []byte) (T, []byte, error) {
+	defer o.enter(s, s.Offset(in))()
+	return o.parser.ParseBytesIn(s, in)
+}
+
+func (o *tracedParser[R, T]) Describe() Grammar {
+	return NewGrammar(GrammarSequence, o.parser)
+}
+
+func (o *tracedParser[R, T]) Expected() []string {
+	return ExpectedOf(o.parser)
+}
+
+// enter starts tracing the session with a TraceBegin event at start. Within a traced parse, the events of the rules are
+// also passed to the enclosing trace, and the depth carries on from the enclosing rule.
+func (o *tracedParser[R, T]) enter(s *Session, start int64) func() {
+	prev := s.tracer
+	t := &tracer{trace: o.trace}
+	if prev != nil {
+		t.depth = prev.depth
+		t.trace = func(e TraceEvent) {
+			prev.trace(e)
+			o.trace(e)
+		}
+	}
+	s.tracer = t
+	o.trace(TraceEvent{Kind: TraceBegin, Depth: t.depth, Start: start})
+	return func() {
+		s.tracer = prev
+	}
+}
+
+// snippet returns up to traceSnippetLen bytes of the input at start, if the reader holds them in memory.
+func snippet[R Reader](in R, start int64) []byte {
+	m, ok := any(in).(inMemory)
+	if !ok {
+		return nil
+	}
+	n := m.Len()
+	if n > traceSnippetLen {
+		n = traceSnippetLen
+	}
+	b := make([]byte, n)
+	n, _ = io.ReadFull(in, b)
+	_, _ = in.Seek(start, io.SeekStart)
+	return b[:n]
+}
+
+// Rule names a rule of a grammar. When the parse is Traced, each call of the rule is reported to the TraceFunc.
+// Otherwise, the rule calls the parser directly.
+func Rule[R Reader, T any](name string, p Parser[R, T]) Parser[R, T] {
+	return &ruleParser[R, T]{parser: NewChild(p), name: name}
+}
+
+// Traced reports the calls of each Rule to fn while the parser runs, so one parse can be traced without changing the
+// grammar. A parser Traced within a traced parse nests under the enclosing trace, which also receives its events.
+func Traced[R Reader, T any](p Parser[R, T], fn TraceFunc) Parser[R, T] {
+	return &tracedParser[R, T]{parser: NewChild(p), trace: fn}
+}
+
+// TraceWriter returns a TraceFunc which writes the calls of the rules to w as an indented tree. Each call is written
+// with its start offset and input, and each return with its offsets, the number of bytes consumed, the time taken and
+// the result or error. It is safe to use from separate parses at the same time.
+func TraceWriter(w io.Writer) TraceFunc {
+	var mu sync.Mutex
+	return func(e TraceEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		indent := strings.Repeat("  ", e.Depth)
+		switch e.Kind {
+		case TraceBegin:
+			return
+		case TraceEnter:
+			_, _ = fmt.Fprintf(w, "%s%s @%d %q\n", indent, e.Rule, e.Start, e.Input)
+			return
+		}
+		outcome := fmt.Sprintf("ok %v", e.Result)
+		if e.Err != nil {
+			outcome = fmt.Sprintf("error %v", e.Err)
+		}
+		_, _ = fmt.Fprintf(w, "%s%s @%d-%d (%d bytes, %s): %s\n",
+			indent, e.Rule, e.Start, e.End, e.End-e.Start, e.Duration, outcome)
+	}
+}
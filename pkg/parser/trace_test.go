@@ -0,0 +1,140 @@
+package parser_test
+
+import (
+	"fmt"
+	"github.com/roblovelock/gobble/pkg/combinator/branch"
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/combinator/multi"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/roblovelock/gobble/pkg/parser/stream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// pairs = pair (',' pair)*
+// pair = 'a' 'b' | 'a' 'c'
+func tracedPairs() parser.Parser[parser.Reader, [][]byte] {
+	pair := parser.Rule("pair", branch.Alt(
+		sequence.Recognize(sequence.Pair(bytes.Byte('a'), bytes.Byte('b'))),
+		sequence.Recognize(sequence.Pair(bytes.Byte('a'), modifier.Label(bytes.Byte('c'), "c"))),
+	))
+	return parser.Rule("pairs", multi.Separated1(pair, bytes.Byte(',')))
+}
+
+func TestTraced(t *testing.T) {
+	want := []string{
+		`begin 0 0`,
+		`enter pairs 0 0 "ab,ac"`,
+		`enter pair 1 0 "ab,ac"`,
+		`exit pair 1 0-2 [97 98] <nil>`,
+		`enter pair 1 3 "ac"`,
+		`enter c 2 4 "c"`,
+		`exit c 2 4-5 99 <nil>`,
+		`exit pair 1 3-5 [97 99] <nil>`,
+		`exit pairs 0 0-5 [[97 98] [97 99]] <nil>`,
+	}
+	var events []string
+	trace := func(e parser.TraceEvent) {
+		switch e.Kind {
+		case parser.TraceBegin:
+			events = append(events, fmt.Sprintf("begin %d %d", e.Depth, e.Start))
+			return
+		case parser.TraceEnter:
+			events = append(events, fmt.Sprintf("enter %s %d %d %q", e.Rule, e.Depth, e.Start, e.Input))
+			return
+		}
+		events = append(events, fmt.Sprintf("exit %s %d %d-%d %v %v", e.Rule, e.Depth, e.Start, e.End, e.Result, e.Err))
+	}
+	p := parser.Traced(tracedPairs(), trace)
+
+	_, err := p.Parse(strings.NewReader("ab,ac"))
+	require.NoError(t, err)
+	assert.Equal(t, want, events)
+
+	events = nil
+	_, _, err = p.ParseBytes([]byte("ab,ac"))
+	require.NoError(t, err)
+	assert.Equal(t, want, events)
+}
+
+func TestTraced_notTraced(t *testing.T) {
+	var events []parser.TraceEvent
+	traced := parser.Traced(tracedPairs(), func(e parser.TraceEvent) { events = append(events, e) })
+
+	_, err := tracedPairs().Parse(strings.NewReader("ab"))
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	_, err = traced.Parse(strings.NewReader("ab"))
+	require.NoError(t, err)
+	assert.Len(t, events, 5)
+}
+
+func TestTraceWriter(t *testing.T) {
+	var out strings.Builder
+	p := parser.Traced(tracedPairs(), parser.TraceWriter(&out))
+
+	_, err := p.Parse(strings.NewReader("ax"))
+	require.Error(t, err)
+
+	trace := regexp.MustCompile(`\([0-9]+ bytes, [^)]*\)`).ReplaceAllString(out.String(), "(duration)")
+	assert.Equal(t, `pairs @0 "ax"
+  pair @0 "ax"
+    c @1 "x"
+    c @1-1 (duration): error expected 'c' at offset 1
+  pair @0-0 (duration): error expected 'b' or 'c' at offset 1
+pairs @0-0 (duration): error not matched at offset 0
+`, trace)
+}
+
+func TestTraced_nested(t *testing.T) {
+	var outer, inner []string
+	record := func(events *[]string) parser.TraceFunc {
+		return func(e parser.TraceEvent) {
+			if e.Kind == parser.TraceEnter {
+				*events = append(*events, fmt.Sprintf("enter %s %d", e.Rule, e.Depth))
+			}
+		}
+	}
+	pair := parser.Traced(parser.Rule("pair", bytes.Tag([]byte("ab"))), record(&inner))
+	p := parser.Traced(parser.Rule("pairs", multi.Separated1(pair, bytes.Byte(','))), record(&outer))
+
+	_, err := p.Parse(strings.NewReader("ab,ab"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"enter pairs 0", "enter pair 1", "enter pair 1"}, outer)
+	assert.Equal(t, []string{"enter pair 1", "enter pair 1"}, inner)
+}
+
+func TestTraced_inputBuffered(t *testing.T) {
+	var inputs []string
+	p := parser.Traced(tracedPairs(), func(e parser.TraceEvent) {
+		if e.Kind == parser.TraceEnter {
+			inputs = append(inputs, string(e.Input))
+		}
+	})
+
+	// the source only returns one byte at a time, so the snippet can't include input which hasn't been read yet
+	in := stream.NewBufferedReaderSize(iotest.OneByteReader(strings.NewReader("ab,ac")), 1)
+	_, err := p.Parse(in)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "", "", "c"}, inputs)
+}
+
+func TestDebug_totals(t *testing.T) {
+	debug := parser.Debug(tracedPairs())
+	p := parser.Traced(debug, func(parser.TraceEvent) {})
+
+	_, err := p.Parse(strings.NewReader("ab,ac"))
+	require.NoError(t, err)
+	_, _, err = p.ParseBytes([]byte("x"))
+	require.Error(t, err)
+
+	totals := regexp.MustCompile(`time: [^\t]*`).ReplaceAllString(fmt.Sprint(debug), "time: duration")
+	assert.Equal(t, "calls: 2\ttime: duration\tbytes: 5\tsuccess: 1\terror: 1", totals)
+}
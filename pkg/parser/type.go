@@ -2,50 +2,92 @@ package parser
 
 import (
 	"github.com/roblovelock/gobble/pkg/errors"
+	"io"
 )
 
 type (
 	untypedParser[R Reader, T any] struct {
-		parser Parser[R, T]
+		parser Child[R, T]
 	}
 
 	typedParser[R Reader, T any] struct {
-		parser Parser[R, interface{}]
+		parser Child[R, interface{}]
 	}
 )
 
 func (o *untypedParser[R, T]) Parse(in R) (interface{}, error) {
-	return o.parser.Parse(in)
+	return o.ParseSession(NewSession(), in)
 }
 
 func (o *untypedParser[R, T]) ParseBytes(in []byte) (interface{}, []byte, error) {
-	return o.parser.ParseBytes(in)
+	return o.ParseBytesSession(NewBytesSession(in), in)
+}
+
+func (o *untypedParser[R, T]) ParseSession(s *Session, in R) (interface{}, error) {
+	return o.parser.ParseIn(s, in)
+}
+
+func (o *untypedParser[R, T]) ParseBytesSession(s *Session, in []byte) (interface{}, []byte, error) {
+	return o.parser.ParseBytesIn(s, in)
+}
+
+func (o *untypedParser[R, T]) Describe() Grammar {
+	return NewGrammar(GrammarSequence, o.parser)
 }
 
 func (o *typedParser[R, T]) Parse(in R) (T, error) {
-	r, err := o.parser.Parse(in)
+	return o.ParseSession(NewSession(), in)
+}
+
+func (o *typedParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
+	return o.ParseBytesSession(NewBytesSession(in), in)
+}
+
+func (o *typedParser[R, T]) ParseSession(s *Session, in R) (T, error) {
+	r, err := o.parser.ParseIn(s, in)
 	val, ok := r.(T)
 	if !ok {
 		var t T
-		return t, errors.ErrNotMatched
+		return t, NewError(in, errors.ErrNotMatched)
 	}
 	return val, err
 }
 
-func (o *typedParser[R, T]) ParseBytes(in []byte) (T, []byte, error) {
-	r, out, err := o.parser.ParseBytes(in)
+func (o *typedParser[R, T]) ParseBytesSession(s *Session, in []byte) (T, []byte, error) {
+	r, out, err := o.parser.ParseBytesIn(s, in)
 	val, ok := r.(T)
 	if !ok {
 		var t T
-		return t, in, errors.ErrNotMatched
+		return t, in, NewBytesError(in, errors.ErrNotMatched)
 	}
 	return val, out, err
 }
 
+func (o *typedParser[R, T]) Describe() Grammar {
+	return NewGrammar(GrammarSequence, o.parser)
+}
+
+func (o *untypedParser[R, T]) Expected() []string {
+	return ExpectedOf(o.parser)
+}
+
+func (o *typedParser[R, T]) Expected() []string {
+	return ExpectedOf(o.parser)
+}
+
+func (o *untypedParser[R, T]) Print(w io.Writer, v interface{}) error {
+	t, _ := v.(T)
+	return o.parser.Print(w, t)
+}
+
+func (o *typedParser[R, T]) Print(w io.Writer, v T) error {
+	return o.parser.Print(w, v)
+}
+
 func Untyped[R Reader, T any](p Parser[R, T]) Parser[R, interface{}] {
-	return &untypedParser[R, T]{parser: p}
+	return &untypedParser[R, T]{parser: NewChild(p)}
 }
 
 func Typed[R Reader, T any](p Parser[R, interface{}]) Parser[R, T] {
-	return &typedParser[R, T]{parser: p}
+	return &typedParser[R, T]{parser: NewChild(p)}
 }
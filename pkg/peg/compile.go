@@ -0,0 +1,303 @@
+package peg
+
+import (
+	"github.com/roblovelock/gobble/pkg/combinator"
+	"github.com/roblovelock/gobble/pkg/combinator/branch"
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/combinator/multi"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"io"
+)
+
+type (
+	// nodes is the parser of an expression, which returns the nodes of the rules matched within it.
+	nodes = parser.Parser[parser.Reader, []*Node]
+
+	// nodeParser matches the expression of a rule, and returns its node.
+	nodeParser struct {
+		rule   string
+		parser parser.Child[parser.Reader, []*Node]
+		action Action
+	}
+
+	compiler struct {
+		definitions map[string]*definition
+		rules       map[string]*parser.Parser[parser.Reader, *Node]
+		nullable    map[string]bool // rules which can match without consuming input
+	}
+)
+
+func (o *nodeParser) Parse(in parser.Reader) (*Node, error) {
+	return o.ParseSession(parser.NewSession(), in)
+}
+
+func (o *nodeParser) ParseBytes(in []byte) (*Node, []byte, error) {
+	return o.ParseBytesSession(parser.NewBytesSession(in), in)
+}
+
+func (o *nodeParser) ParseSession(s *parser.Session, in parser.Reader) (*Node, error) {
+	start, _ := in.Seek(0, io.SeekCurrent)
+	children, err := o.parser.ParseIn(s, in)
+	if err != nil {
+		return nil, err
+	}
+	end, _ := in.Seek(0, io.SeekCurrent)
+	_, _ = in.Seek(start, io.SeekStart)
+	text := make([]byte, end-start)
+	_, _ = io.ReadFull(in, text)
+
+	n := &Node{Rule: o.rule, Start: start, End: end, Text: text, Children: children}
+	if o.action != nil {
+		if n.Value, err = o.action(n); err != nil {
+			_, _ = in.Seek(start, io.SeekStart)
+			return nil, parser.NewError(in, err)
+		}
+	}
+	return n, nil
+}
+
+func (o *nodeParser) ParseBytesSession(s *parser.Session, in []byte) (*Node, []byte, error) {
+	children, out, err := o.parser.ParseBytesIn(s, in)
+	if err != nil {
+		return nil, in, err
+	}
+
+	n := &Node{
+		Rule:     o.rule,
+		Start:    s.Offset(in),
+		End:      s.Offset(out),
+		Text:     in[:len(in)-len(out)],
+		Children: children,
+	}
+	if o.action != nil {
+		if n.Value, err = o.action(n); err != nil {
+			return nil, in, parser.NewBytesError(in, err)
+		}
+	}
+	return n, out, nil
+}
+
+func (o *nodeParser) Describe() parser.Grammar {
+	return parser.NewGrammar(parser.GrammarSequence, o.parser)
+}
+
+func (o *nodeParser) Expected() []string {
+	return parser.ExpectedOf(o.parser)
+}
+
+// compile builds the parser of each rule. References are compiled to a parser.Pointer to the rule, so rules can refer
+// to rules defined after them, and to themselves.
+func compile(definitions []*definition, actions Actions) (*Grammar, error) {
+	c := &compiler{
+		definitions: make(map[string]*definition, len(definitions)),
+		rules:       make(map[string]*parser.Parser[parser.Reader, *Node], len(definitions)),
+	}
+	g := &Grammar{rules: c.rules}
+	for _, d := range definitions {
+		if _, ok := c.definitions[d.name]; ok {
+			return nil, errors.NewParseError(ErrDuplicateRule.Wrap(errors.Error(d.name)), d.offset, io.SeekStart)
+		}
+		c.definitions[d.name] = d
+		c.rules[d.name] = new(parser.Parser[parser.Reader, *Node])
+		g.names = append(g.names, d.name)
+	}
+	for name := range actions {
+		if _, ok := c.definitions[name]; !ok {
+			return nil, ErrUndefinedAction.Wrap(errors.Error(name))
+		}
+	}
+
+	c.nullable = c.findNullable()
+	leftRecursive := c.leftRecursive(definitions)
+	for _, d := range definitions {
+		body, err := c.compile(d.expr)
+		if err != nil {
+			return nil, err
+		}
+		var p parser.Parser[parser.Reader, *Node] = &nodeParser{
+			rule:   d.name,
+			parser: parser.NewChild(body),
+			action: actions[d.name],
+		}
+		if leftRecursive[d.name] {
+			p = parser.LeftRecursive(p)
+		}
+		*c.rules[d.name] = parser.Rule(d.name, p)
+	}
+	return g, nil
+}
+
+func (c *compiler) compile(e *expr) (nodes, error) {
+	switch e.kind {
+	case exprLiteral:
+		if len(e.literal) == 1 {
+			return discard(bytes.Byte(e.literal[0])), nil
+		}
+		return discard(bytes.Tag(e.literal)), nil
+	case exprClass:
+		if e.negated {
+			return discard(bytes.NotOneOf(e.set...)), nil
+		}
+		return discard(bytes.OneOf(e.set...)), nil
+	case exprAny:
+		return discard(bytes.One()), nil
+	case exprReference:
+		rule, ok := c.rules[e.name]
+		if !ok {
+			return nil, errors.NewParseError(ErrUndefinedRule.Wrap(errors.Error(e.name)), e.offset, io.SeekStart)
+		}
+		return modifier.Map(parser.Pointer(rule), func(n *Node) ([]*Node, error) {
+			return []*Node{n}, nil
+		}), nil
+	}
+
+	children := make([]nodes, len(e.children))
+	for i, child := range e.children {
+		p, err := c.compile(child)
+		if err != nil {
+			return nil, err
+		}
+		children[i] = p
+	}
+
+	switch e.kind {
+	case exprSequence:
+		if len(children) == 0 {
+			return combinator.Success[parser.Reader, []*Node](nil), nil
+		}
+		return modifier.Map(sequence.Tuple(children...), flatten), nil
+	case exprChoice:
+		return branch.Alt(children...), nil
+	case exprRepeat:
+		if e.max != 1 && c.isNullable(e.children[0], c.nullable) {
+			// the repetition would stop on its first match, which consumed no input
+			return nil, errors.NewParseError(ErrNullableRepeat, e.offset, io.SeekStart)
+		}
+		switch {
+		case e.max == 1:
+			return modifier.Optional(children[0]), nil
+		case e.min == 0:
+			return modifier.Map(multi.Many0(children[0]), flatten), nil
+		default:
+			return modifier.Map(multi.Many1(children[0]), flatten), nil
+		}
+	case exprNot:
+		return discard(modifier.Not(children[0])), nil
+	default:
+		return discard(modifier.Peek(children[0])), nil
+	}
+}
+
+// leftRecursive finds the rules which can be called again at the position they started, without consuming input.
+// Each cycle of such calls is broken at the first rule reached, in the order the rules are defined.
+func (c *compiler) leftRecursive(definitions []*definition) map[string]bool {
+	calls := make(map[string][]string, len(definitions))
+	for _, d := range definitions {
+		calls[d.name] = c.leftCalls(d.expr, c.nullable, nil)
+	}
+
+	result := map[string]bool{}
+	active := map[string]bool{}
+	done := map[string]bool{}
+	var visit func(name string)
+	visit = func(name string) {
+		if active[name] {
+			result[name] = true
+			return
+		}
+		if done[name] {
+			return
+		}
+		active[name] = true
+		for _, callee := range calls[name] {
+			visit(callee)
+		}
+		delete(active, name)
+		done[name] = true
+	}
+	for _, d := range definitions {
+		visit(d.name)
+	}
+	return result
+}
+
+// leftCalls appends the rules the expression can call before it consumes any input.
+func (c *compiler) leftCalls(e *expr, nullable map[string]bool, calls []string) []string {
+	switch e.kind {
+	case exprReference:
+		if _, ok := c.definitions[e.name]; ok {
+			calls = append(calls, e.name)
+		}
+	case exprSequence:
+		for _, child := range e.children {
+			calls = c.leftCalls(child, nullable, calls)
+			if !c.isNullable(child, nullable) {
+				break
+			}
+		}
+	case exprChoice, exprRepeat, exprNot, exprPeek:
+		for _, child := range e.children {
+			calls = c.leftCalls(child, nullable, calls)
+		}
+	}
+	return calls
+}
+
+// findNullable finds the rules which can match without consuming input.
+func (c *compiler) findNullable() map[string]bool {
+	nullable := map[string]bool{}
+	for changed := true; changed; {
+		changed = false
+		for name, d := range c.definitions {
+			if !nullable[name] && c.isNullable(d.expr, nullable) {
+				nullable[name] = true
+				changed = true
+			}
+		}
+	}
+	return nullable
+}
+
+func (c *compiler) isNullable(e *expr, nullable map[string]bool) bool {
+	switch e.kind {
+	case exprLiteral:
+		return len(e.literal) == 0
+	case exprClass, exprAny:
+		return false
+	case exprReference:
+		return nullable[e.name]
+	case exprSequence:
+		for _, child := range e.children {
+			if !c.isNullable(child, nullable) {
+				return false
+			}
+		}
+		return true
+	case exprChoice:
+		for _, child := range e.children {
+			if c.isNullable(child, nullable) {
+				return true
+			}
+		}
+		return false
+	case exprRepeat:
+		return e.min == 0 || c.isNullable(e.children[0], nullable)
+	}
+	return true
+}
+
+// discard matches the parser without returning any nodes.
+func discard[T any](p parser.Parser[parser.Reader, T]) nodes {
+	return modifier.Value[parser.Reader, T, []*Node](p, nil)
+}
+
+func flatten(groups [][]*Node) ([]*Node, error) {
+	var result []*Node
+	for _, g := range groups {
+		result = append(result, g...)
+	}
+	return result, nil
+}
@@ -0,0 +1,130 @@
+// Package peg compiles grammars written as text into parsers, so small formats can be defined in configuration.
+//
+// A grammar is a list of rules written in PEG notation, with the EBNF forms `=`, `::=`, `|` and `;` also accepted:
+//
+//	sum    <- number (('+' / '-') number)*
+//	number <- '-'? [0-9]+
+//
+// Expressions are literals in single or double quotes, character classes such as [a-z_] or [^"], '.' for any byte,
+// references to other rules and brackets, followed by ?, * or + to repeat them, or preceded by & or ! to look ahead
+// without consuming input. Alternatives separated by / are ordered: the first which matches is chosen. Literals and
+// classes may contain the escapes \n, \r, \t and \xNN, and classes may also contain #xNN. Comments start with # or //.
+//
+// The first rule is the start of the grammar. It doesn't have to match all of the input, unless its expression ends
+// with `!.`, which only matches at the end of the input.
+//
+// Each rule compiles to a parser.Rule of the combinators for its expression, so compiled grammars can be traced,
+// profiled and documented in the same way as grammars written in Go. Rules which refer to themselves at the start of
+// their expression, such as `expr <- expr '+' term / term`, are made parser.LeftRecursive.
+package peg
+
+import (
+	"fmt"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"strings"
+)
+
+const (
+	ErrUndefinedRule   errors.Error = "undefined rule"            // an expression refers to a rule which isn't defined
+	ErrDuplicateRule   errors.Error = "duplicate rule"            // a rule is defined more than once
+	ErrUndefinedAction errors.Error = "action for undefined rule" // an action is given for a rule which isn't defined
+	ErrInvalidRange    errors.Error = "invalid class range"       // a character class range ends before it starts
+	ErrNullableRepeat  errors.Error = "nullable repetition"       // * or + repeats an expression matching no input
+)
+
+type (
+	// Node is a match of a rule in the parse tree. The children are the matches of the rules within it, in order.
+	// Input matched by literals and classes is only recorded in the Text of the rule containing it.
+	Node struct {
+		Rule string
+		// Start is the offset of the match. For ParseBytes it is relative to the input passed to the grammar.
+		Start int64
+		// End is the offset after the match.
+		End int64
+		// Text is the matched input. Parse reads it again from the reader once the rule has matched, which costs a read
+		// of the input for every rule it's nested in, while ParseBytes slices it from the input.
+		Text     []byte
+		Children []*Node
+		// Value is the result of the action of the rule, if it has one.
+		Value any
+	}
+
+	// Action is called with the node of each match of a rule, after the children have been matched, and returns the
+	// Value of the node. If it returns an error the rule doesn't match, so the action can reject a match and let the
+	// grammar try an alternative. Errors made with errors.NewFatalError stop the parse.
+	Action func(n *Node) (any, error)
+
+	// Actions are the actions of the rules of a grammar, keyed by rule name.
+	Actions map[string]Action
+
+	// Grammar is a compiled grammar.
+	Grammar struct {
+		rules map[string]*parser.Parser[parser.Reader, *Node]
+		names []string
+	}
+)
+
+// Compile compiles the grammar source into parsers. The actions are called for each match of the rule they are keyed
+// by, and may be nil. Errors in the source are returned as errors.ParseError, with the offset of the error in the
+// source.
+func Compile(source string, actions Actions) (*Grammar, error) {
+	definitions, err := syntaxGrammar.Parse(strings.NewReader(source))
+	if err != nil {
+		return nil, err
+	}
+	return compile(definitions, actions)
+}
+
+// MustCompile is Compile, but panics if the grammar can't be compiled. It simplifies initialising package variables
+// with grammars which are known to be valid.
+func MustCompile(source string, actions Actions) *Grammar {
+	g, err := Compile(source, actions)
+	if err != nil {
+		panic("peg: Compile: " + err.Error())
+	}
+	return g
+}
+
+// Parser returns the parser of the first rule of the grammar.
+func (g *Grammar) Parser() parser.Parser[parser.Reader, *Node] {
+	p, _ := g.Rule(g.names[0])
+	return p
+}
+
+// Rule returns the parser of the rule, so any rule of the grammar can be used as a start rule or within other
+// parsers. It returns false if the grammar doesn't define the rule.
+func (g *Grammar) Rule(name string) (parser.Parser[parser.Reader, *Node], bool) {
+	p, ok := g.rules[name]
+	if !ok {
+		return nil, false
+	}
+	return *p, true
+}
+
+// Rules returns the names of the rules of the grammar, in the order they are defined.
+func (g *Grammar) Rules() []string {
+	return append([]string(nil), g.names...)
+}
+
+// String formats the node and its children as rule@start-end[children...], such as sum@0-5[number@0-1 number@4-5].
+func (n *Node) String() string {
+	var b strings.Builder
+	n.format(&b)
+	return b.String()
+}
+
+func (n *Node) format(b *strings.Builder) {
+	_, _ = fmt.Fprintf(b, "%s@%d-%d", n.Rule, n.Start, n.End)
+	if len(n.Children) == 0 {
+		return
+	}
+	b.WriteByte('[')
+	for i, c := range n.Children {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		c.format(b)
+	}
+	b.WriteByte(']')
+}
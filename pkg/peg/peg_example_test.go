@@ -0,0 +1,40 @@
+package peg_test
+
+import (
+	"fmt"
+	"github.com/roblovelock/gobble/pkg/peg"
+	"strconv"
+	"strings"
+)
+
+func ExampleCompile() {
+	g, err := peg.Compile(`
+		sum    <- number ('+' number)* !.
+		number <- [0-9]+
+	`, peg.Actions{
+		"sum": func(n *peg.Node) (any, error) {
+			total := 0
+			for _, c := range n.Children {
+				total += c.Value.(int)
+			}
+			return total, nil
+		},
+		"number": func(n *peg.Node) (any, error) {
+			return strconv.Atoi(string(n.Text))
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	n, err := g.Parser().Parse(strings.NewReader("1+22+300"))
+	fmt.Println(n, n.Value, err)
+
+	_, err = g.Parser().Parse(strings.NewReader("1+x"))
+	fmt.Println(err)
+
+	// Output:
+	// sum@0-8[number@0-1 number@2-4 number@5-8] 323 <nil>
+	// not matched at offset 1
+}
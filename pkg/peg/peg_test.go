@@ -0,0 +1,332 @@
+package peg_test
+
+import (
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/grammar"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/peg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name      string
+		source    string
+		input     string
+		want      string
+		wantText  string
+		remaining string
+		wantErr   string
+	}{
+		{
+			name:     "literal",
+			source:   `greeting <- 'hello' ' ' "world"`,
+			input:    "hello world",
+			want:     "greeting@0-11",
+			wantText: "hello world",
+		},
+		{
+			name:     "references => children",
+			source:   "pair <- key '=' value\nkey <- [a-z]+\nvalue <- [0-9]+",
+			input:    "abc=123",
+			want:     "pair@0-7[key@0-3 value@4-7]",
+			wantText: "abc=123",
+		},
+		{
+			name:     "ebnf notation",
+			source:   "pair ::= key '=' value;\nkey = [a-z]+;\nvalue ::= [0-9]+ | 'none';",
+			input:    "abc=none",
+			want:     "pair@0-8[key@0-3 value@4-8]",
+			wantText: "abc=none",
+		},
+		{
+			name:      "ordered choice => first match",
+			source:    "word <- 'a' / 'ab'",
+			input:     "ab",
+			want:      "word@0-1",
+			wantText:  "a",
+			remaining: "b",
+		},
+		{
+			name:     "repetition and option",
+			source:   "list <- item (',' item)* ','?\nitem <- [a-z]",
+			input:    "a,b,c,",
+			want:     "list@0-6[item@0-1 item@2-3 item@4-5]",
+			wantText: "a,b,c,",
+		},
+		{
+			name:     "negated class and escapes",
+			source:   `string <- '"' ([^"\\] / '\\' .)* '"'`,
+			input:    `"a\"b"`,
+			want:     "string@0-6",
+			wantText: `"a\"b"`,
+		},
+		{
+			name:     "hex bytes",
+			source:   `bytes <- '\x01' [#x02-#x03] [\x04]`,
+			input:    "\x01\x03\x04",
+			want:     "bytes@0-3",
+			wantText: "\x01\x03\x04",
+		},
+		{
+			name:    "not predicate",
+			source:  "keyword <- !'if' [a-z]+",
+			input:   "if",
+			wantErr: "not matched at offset 0",
+		},
+		{
+			name:      "and predicate => no input consumed",
+			source:    "start <- &'a' [a-z]",
+			input:     "ab",
+			want:      "start@0-1",
+			wantText:  "a",
+			remaining: "b",
+		},
+		{
+			name:    "end of input",
+			source:  "all <- 'a'* !.",
+			input:   "aab",
+			wantErr: "not matched at offset 2",
+		},
+		{
+			name: "comments",
+			source: `# a list of digits
+				digits <- digit+ // one or more
+				digit <- [0-9]`,
+			input:    "12",
+			want:     "digits@0-2[digit@0-1 digit@1-2]",
+			wantText: "12",
+		},
+		{
+			name:     "left recursion",
+			source:   "sum <- sum '+' num / num\nnum <- [0-9]",
+			input:    "1+2+3",
+			want:     "sum@0-5[sum@0-3[sum@0-1[num@0-1] num@2-3] num@4-5]",
+			wantText: "1+2+3",
+		},
+		{
+			name:    "no match",
+			source:  "ab <- 'a' / 'b'",
+			input:   "c",
+			wantErr: "expected 'a' or 'b' at offset 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := peg.Compile(tt.source, nil)
+			require.NoError(t, err)
+
+			in := strings.NewReader(tt.input)
+			n, err := g.Parser().Parse(in)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, n)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, n.String())
+				assert.Equal(t, tt.wantText, string(n.Text))
+			}
+			remaining, _ := io.ReadAll(in)
+			if tt.wantErr != "" {
+				assert.Equal(t, tt.input, string(remaining))
+			} else {
+				assert.Equal(t, tt.remaining, string(remaining))
+			}
+
+			n, out, err := g.Parser().ParseBytes([]byte(tt.input))
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Nil(t, n)
+				assert.Equal(t, tt.input, string(out))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, n.String())
+				assert.Equal(t, tt.wantText, string(n.Text))
+				assert.Equal(t, tt.remaining, string(out))
+			}
+		})
+	}
+}
+
+func TestCompile_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		actions peg.Actions
+		wantErr string
+		is      error
+	}{
+		{
+			name:    "undefined rule",
+			source:  "a <- b",
+			wantErr: "undefined rule: b at offset 5",
+			is:      peg.ErrUndefinedRule,
+		},
+		{
+			name:    "duplicate rule",
+			source:  "a <- 'a'\na <- 'b'",
+			wantErr: "duplicate rule: a at offset 9",
+			is:      peg.ErrDuplicateRule,
+		},
+		{
+			name:    "action for undefined rule",
+			source:  "a <- 'a'",
+			actions: peg.Actions{"b": func(*peg.Node) (any, error) { return nil, nil }},
+			wantErr: "action for undefined rule: b",
+			is:      peg.ErrUndefinedAction,
+		},
+		{
+			name:    "invalid range",
+			source:  "a <- [z-a]",
+			wantErr: "invalid class range at offset 6",
+			is:      peg.ErrInvalidRange,
+		},
+		{
+			name:    "nullable repetition",
+			source:  "a <- 'a' (b?)*\nb <- 'b'",
+			wantErr: "nullable repetition at offset 9",
+			is:      peg.ErrNullableRepeat,
+		},
+		{
+			name:    "nullable rule repetition",
+			source:  "a <- 'a' b+\nb <- 'b'*",
+			wantErr: "nullable repetition at offset 9",
+			is:      peg.ErrNullableRepeat,
+		},
+		{
+			name:    "unterminated literal",
+			source:  "a <- 'abc\nb <- 'b'",
+			wantErr: `expected '\'' at offset 9`,
+		},
+		{
+			name:    "unclosed bracket",
+			source:  "a <- ('a' 'b'",
+			wantErr: "unexpected end of input, expected ')' at offset 13",
+		},
+		{
+			name:    "unexpected input",
+			source:  "a <- 'a' )",
+			wantErr: "expected end of input at offset 9",
+		},
+		{
+			name:    "no rules",
+			source:  "# nothing",
+			wantErr: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := peg.Compile(tt.source, tt.actions)
+			assert.Nil(t, g)
+			require.Error(t, err)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			if tt.is != nil {
+				assert.ErrorIs(t, err, tt.is)
+			}
+		})
+	}
+}
+
+func TestCompile_actions(t *testing.T) {
+	g, err := peg.Compile(`
+		sum    <- number ('+' number)*
+		number <- [0-9]+
+	`, peg.Actions{
+		"sum": func(n *peg.Node) (any, error) {
+			total := 0
+			for _, c := range n.Children {
+				total += c.Value.(int)
+			}
+			return total, nil
+		},
+		"number": func(n *peg.Node) (any, error) {
+			return strconv.Atoi(string(n.Text))
+		},
+	})
+	require.NoError(t, err)
+
+	n, err := g.Parser().Parse(strings.NewReader("1+22+300"))
+	require.NoError(t, err)
+	assert.Equal(t, 323, n.Value)
+
+	n, _, err = g.Parser().ParseBytes([]byte("1+22+300"))
+	require.NoError(t, err)
+	assert.Equal(t, 323, n.Value)
+}
+
+func TestCompile_actionError(t *testing.T) {
+	small := errors.Error("too big")
+	g, err := peg.Compile(`
+		value <- small / word
+		small <- [0-9]+
+		word  <- [0-9a-z]+
+	`, peg.Actions{
+		"small": func(n *peg.Node) (any, error) {
+			if len(n.Text) > 2 {
+				return nil, small
+			}
+			return nil, nil
+		},
+	})
+	require.NoError(t, err)
+
+	n, err := g.Parser().Parse(strings.NewReader("12"))
+	require.NoError(t, err)
+	assert.Equal(t, "value@0-2[small@0-2]", n.String())
+
+	n, err = g.Parser().Parse(strings.NewReader("123"))
+	require.NoError(t, err)
+	assert.Equal(t, "value@0-3[word@0-3]", n.String())
+
+	fatal, err := peg.Compile("value <- [0-9]+", peg.Actions{
+		"value": func(*peg.Node) (any, error) { return nil, errors.NewFatalError(small) },
+	})
+	require.NoError(t, err)
+	_, err = fatal.Parser().Parse(strings.NewReader("1"))
+	assert.ErrorIs(t, err, small)
+	assert.True(t, errors.IsFatal(err))
+}
+
+func TestGrammar_Rule(t *testing.T) {
+	g := peg.MustCompile("pair <- key '=' key\nkey <- [a-z]+", nil)
+	assert.Equal(t, []string{"pair", "key"}, g.Rules())
+
+	key, ok := g.Rule("key")
+	require.True(t, ok)
+	n, err := key.Parse(strings.NewReader("abc=d"))
+	require.NoError(t, err)
+	assert.Equal(t, "key@0-3", n.String())
+
+	_, ok = g.Rule("value")
+	assert.False(t, ok)
+}
+
+func TestMustCompile_panics(t *testing.T) {
+	assert.PanicsWithValue(t, "peg: Compile: undefined rule: b at offset 5", func() {
+		peg.MustCompile("a <- b", nil)
+	})
+}
+
+func TestGrammar_tools(t *testing.T) {
+	g := peg.MustCompile("list <- '[' (item (',' item)*)? ']'\nitem <- [0-9]+ / list", nil)
+
+	var ebnf strings.Builder
+	require.NoError(t, grammar.WriteEBNF(&ebnf, g.Parser()))
+	assert.Equal(t, "list ::= '[' (item (',' item)*)? ']'\nitem ::= [0-9]+ | list\n", ebnf.String())
+
+	profiler := parser.NewProfiler()
+	_, err := parser.Traced(g.Parser(), profiler.Trace).Parse(strings.NewReader("[1,[2]]"))
+	require.NoError(t, err)
+	calls := map[string]int{}
+	for _, r := range profiler.Rules() {
+		calls[r.Rule] = r.Calls
+	}
+	assert.Equal(t, map[string]int{"list": 2, "item": 3}, calls)
+}
@@ -0,0 +1,249 @@
+package peg
+
+import (
+	"github.com/roblovelock/gobble/pkg/combinator/branch"
+	"github.com/roblovelock/gobble/pkg/combinator/modifier"
+	"github.com/roblovelock/gobble/pkg/combinator/multi"
+	"github.com/roblovelock/gobble/pkg/combinator/sequence"
+	"github.com/roblovelock/gobble/pkg/errors"
+	"github.com/roblovelock/gobble/pkg/parser"
+	"github.com/roblovelock/gobble/pkg/parser/ascii"
+	"github.com/roblovelock/gobble/pkg/parser/bytes"
+	"github.com/roblovelock/gobble/pkg/parser/stream"
+	"strconv"
+)
+
+const (
+	exprLiteral exprKind = iota
+	exprClass
+	exprAny
+	exprReference
+	exprSequence
+	exprChoice
+	exprRepeat
+	exprNot
+	exprPeek
+)
+
+type (
+	exprKind int
+
+	// expr is an expression of a grammar, as written in the source.
+	expr struct {
+		kind exprKind
+		// offset is where the expression starts in the source, used to report references to undefined rules and
+		// repetitions which can match empty input.
+		offset   int64
+		name     string
+		literal  []byte
+		set      []byte
+		negated  bool
+		min      int
+		max      int
+		children []*expr
+	}
+
+	// definition is a rule of a grammar, as written in the source.
+	definition struct {
+		name   string
+		offset int64
+		expr   *expr
+	}
+
+	// classRange is a range of bytes in a character class, such as a-z.
+	classRange struct {
+		from, to byte
+	}
+)
+
+// The syntax of a grammar:
+//
+//	grammar    <- spacing definition+ !.
+//	definition <- identifier ('<-' | '::=' | '=') expression ';'?
+//	expression <- sequence (('/' | '|') sequence)*
+//	sequence   <- prefix*
+//	prefix     <- ('&' | '!') suffix | suffix
+//	suffix     <- primary ('?' | '*' | '+')?
+//	primary    <- identifier !arrow | '(' expression ')' | literal | class | '.'
+//
+// Tokens are followed by spacing, which is whitespace and comments starting with # or //.
+var (
+	syntaxExpression    parser.Parser[parser.Reader, *expr]
+	syntaxExpressionPtr = parser.Pointer(&syntaxExpression)
+
+	syntaxComment = modifier.Value[parser.Reader, []byte, parser.Empty](sequence.Preceded(
+		branch.Alt(bytes.Tag([]byte("#")), bytes.Tag([]byte("//"))),
+		bytes.TakeWhile(func(b byte) bool { return b != '\n' }),
+	), nil)
+	syntaxSpacing = multi.Many0(branch.Alt(ascii.SkipWhitespace1(), syntaxComment))
+
+	syntaxIdentifier = token(sequence.Spanned(modifier.Map(
+		sequence.Recognize(sequence.Pair(
+			bytes.OneOf(identifierStart()...),
+			bytes.TakeWhile(isIdentifier),
+		)),
+		func(b []byte) (string, error) { return string(b), nil },
+	)))
+	syntaxArrow = token(branch.Alt(bytes.Tag([]byte("<-")), bytes.Tag([]byte("::=")), bytes.Tag([]byte("="))))
+
+	// syntaxEscape is a backslash escape in a literal or class, such as \n or \x7f.
+	syntaxEscape = sequence.Preceded(bytes.Byte('\\'), modifier.Cut(branch.Alt(
+		modifier.Map(bytes.OneOf('n', 'r', 't', '\\', '\'', '"', '[', ']', '-', '^'), unescape),
+		sequence.Preceded(bytes.Byte('x'), hexByte),
+	)))
+
+	syntaxLiteral = token(branch.Alt(quoted('\''), quoted('"')))
+
+	// syntaxClassByte is a byte in a character class, which may be written as #xNN, as in the W3C notation.
+	syntaxClassByte = branch.Alt(
+		syntaxEscape,
+		sequence.Preceded(bytes.Tag([]byte("#x")), hexByte),
+		bytes.NotOneOf(']', '\\', '\n'),
+	)
+	syntaxClassRange = modifier.Map(
+		sequence.Pair(syntaxClassByte, modifier.Optional(sequence.Preceded(bytes.Byte('-'), syntaxClassByte))),
+		func(p parser.Pair[byte, byte]) (classRange, error) {
+			r := classRange{from: p.First, to: p.First}
+			if p.Second != 0 {
+				r.to = p.Second
+			}
+			if r.to < r.from {
+				return r, errors.NewFatalError(ErrInvalidRange)
+			}
+			return r, nil
+		},
+	)
+	syntaxClass = token(modifier.Map(
+		sequence.Preceded(bytes.Byte('['), modifier.Cut(sequence.Terminated(
+			sequence.Pair(modifier.Optional(bytes.Byte('^')), multi.Many0(syntaxClassRange)),
+			bytes.Byte(']'),
+		))),
+		func(p parser.Pair[byte, []classRange]) (*expr, error) {
+			e := &expr{kind: exprClass, negated: p.First == '^'}
+			for _, r := range p.Second {
+				for b := int(r.from); b <= int(r.to); b++ {
+					e.set = append(e.set, byte(b))
+				}
+			}
+			return e, nil
+		},
+	))
+
+	syntaxPrimary = branch.Alt(
+		modifier.Map(
+			sequence.Terminated(syntaxIdentifier, modifier.Not(syntaxArrow)),
+			func(s parser.Spanned[string]) (*expr, error) {
+				return &expr{kind: exprReference, name: s.Value, offset: s.Start}, nil
+			},
+		),
+		sequence.Preceded(token(bytes.Byte('(')), modifier.Cut(sequence.Terminated(
+			syntaxExpressionPtr, token(bytes.Byte(')')),
+		))),
+		modifier.Map(syntaxLiteral, func(b []byte) (*expr, error) {
+			return &expr{kind: exprLiteral, literal: b}, nil
+		}),
+		syntaxClass,
+		modifier.Value(token(bytes.Byte('.')), &expr{kind: exprAny}),
+	)
+	syntaxSuffix = modifier.Map(
+		sequence.Spanned(sequence.Pair(syntaxPrimary, modifier.Optional(token(bytes.OneOf('?', '*', '+'))))),
+		func(s parser.Spanned[parser.Pair[*expr, byte]]) (*expr, error) {
+			p := s.Value
+			switch p.Second {
+			case '?':
+				return &expr{kind: exprRepeat, offset: s.Start, min: 0, max: 1, children: []*expr{p.First}}, nil
+			case '*':
+				return &expr{kind: exprRepeat, offset: s.Start, min: 0, max: -1, children: []*expr{p.First}}, nil
+			case '+':
+				return &expr{kind: exprRepeat, offset: s.Start, min: 1, max: -1, children: []*expr{p.First}}, nil
+			}
+			return p.First, nil
+		},
+	)
+	syntaxPrefix = branch.Alt(
+		modifier.Map(
+			sequence.Pair(token(bytes.OneOf('&', '!')), modifier.Cut(syntaxSuffix)),
+			func(p parser.Pair[byte, *expr]) (*expr, error) {
+				if p.First == '!' {
+					return &expr{kind: exprNot, children: []*expr{p.Second}}, nil
+				}
+				return &expr{kind: exprPeek, children: []*expr{p.Second}}, nil
+			},
+		),
+		syntaxSuffix,
+	)
+	syntaxSequence = modifier.Map(multi.Many0(syntaxPrefix), func(es []*expr) (*expr, error) {
+		if len(es) == 1 {
+			return es[0], nil
+		}
+		return &expr{kind: exprSequence, children: es}, nil
+	})
+
+	syntaxDefinition = modifier.Map(
+		sequence.Pair(
+			sequence.Terminated(syntaxIdentifier, syntaxArrow),
+			sequence.Terminated(syntaxExpressionPtr, modifier.Optional(token(bytes.Byte(';')))),
+		),
+		func(p parser.Pair[parser.Spanned[string], *expr]) (*definition, error) {
+			return &definition{name: p.First.Value, offset: p.First.Start, expr: p.Second}, nil
+		},
+	)
+	syntaxGrammar = sequence.Delimited(syntaxSpacing, multi.Many1(syntaxDefinition), modifier.Cut(stream.EOF()))
+)
+
+func init() {
+	syntaxExpression = modifier.Map(
+		multi.Separated1(syntaxSequence, token(bytes.OneOf('/', '|'))),
+		func(es []*expr) (*expr, error) {
+			if len(es) == 1 {
+				return es[0], nil
+			}
+			return &expr{kind: exprChoice, children: es}, nil
+		},
+	)
+}
+
+// token skips the spacing after the parser.
+func token[T any](p parser.Parser[parser.Reader, T]) parser.Parser[parser.Reader, T] {
+	return sequence.Terminated(p, syntaxSpacing)
+}
+
+// quoted is a literal between the quotes, which can't span lines.
+func quoted(quote byte) parser.Parser[parser.Reader, []byte] {
+	return sequence.Preceded(bytes.Byte(quote), modifier.Cut(sequence.Terminated(
+		multi.Many0(branch.Alt(syntaxEscape, bytes.NotOneOf(quote, '\\', '\n'))),
+		bytes.Byte(quote),
+	)))
+}
+
+var hexByte = modifier.Map(
+	bytes.TakeWhileMinMax(2, 2, ascii.IsHexDigit),
+	func(b []byte) (byte, error) {
+		v, err := strconv.ParseUint(string(b), 16, 8)
+		return byte(v), err
+	},
+)
+
+func unescape(b byte) (byte, error) {
+	switch b {
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 't':
+		return '\t', nil
+	}
+	return b, nil
+}
+
+func identifierStart() []byte {
+	start := []byte{'_'}
+	for b := byte('a'); b <= 'z'; b++ {
+		start = append(start, b, b-'a'+'A')
+	}
+	return start
+}
+
+func isIdentifier(b byte) bool {
+	return b == '_' || b == '-' || ascii.IsAlphanumeric(b)
+}